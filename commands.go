@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/migrate"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/seed"
+	"salesforge-assignment/internal/service"
+	"salesforge-assignment/migrations"
+	"strings"
+)
+
+const defaultFixture = "init-db/seed.yaml"
+
+// usageError reports a malformed command line and exits.
+func usageError(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n\n%s", append(args, usage)...)
+	os.Exit(2)
+}
+
+// migrateCommand runs "server migrate up|down|status|check [flags]".
+func migrateCommand(args []string) {
+	if len(args) == 0 {
+		usageError("migrate needs up, down, status or check")
+	}
+	action, args := args[0], args[1:]
+	flags, printConfig := commandFlags("migrate "+action, flag.ExitOnError)
+	var steps int
+	switch action {
+	case "up", "status", "check":
+	case "down":
+		flags.IntVar(&steps, "steps", 1, "number of migrations to revert")
+	default:
+		usageError("Unknown migrate action %q", action)
+	}
+
+	_, log, db := setupCommand(flags, printConfig, args)
+	all, err := migrations.All()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to read the embedded migrations")
+	}
+	migrationRepo := repository.NewMigrationRepository(log, db)
+	migrator := migrate.NewMigrator(log, migrationRepo, all)
+	ctx := log.WithContext(context.Background())
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Int("applied", applied).Msg("Failed to apply migrations")
+		}
+		log.Info().Int("applied", applied).Msg("Schema is up to date")
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatal().Err(err).Int("reverted", reverted).Msg("Failed to revert migrations")
+		}
+		log.Info().Int("reverted", reverted).Msg("Reverted migrations")
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to read the schema version")
+		}
+		printMigrationStatus(os.Stdout, status)
+	case "check":
+		drifts, err := migrate.CheckDrift(ctx, migrationRepo, model.Tables()...)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to compare the models with the schema")
+		}
+		for _, drift := range drifts {
+			fmt.Println(drift)
+		}
+		if len(drifts) > 0 {
+			os.Exit(1)
+		}
+	}
+}
+
+func printMigrationStatus(w io.Writer, status migrate.Status) {
+	fmt.Fprintf(w, "Version: %d\n", status.Version)
+	fmt.Fprintf(w, "Dirty:   %t\n", status.Dirty)
+	fmt.Fprintf(w, "Pending: %d\n", len(status.Pending))
+	for _, migration := range status.Pending {
+		fmt.Fprintf(w, "  %s\n", migration.Name)
+	}
+}
+
+// seedCommand runs "server seed [flags]".
+func seedCommand(args []string) {
+	flags, printConfig := commandFlags("seed", flag.ExitOnError)
+	fixturePath := flags.String("fixture", defaultFixture, "YAML `file` of the users and forms to create")
+
+	cfg, log, db := setupCommand(flags, printConfig, args)
+	fixture, err := seed.LoadFixture(*fixturePath, os.LookupEnv)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to read the fixture")
+	}
+
+	credentialsRepo := repository.NewCredentialsRepository(log, db)
+	formRepo := repository.NewFormRepository(log, db)
+	outboxRepo := repository.NewOutboxRepository(log, db)
+	txManager := repository.NewTxManager(log, db)
+	seeder := seed.NewSeeder(log,
+		service.NewUserService(credentialsRepo),
+		service.NewFormService(log, credentialsRepo, formRepo, outboxRepo, txManager, cfg),
+		formRepo,
+	)
+	if err := seeder.Seed(log.WithContext(context.Background()), fixture); err != nil {
+		log.Fatal().Err(err).Msg("Failed to seed the database")
+	}
+	log.Info().Str("fixture", *fixturePath).Msg("Database seeded")
+}
+
+// userCommand runs "server user create|reset-password|disable [flags]
+// <username>". Passwords are read from the standard input, so that they do
+// not show in the process list or the shell history.
+func userCommand(args []string) {
+	if len(args) == 0 {
+		usageError("user needs create, reset-password or disable")
+	}
+	action, args := args[0], args[1:]
+	switch action {
+	case "create", "reset-password", "disable":
+	default:
+		usageError("Unknown user action %q", action)
+	}
+
+	flags, printConfig := commandFlags("user "+action, flag.ExitOnError)
+	_, log, db := setupCommand(flags, printConfig, args)
+	if flags.NArg() != 1 {
+		usageError("user %s needs a username after the flags", action)
+	}
+	username := flags.Arg(0)
+	users := service.NewUserService(repository.NewCredentialsRepository(log, db))
+	ctx := log.WithContext(context.Background())
+
+	var err error
+	switch action {
+	case "create", "reset-password":
+		var password string
+		password, err = readPassword(os.Stdin, os.Stderr)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to read the password")
+		}
+		if action == "create" {
+			err = users.CreateUser(ctx, username, password)
+		} else {
+			err = users.ResetPassword(ctx, username, password)
+		}
+	case "disable":
+		err = users.DisableUser(ctx, username)
+	}
+	if err != nil {
+		log.Fatal().Err(err).EmbedObject(logger.Fields{"username": username}).Msgf("Failed to %s the user", strings.ReplaceAll(action, "-", " "))
+	}
+}
+
+// readPassword reads a password without echoing it from a terminal, or the
+// first line of piped input.
+func readPassword(in *os.File, prompt io.Writer) (string, error) {
+	if fd := int(in.Fd()); term.IsTerminal(fd) {
+		fmt.Fprint(prompt, "Password: ")
+		password, err := term.ReadPassword(fd)
+		fmt.Fprintln(prompt)
+		return string(password), err
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
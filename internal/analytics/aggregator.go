@@ -0,0 +1,69 @@
+package analytics
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"salesforge-assignment/internal/repository"
+	"time"
+)
+
+const defaultRollupInterval = time.Minute
+
+// Aggregator periodically rolls raw tracking events of completed hours up
+// into the hourly rollup table so analytics queries only need to scan the
+// raw events of the current hour.
+type Aggregator struct {
+	log        *zerolog.Logger
+	repository repository.AnalyticsRepository
+	interval   time.Duration
+}
+
+func NewAggregator(
+	log *zerolog.Logger,
+	repository repository.AnalyticsRepository,
+	interval time.Duration,
+) *Aggregator {
+	if interval <= 0 {
+		interval = defaultRollupInterval
+	}
+
+	return &Aggregator{
+		log:        log,
+		repository: repository,
+		interval:   interval,
+	}
+}
+
+// Run rolls up events until the context is cancelled.
+func (a *Aggregator) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	a.log.Info().Dur("interval", a.interval).Msg("Analytics aggregator started")
+	for {
+		a.Rollup(ctx)
+
+		select {
+		case <-ctx.Done():
+			a.log.Info().Msg("Analytics aggregator stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Aggregator) Rollup(ctx context.Context) {
+	until := time.Now().UTC().Truncate(time.Hour)
+
+	from, err := a.repository.RollupEvents(ctx, until)
+	if err != nil {
+		if ctx.Err() == nil {
+			a.log.Error().Err(err).Msg("Failed to roll up tracking events")
+		}
+		return
+	}
+
+	if from.Before(until) {
+		a.log.Debug().Time("from", from).Time("until", until).Msg("Rolled up tracking events")
+	}
+}
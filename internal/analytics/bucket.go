@@ -0,0 +1,79 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	BucketHour = "hour"
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+// Truncate aligns t to the start of its bucket in UTC. Weeks start on Monday,
+// matching Postgres' date_trunc('week', ...).
+func Truncate(t time.Time, bucket string) (time.Time, error) {
+	t = t.UTC()
+	switch bucket {
+	case BucketHour:
+		return t.Truncate(time.Hour), nil
+	case BucketDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	case BucketWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown bucket %q", bucket)
+	}
+}
+
+// Next returns the start of the bucket following the one starting at start.
+func Next(start time.Time, bucket string) time.Time {
+	switch bucket {
+	case BucketHour:
+		return start.Add(time.Hour)
+	case BucketDay:
+		return start.AddDate(0, 0, 1)
+	default:
+		return start.AddDate(0, 0, 7)
+	}
+}
+
+// Count returns the number of buckets covering [from, to) without listing
+// them, so that ranges can be bounded before anything is allocated. Both
+// ends are expected to be aligned already.
+func Count(from time.Time, to time.Time, bucket string) int64 {
+	if !from.Before(to) {
+		return 0
+	}
+	// Unix seconds, unlike durations, do not saturate for ranges of
+	// centuries; buckets are whole hours in UTC, so there is no DST
+	seconds := to.Unix() - from.Unix()
+	switch bucket {
+	case BucketHour:
+		return seconds / int64(time.Hour/time.Second)
+	case BucketDay:
+		return seconds / int64(24*time.Hour/time.Second)
+	default:
+		return seconds / int64(7*24*time.Hour/time.Second)
+	}
+}
+
+// Buckets lists the bucket starts covering [from, to). Both ends are
+// expected to be aligned already and bounded with Count.
+func Buckets(from time.Time, to time.Time, bucket string) []time.Time {
+	var buckets []time.Time
+	for start := from; start.Before(to); start = Next(start, bucket) {
+		buckets = append(buckets, start)
+	}
+	return buckets
+}
+
+// Ratio divides part by whole, returning 0 instead of NaN for empty wholes.
+func Ratio(part int64, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
@@ -0,0 +1,56 @@
+package api_errors
+
+// Code is a stable, machine-readable identifier of an error. Codes are part
+// of the API contract: clients branch on them, so published codes must never
+// change meaning or be removed.
+type Code string
+
+const (
+	CodeInternal            Code = "internal_error"
+	CodePermissionDenied    Code = "permission_denied"
+	CodeInvalidInput        Code = "invalid_input"
+	CodeInvalidRequestBody  Code = "invalid_request_body"
+	CodeValidationFailed    Code = "validation_failed"
+	CodeResourceNotFound    Code = "resource_not_found"
+	CodeMethodNotAllowed    Code = "method_not_allowed"
+	CodePreconditionFailed  Code = "precondition_failed"
+	CodeConflict            Code = "conflict"
+	CodeConcurrentUpdate    Code = "concurrent_update"
+	CodeUnprocessableEntity Code = "unprocessable_entity"
+	CodeInvalidCredentials  Code = "invalid_credentials"
+	CodeUnauthorized        Code = "unauthorized"
+	CodeTooManyRequests     Code = "too_many_requests"
+)
+
+const problemTypePrefix = "urn:forms-api:problem:"
+
+var titles = map[Code]string{
+	CodeInternal:            "Internal server error",
+	CodePermissionDenied:    "Permission denied",
+	CodeInvalidInput:        "Invalid input",
+	CodeInvalidRequestBody:  "Invalid request body",
+	CodeValidationFailed:    "Validation failed",
+	CodeResourceNotFound:    "Resource not found",
+	CodeMethodNotAllowed:    "Method not allowed",
+	CodePreconditionFailed:  "Precondition failed",
+	CodeConflict:            "Conflict",
+	CodeConcurrentUpdate:    "Concurrent update",
+	CodeUnprocessableEntity: "Unprocessable entity",
+	CodeInvalidCredentials:  "Invalid credentials",
+	CodeUnauthorized:        "Unauthorized",
+	CodeTooManyRequests:     "Too many requests",
+}
+
+// TypeURI returns the RFC 7807 problem type of the code.
+func (c Code) TypeURI() string {
+	return problemTypePrefix + string(c)
+}
+
+// Title returns the short summary of the code, which does not vary between
+// occurrences.
+func (c Code) Title() string {
+	if title, ok := titles[c]; ok {
+		return title
+	}
+	return string(c)
+}
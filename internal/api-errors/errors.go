@@ -4,13 +4,24 @@ import (
 	"errors"
 	"fmt"
 	"salesforge-assignment/internal/api"
+	"time"
 )
 
 type HTTPError interface {
 	error
 	APIErrorResponse() api.ErrorResponse
+	ErrorCode() Code
+	// MessageKey returns the catalog key and parameters of the message, so
+	// it can be translated to the client's language.
+	MessageKey() (key string, params []string)
 }
 
+// Reasons of UnauthorizedError that have translations.
+const (
+	ReasonMissingToken = "Authorization header is required"
+	ReasonInvalidToken = "Invalid token"
+)
+
 type InvalidApplicationStateError struct {
 	Err error
 }
@@ -26,6 +37,14 @@ func (err *InvalidApplicationStateError) APIErrorResponse() api.ErrorResponse {
 	}
 }
 
+func (err *InvalidApplicationStateError) ErrorCode() Code {
+	return CodeInternal
+}
+
+func (err *InvalidApplicationStateError) MessageKey() (string, []string) {
+	return string(err.ErrorCode()), nil
+}
+
 type PermissionDeniedError struct {
 	Err error
 }
@@ -41,8 +60,17 @@ func (err *PermissionDeniedError) APIErrorResponse() api.ErrorResponse {
 	}
 }
 
+func (err *PermissionDeniedError) ErrorCode() Code {
+	return CodePermissionDenied
+}
+
+func (err *PermissionDeniedError) MessageKey() (string, []string) {
+	return string(err.ErrorCode()), nil
+}
+
 type InvalidInputError struct {
-	Err error
+	Field string
+	Err   error
 }
 
 func (err *InvalidInputError) Error() string {
@@ -50,12 +78,30 @@ func (err *InvalidInputError) Error() string {
 }
 
 func (err *InvalidInputError) APIErrorResponse() api.ErrorResponse {
+	if err.Field != "" {
+		return api.ErrorResponse{
+			Message: fmt.Sprintf("Invalid value of '%s'", err.Field),
+			Code:    400,
+			Field:   &err.Field,
+		}
+	}
 	return api.ErrorResponse{
 		Message: "Invalid input",
 		Code:    400,
 	}
 }
 
+func (err *InvalidInputError) ErrorCode() Code {
+	return CodeInvalidInput
+}
+
+func (err *InvalidInputError) MessageKey() (string, []string) {
+	if err.Field != "" {
+		return string(err.ErrorCode()) + ".field", []string{err.Field}
+	}
+	return string(err.ErrorCode()), nil
+}
+
 type ResourceNotFoundError struct {
 	Err error
 }
@@ -71,6 +117,159 @@ func (err *ResourceNotFoundError) APIErrorResponse() api.ErrorResponse {
 	}
 }
 
+func (err *ResourceNotFoundError) ErrorCode() Code {
+	return CodeResourceNotFound
+}
+
+func (err *ResourceNotFoundError) MessageKey() (string, []string) {
+	return string(err.ErrorCode()), nil
+}
+
+type MethodNotAllowedError struct {
+	Err error
+}
+
+func (err *MethodNotAllowedError) Error() string {
+	return "method not allowed"
+}
+
+func (err *MethodNotAllowedError) APIErrorResponse() api.ErrorResponse {
+	return api.ErrorResponse{
+		Message: "Method not allowed",
+		Code:    405,
+	}
+}
+
+func (err *MethodNotAllowedError) ErrorCode() Code {
+	return CodeMethodNotAllowed
+}
+
+func (err *MethodNotAllowedError) MessageKey() (string, []string) {
+	return string(err.ErrorCode()), nil
+}
+
+type PreconditionFailedError struct {
+	Err error
+}
+
+func (err *PreconditionFailedError) Error() string {
+	return "precondition failed"
+}
+
+func (err *PreconditionFailedError) APIErrorResponse() api.ErrorResponse {
+	return api.ErrorResponse{
+		Message: "Precondition failed",
+		Code:    412,
+	}
+}
+
+func (err *PreconditionFailedError) ErrorCode() Code {
+	return CodePreconditionFailed
+}
+
+func (err *PreconditionFailedError) MessageKey() (string, []string) {
+	return string(err.ErrorCode()), nil
+}
+
+type ConflictError struct {
+	Field string
+	Err   error
+}
+
+func (err *ConflictError) Error() string {
+	return "conflict"
+}
+
+func (err *ConflictError) APIErrorResponse() api.ErrorResponse {
+	if err.Field != "" {
+		return api.ErrorResponse{
+			Message: fmt.Sprintf("Value of '%s' is already in use", err.Field),
+			Code:    409,
+			Field:   &err.Field,
+		}
+	}
+	return api.ErrorResponse{
+		Message: "Conflict",
+		Code:    409,
+	}
+}
+
+func (err *ConflictError) ErrorCode() Code {
+	return CodeConflict
+}
+
+func (err *ConflictError) MessageKey() (string, []string) {
+	if err.Field != "" {
+		return string(err.ErrorCode()) + ".field", []string{err.Field}
+	}
+	return string(err.ErrorCode()), nil
+}
+
+func (err *ConflictError) Unwrap() error {
+	return err.Err
+}
+
+type ConcurrentUpdateError struct {
+	Err error
+}
+
+func (err *ConcurrentUpdateError) Error() string {
+	return "concurrent update"
+}
+
+func (err *ConcurrentUpdateError) APIErrorResponse() api.ErrorResponse {
+	return api.ErrorResponse{
+		Message: "Conflicting concurrent update, retry the request",
+		Code:    409,
+	}
+}
+
+func (err *ConcurrentUpdateError) ErrorCode() Code {
+	return CodeConcurrentUpdate
+}
+
+func (err *ConcurrentUpdateError) MessageKey() (string, []string) {
+	return string(err.ErrorCode()), nil
+}
+
+func (err *ConcurrentUpdateError) Unwrap() error {
+	return err.Err
+}
+
+type UnprocessableEntityError struct {
+	Field string
+	Err   error
+}
+
+func (err *UnprocessableEntityError) Error() string {
+	return "unprocessable entity"
+}
+
+func (err *UnprocessableEntityError) APIErrorResponse() api.ErrorResponse {
+	if err.Field != "" {
+		return api.ErrorResponse{
+			Message: fmt.Sprintf("Referenced '%s' does not exist", err.Field),
+			Code:    422,
+			Field:   &err.Field,
+		}
+	}
+	return api.ErrorResponse{
+		Message: "Unprocessable entity",
+		Code:    422,
+	}
+}
+
+func (err *UnprocessableEntityError) ErrorCode() Code {
+	return CodeUnprocessableEntity
+}
+
+func (err *UnprocessableEntityError) MessageKey() (string, []string) {
+	if err.Field != "" {
+		return string(err.ErrorCode()) + ".field", []string{err.Field}
+	}
+	return string(err.ErrorCode()), nil
+}
+
 type InvalidCredentialsError struct {
 	Err error
 }
@@ -86,8 +285,18 @@ func (err *InvalidCredentialsError) APIErrorResponse() api.ErrorResponse {
 	}
 }
 
+func (err *InvalidCredentialsError) ErrorCode() Code {
+	return CodeInvalidCredentials
+}
+
+func (err *InvalidCredentialsError) MessageKey() (string, []string) {
+	return string(err.ErrorCode()), nil
+}
+
 type UnauthorizedError struct {
-	Err error
+	// Reason replaces the generic message, e.g. to tell a missing token from an invalid one
+	Reason string
+	Err    error
 }
 
 func (err *UnauthorizedError) Error() string {
@@ -95,12 +304,33 @@ func (err *UnauthorizedError) Error() string {
 }
 
 func (err *UnauthorizedError) APIErrorResponse() api.ErrorResponse {
+	message := "Unauthorized access"
+	if err.Reason != "" {
+		message = err.Reason
+	}
 	return api.ErrorResponse{
-		Message: "Unauthorized access",
+		Message: message,
 		Code:    401,
 	}
 }
 
+func (err *UnauthorizedError) ErrorCode() Code {
+	return CodeUnauthorized
+}
+
+func (err *UnauthorizedError) MessageKey() (string, []string) {
+	switch err.Reason {
+	case "":
+		return string(CodeUnauthorized), nil
+	case ReasonMissingToken:
+		return "unauthorized.missing_token", nil
+	case ReasonInvalidToken:
+		return "unauthorized.invalid_token", nil
+	default:
+		return "", nil
+	}
+}
+
 type InvalidRequestBodyError struct {
 	Err error
 }
@@ -124,6 +354,41 @@ func (err *InvalidRequestBodyError) APIErrorResponse() api.ErrorResponse {
 	}
 }
 
+func (err *InvalidRequestBodyError) ErrorCode() Code {
+	return CodeInvalidRequestBody
+}
+
+func (err *InvalidRequestBodyError) MessageKey() (string, []string) {
+	if originalErr := errors.Unwrap(err.Err); originalErr != nil {
+		return string(CodeInvalidRequestBody) + ".detail", []string{originalErr.Error()}
+	}
+	return string(CodeInvalidRequestBody), nil
+}
+
 func (err *InvalidRequestBodyError) Unwrap() error {
 	return err.Err
 }
+
+type TooManyRequestsError struct {
+	// RetryAfter is how long until the client may make the request
+	RetryAfter time.Duration
+}
+
+func (err *TooManyRequestsError) Error() string {
+	return "too many requests"
+}
+
+func (err *TooManyRequestsError) APIErrorResponse() api.ErrorResponse {
+	return api.ErrorResponse{
+		Message: "Too many requests, retry later",
+		Code:    429,
+	}
+}
+
+func (err *TooManyRequestsError) ErrorCode() Code {
+	return CodeTooManyRequests
+}
+
+func (err *TooManyRequestsError) MessageKey() (string, []string) {
+	return string(err.ErrorCode()), nil
+}
@@ -4,13 +4,56 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for EventCreateType.
+const (
+	Click         EventCreateType = "click"
+	Open          EventCreateType = "open"
+	StepCompleted EventCreateType = "step_completed"
+	View          EventCreateType = "view"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Dead      WebhookDeliveryStatus = "dead"
+	Pending   WebhookDeliveryStatus = "pending"
+	Succeeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for WebhookEventType.
+const (
+	FormCreated         WebhookEventType = "form.created"
+	FormUpdated         WebhookEventType = "form.updated"
+	StepDeleted         WebhookEventType = "step.deleted"
+	StepUpdated         WebhookEventType = "step.updated"
+	SubmissionCompleted WebhookEventType = "submission.completed"
+)
+
+// Defines values for GetFormAnalyticsParamsBucket.
+const (
+	Day  GetFormAnalyticsParamsBucket = "day"
+	Hour GetFormAnalyticsParamsBucket = "hour"
+	Week GetFormAnalyticsParamsBucket = "week"
+)
+
 // Authentication defines model for Authentication.
 type Authentication struct {
 	// Password The password for authentication
@@ -26,15 +69,118 @@ type AuthenticationResponse struct {
 	Token string `json:"token"`
 }
 
+// EngagementBucket defines model for EngagementBucket.
+type EngagementBucket struct {
+	// BucketStart The start of the bucket
+	BucketStart time.Time `json:"bucketStart"`
+
+	// ClickThroughRate Unique clicks divided by unique opens, 0 when there are no opens
+	ClickThroughRate float64 `json:"clickThroughRate"`
+
+	// Clicks The number of click events
+	Clicks int64 `json:"clicks"`
+
+	// Opens The number of open events
+	Opens int64 `json:"opens"`
+
+	// UniqueClicks The number of distinct recipients that clicked
+	UniqueClicks int64 `json:"uniqueClicks"`
+
+	// UniqueOpens The number of distinct recipients that opened
+	UniqueOpens int64 `json:"uniqueOpens"`
+}
+
+// EngagementStats defines model for EngagementStats.
+type EngagementStats struct {
+	// ClickThroughRate Unique clicks divided by unique opens, 0 when there are no opens
+	ClickThroughRate float64 `json:"clickThroughRate"`
+
+	// Clicks The number of click events
+	Clicks int64 `json:"clicks"`
+
+	// Opens The number of open events
+	Opens int64 `json:"opens"`
+
+	// UniqueClicks The number of distinct recipients that clicked
+	UniqueClicks int64 `json:"uniqueClicks"`
+
+	// UniqueOpens The number of distinct recipients that opened
+	UniqueOpens int64 `json:"uniqueOpens"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	// Code The error code
 	Code int `json:"code"`
 
+	// Field The field that caused the error, if it is known
+	Field *string `json:"field,omitempty"`
+
 	// Message A descriptive error message
 	Message string `json:"message"`
 }
 
+// EventBatch defines model for EventBatch.
+type EventBatch struct {
+	// Events The events to ingest
+	Events []EventCreate `json:"events" validate:"required,min=1,max=500,dive"`
+}
+
+// EventBatchResponse defines model for EventBatchResponse.
+type EventBatchResponse struct {
+	// Accepted The number of events stored
+	Accepted int `json:"accepted"`
+
+	// Dropped The number of events dropped because tracking is disabled for the form
+	Dropped int `json:"dropped"`
+}
+
+// EventCreate defines model for EventCreate.
+type EventCreate struct {
+	// FormId The ID of the form the event belongs to
+	FormId string `json:"formId" validate:"required,uuid"`
+
+	// OccurredAt When the event happened, defaults to the time of ingestion
+	OccurredAt *time.Time `json:"occurredAt,omitempty"`
+
+	// Recipient An opaque identifier of the recipient or visitor
+	Recipient string `json:"recipient" validate:"required,min=1,max=256"`
+
+	// StepId The ID of the form step the event belongs to
+	StepId string `json:"stepId" validate:"required,uuid"`
+
+	// Type The kind of engagement event
+	Type EventCreateType `json:"type" validate:"required,oneof=view open click step_completed"`
+
+	// Url The clicked URL, for click events
+	Url *string `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+}
+
+// EventCreateType The kind of engagement event
+type EventCreateType string
+
+// FormAnalytics defines model for FormAnalytics.
+type FormAnalytics struct {
+	// Bucket The size of the time series buckets
+	Bucket string `json:"bucket"`
+
+	// From The start of the reported range, aligned to the bucket size
+	From time.Time `json:"from"`
+
+	// Funnel Step funnel in step order
+	Funnel []FunnelStep `json:"funnel"`
+
+	// Self An object containing the ID and href of a resource
+	Self SelfId `json:"self"`
+
+	// Series Engagement per bucket, oldest first
+	Series []EngagementBucket `json:"series"`
+
+	// To The end of the reported range, aligned to the bucket size
+	To     time.Time       `json:"to"`
+	Totals EngagementStats `json:"totals"`
+}
+
 // FormCreate defines model for FormCreate.
 type FormCreate struct {
 	// ClickTrackingEnabled Indicates if click tracking is enabled
@@ -47,7 +193,7 @@ type FormCreate struct {
 	OpenTrackingEnabled *bool `json:"openTrackingEnabled,omitempty"`
 
 	// Steps An array of form steps
-	Steps FormStepCreateArray `json:"steps" validate:"required,min=1,max=100"`
+	Steps FormStepCreateArray `json:"steps" validate:"required,min=1,max=100,dive"`
 }
 
 // FormResponseGet defines model for FormResponseGet.
@@ -66,6 +212,12 @@ type FormResponseGet struct {
 
 	// Steps An array of form steps
 	Steps FormStepGetArray `json:"steps"`
+
+	// UpdatedAt When the form or one of its steps was last changed
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Version The version of the form, incremented on every change to the form or its steps
+	Version int `json:"version"`
 }
 
 // FormStepCreate defines model for FormStepCreate.
@@ -86,6 +238,21 @@ type FormStepCreateArray = []FormStepCreate
 // FormStepGetArray An array of form steps
 type FormStepGetArray = []FormStepResponseGet
 
+// FormStepRenderResponse defines model for FormStepRenderResponse.
+type FormStepRenderResponse struct {
+	// Content The content of the form step with tracking applied
+	Content string `json:"content"`
+
+	// Name The name of the form step
+	Name string `json:"name"`
+
+	// Self An object containing the ID and href of a resource
+	Self SelfId `json:"self"`
+
+	// Step The order of the step in the form
+	Step int `json:"step"`
+}
+
 // FormStepResponseGet defines model for FormStepResponseGet.
 type FormStepResponseGet struct {
 	// Content The content of the form step
@@ -99,6 +266,9 @@ type FormStepResponseGet struct {
 
 	// Step The order of the step in the form
 	Step int `json:"step"`
+
+	// Version The version of the form step, incremented on every change
+	Version int `json:"version"`
 }
 
 // FormStepUpdate defines model for FormStepUpdate.
@@ -119,6 +289,60 @@ type FormUpdate struct {
 	OpenTrackingEnabled *bool `json:"openTrackingEnabled,omitempty"`
 }
 
+// FunnelStep defines model for FunnelStep.
+type FunnelStep struct {
+	// Completed The number of distinct recipients that completed the step
+	Completed int64 `json:"completed"`
+
+	// DropOff The number of recipients that viewed but did not complete the step
+	DropOff int64 `json:"dropOff"`
+
+	// DropOffRate Drop-off divided by viewed, 0 when nobody viewed the step
+	DropOffRate float64 `json:"dropOffRate"`
+
+	// Name The name of the form step
+	Name string `json:"name"`
+
+	// Self An object containing the ID and href of a resource
+	Self SelfId `json:"self"`
+
+	// Step The order of the step in the form
+	Step int `json:"step"`
+
+	// Viewed The number of distinct recipients that viewed the step
+	Viewed int64 `json:"viewed"`
+}
+
+// Problem An RFC 7807 problem detail. Errors are returned in this format with the application/problem+json content type when the request accepts it, and as ErrorResponse or ValidationErrorResponse otherwise.
+type Problem struct {
+	// Code A stable, machine-readable error code. One of internal_error, permission_denied, invalid_input, invalid_request_body, validation_failed, resource_not_found, method_not_allowed, precondition_failed, conflict, concurrent_update, unprocessable_entity, invalid_credentials, unauthorized, too_many_requests.
+	Code string `json:"code"`
+
+	// Detail An explanation specific to this occurrence
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors A list of validation errors
+	Errors *ValidationErrors `json:"errors,omitempty"`
+
+	// Field The field that caused the error, if it is known
+	Field *string `json:"field,omitempty"`
+
+	// Instance The path of the request
+	Instance *string `json:"instance,omitempty"`
+
+	// RequestId The ID of the request, as in the X-Request-ID response header
+	RequestId *string `json:"requestId,omitempty"`
+
+	// Status The HTTP status code
+	Status int `json:"status"`
+
+	// Title A short summary of the problem type
+	Title string `json:"title"`
+
+	// Type A URI identifying the problem type, derived from the code
+	Type string `json:"type"`
+}
+
 // SelfId An object containing the ID and href of a resource
 type SelfId struct {
 	// Href The URL of the location
@@ -130,11 +354,17 @@ type SelfId struct {
 
 // ValidationError defines model for ValidationError.
 type ValidationError struct {
-	// Field The field that caused the validation error
+	// Field The JSON path of the field that caused the validation error, e.g. steps[2].content
 	Field string `json:"field"`
 
-	// Message The validation error message for the field
+	// Message The validation error message for the field, in the language negotiated with Accept-Language
 	Message string `json:"message"`
+
+	// Param The parameter of the violated rule, if it has one
+	Param *string `json:"param,omitempty"`
+
+	// Rule The validation rule that was violated
+	Rule string `json:"rule"`
 }
 
 // ValidationErrorResponse defines model for ValidationErrorResponse.
@@ -152,6 +382,231 @@ type ValidationErrorResponse struct {
 // ValidationErrors A list of validation errors
 type ValidationErrors = []ValidationError
 
+// WebhookCreate defines model for WebhookCreate.
+type WebhookCreate struct {
+	// EventTypes The event types to deliver
+	EventTypes []WebhookEventType `json:"eventTypes" validate:"required,min=1,dive,oneof=form.created form.updated step.updated step.deleted submission.completed"`
+
+	// FormId The form to receive events of, omit to receive events of all forms in the workspace
+	FormId *string `json:"formId,omitempty" validate:"omitempty,uuid"`
+
+	// Secret The secret used to sign deliveries, generated when omitted
+	Secret *string `json:"secret,omitempty" validate:"omitempty,min=16,max=256"`
+
+	// Url The http(s) URL events are posted to
+	Url string `json:"url" validate:"required,url,startswith=http,max=2048"`
+}
+
+// WebhookDeliveryAttempt defines model for WebhookDeliveryAttempt.
+type WebhookDeliveryAttempt struct {
+	// AttemptedAt When the attempt was made
+	AttemptedAt time.Time `json:"attemptedAt"`
+
+	// DurationMs How long the attempt took in milliseconds
+	DurationMs int64 `json:"durationMs"`
+
+	// Error Why the attempt failed
+	Error *string `json:"error,omitempty"`
+
+	// StatusCode The HTTP status code returned by the receiver, absent if no response was received
+	StatusCode *int `json:"statusCode,omitempty"`
+}
+
+// WebhookDeliveryPage defines model for WebhookDeliveryPage.
+type WebhookDeliveryPage struct {
+	// Items The deliveries on this page
+	Items []WebhookDeliveryResponseGet `json:"items"`
+
+	// Page The current page
+	Page int `json:"page"`
+
+	// PageSize The maximum number of deliveries per page
+	PageSize int `json:"pageSize"`
+
+	// Total The total number of deliveries
+	Total int64 `json:"total"`
+}
+
+// WebhookDeliveryResponseGet defines model for WebhookDeliveryResponseGet.
+type WebhookDeliveryResponseGet struct {
+	// AttemptLog All attempts made, oldest first
+	AttemptLog *[]WebhookDeliveryAttempt `json:"attemptLog,omitempty"`
+
+	// Attempts The number of failed attempts in the current delivery cycle
+	Attempts int `json:"attempts"`
+
+	// CreatedAt When the delivery was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// EventId The ID of the delivered event, identical across redeliveries
+	EventId string `json:"eventId"`
+
+	// EventType The type of a form or submission event
+	EventType WebhookEventType `json:"eventType"`
+
+	// NextAttemptAt When the next attempt is scheduled, for pending deliveries
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	// Payload The JSON document posted to the receiver
+	Payload string `json:"payload"`
+
+	// Self An object containing the ID and href of a resource
+	Self SelfId `json:"self"`
+
+	// Status The state of a delivery. Deliveries that keep failing are retried with exponential backoff and end up dead once the retry budget is exhausted.
+	Status WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus The state of a delivery. Deliveries that keep failing are retried with exponential backoff and end up dead once the retry budget is exhausted.
+type WebhookDeliveryStatus string
+
+// WebhookEventType The type of a form or submission event
+type WebhookEventType string
+
+// WebhookGetArray An array of webhooks
+type WebhookGetArray = []WebhookResponseGet
+
+// WebhookResponseGet defines model for WebhookResponseGet.
+type WebhookResponseGet struct {
+	// CreatedAt When the webhook was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// EventTypes The event types delivered
+	EventTypes []WebhookEventType `json:"eventTypes"`
+
+	// FormId The form the webhook receives events of, absent for workspace webhooks
+	FormId *string `json:"formId,omitempty"`
+
+	// Secret The signing secret, only returned when the webhook is created
+	Secret *string `json:"secret,omitempty"`
+
+	// Self An object containing the ID and href of a resource
+	Self SelfId `json:"self"`
+
+	// Url The URL events are posted to
+	Url string `json:"url"`
+}
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfModifiedSince defines model for IfModifiedSince.
+type IfModifiedSince = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// ForbiddenApplicationJSON defines model for Forbidden.
+type ForbiddenApplicationJSON = ErrorResponse
+
+// ForbiddenApplicationProblemPlusJSON An RFC 7807 problem detail. Errors are returned in this format with the application/problem+json content type when the request accepts it, and as ErrorResponse or ValidationErrorResponse otherwise.
+type ForbiddenApplicationProblemPlusJSON = Problem
+
+// IdempotencyKeyInUseApplicationJSON defines model for IdempotencyKeyInUse.
+type IdempotencyKeyInUseApplicationJSON = ErrorResponse
+
+// IdempotencyKeyInUseApplicationProblemPlusJSON An RFC 7807 problem detail. Errors are returned in this format with the application/problem+json content type when the request accepts it, and as ErrorResponse or ValidationErrorResponse otherwise.
+type IdempotencyKeyInUseApplicationProblemPlusJSON = Problem
+
+// IdempotencyKeyReusedApplicationJSON defines model for IdempotencyKeyReused.
+type IdempotencyKeyReusedApplicationJSON = ErrorResponse
+
+// IdempotencyKeyReusedApplicationProblemPlusJSON An RFC 7807 problem detail. Errors are returned in this format with the application/problem+json content type when the request accepts it, and as ErrorResponse or ValidationErrorResponse otherwise.
+type IdempotencyKeyReusedApplicationProblemPlusJSON = Problem
+
+// TooManyRequestsApplicationJSON defines model for TooManyRequests.
+type TooManyRequestsApplicationJSON = ErrorResponse
+
+// TooManyRequestsApplicationProblemPlusJSON An RFC 7807 problem detail. Errors are returned in this format with the application/problem+json content type when the request accepts it, and as ErrorResponse or ValidationErrorResponse otherwise.
+type TooManyRequestsApplicationProblemPlusJSON = Problem
+
+// IngestEventsParams defines parameters for IngestEvents.
+type IngestEventsParams struct {
+	// IdempotencyKey A unique key chosen by the client that makes the request safe to retry. Retries with the same key and body within the retention window (24 hours by default) get the response of the first request, with the Idempotent-Replayed header set.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CreateFormParams defines parameters for CreateForm.
+type CreateFormParams struct {
+	// IdempotencyKey A unique key chosen by the client that makes the request safe to retry. Retries with the same key and body within the retention window (24 hours by default) get the response of the first request, with the Idempotent-Replayed header set.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetFormByIdParams defines parameters for GetFormById.
+type GetFormByIdParams struct {
+	// IfNoneMatch ETags of versions the client holds. The response is 304 Not Modified if one of them is current.
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince An HTTP date. The response is 304 Not Modified if the resource has not changed since. Ignored when If-None-Match is present.
+	IfModifiedSince *IfModifiedSince `json:"If-Modified-Since,omitempty"`
+}
+
+// UpdateFormByIdParams defines parameters for UpdateFormById.
+type UpdateFormByIdParams struct {
+	// IfMatch The ETag of the version the change is based on. The request fails with 412 if the resource has been modified since.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetFormAnalyticsParams defines parameters for GetFormAnalytics.
+type GetFormAnalyticsParams struct {
+	// From Start of the reported time range, defaults to seven days before `to`
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the reported time range, defaults to now
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Bucket The size of the time series buckets
+	Bucket *GetFormAnalyticsParamsBucket `form:"bucket,omitempty" json:"bucket,omitempty"`
+}
+
+// GetFormAnalyticsParamsBucket defines parameters for GetFormAnalytics.
+type GetFormAnalyticsParamsBucket string
+
+// DeleteFormStepByIdParams defines parameters for DeleteFormStepById.
+type DeleteFormStepByIdParams struct {
+	// IfMatch The ETag of the version the change is based on. The request fails with 412 if the resource has been modified since.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetFormStepByIdParams defines parameters for GetFormStepById.
+type GetFormStepByIdParams struct {
+	// IfNoneMatch ETags of versions the client holds. The response is 304 Not Modified if one of them is current.
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// UpdateFormStepByIdParams defines parameters for UpdateFormStepById.
+type UpdateFormStepByIdParams struct {
+	// IfMatch The ETag of the version the change is based on. The request fails with 412 if the resource has been modified since.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// RenderFormStepByIdParams defines parameters for RenderFormStepById.
+type RenderFormStepByIdParams struct {
+	// Recipient An opaque identifier of the recipient the content is rendered for
+	Recipient string `form:"recipient" json:"recipient"`
+}
+
+// CreateWebhookParams defines parameters for CreateWebhook.
+type CreateWebhookParams struct {
+	// IdempotencyKey A unique key chosen by the client that makes the request safe to retry. Retries with the same key and body within the retention window (24 hours by default) get the response of the first request, with the Idempotent-Replayed header set.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Page The page to return, starting at 1
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PageSize The number of deliveries per page
+	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
+// IngestEventsJSONRequestBody defines body for IngestEvents for application/json ContentType.
+type IngestEventsJSONRequestBody = EventBatch
+
 // CreateFormJSONRequestBody defines body for CreateForm for application/json ContentType.
 type CreateFormJSONRequestBody = FormCreate
 
@@ -164,29 +619,68 @@ type UpdateFormStepByIdJSONRequestBody = FormStepUpdate
 // LoginUserJSONRequestBody defines body for LoginUser for application/json ContentType.
 type LoginUserJSONRequestBody = Authentication
 
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = WebhookCreate
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Ingest a batch of engagement events
+	// (POST /events)
+	IngestEvents(c *gin.Context, params IngestEventsParams)
 	// Create a new form
 	// (POST /form)
-	CreateForm(c *gin.Context)
+	CreateForm(c *gin.Context, params CreateFormParams)
 	// Get a specific form
 	// (GET /form/{formId})
-	GetFormById(c *gin.Context, formId string)
+	GetFormById(c *gin.Context, formId string, params GetFormByIdParams)
 	// Update an existing form
 	// (PATCH /form/{formId})
-	UpdateFormById(c *gin.Context, formId string)
+	UpdateFormById(c *gin.Context, formId string, params UpdateFormByIdParams)
+	// Get engagement analytics of a form
+	// (GET /form/{formId}/analytics)
+	GetFormAnalytics(c *gin.Context, formId string, params GetFormAnalyticsParams)
 	// Delete a form step
 	// (DELETE /form/{formId}/steps/{stepId})
-	DeleteFormStepById(c *gin.Context, formId string, stepId string)
+	DeleteFormStepById(c *gin.Context, formId string, stepId string, params DeleteFormStepByIdParams)
 	// Get a specific form step
 	// (GET /form/{formId}/steps/{stepId})
-	GetFormStepById(c *gin.Context, formId string, stepId string)
+	GetFormStepById(c *gin.Context, formId string, stepId string, params GetFormStepByIdParams)
 	// Update an existing form step
 	// (PATCH /form/{formId}/steps/{stepId})
-	UpdateFormStepById(c *gin.Context, formId string, stepId string)
+	UpdateFormStepById(c *gin.Context, formId string, stepId string, params UpdateFormStepByIdParams)
+	// Render a form step for a recipient
+	// (GET /form/{formId}/steps/{stepId}/render)
+	RenderFormStepById(c *gin.Context, formId string, stepId string, params RenderFormStepByIdParams)
 	// User login
 	// (POST /login)
 	LoginUser(c *gin.Context)
+	// Click-tracking redirect
+	// (GET /t/c/{token})
+	TrackClick(c *gin.Context, token string)
+	// Open-tracking pixel
+	// (GET /t/o/{pixel})
+	TrackOpen(c *gin.Context, pixel string)
+	// List webhooks
+	// (GET /webhooks)
+	ListWebhooks(c *gin.Context)
+	// Subscribe a webhook to form events
+	// (POST /webhooks)
+	CreateWebhook(c *gin.Context, params CreateWebhookParams)
+	// Delete a webhook
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhookById(c *gin.Context, webhookId string)
+	// Get a specific webhook
+	// (GET /webhooks/{webhookId})
+	GetWebhookById(c *gin.Context, webhookId string)
+	// List deliveries of a webhook
+	// (GET /webhooks/{webhookId}/deliveries)
+	ListWebhookDeliveries(c *gin.Context, webhookId string, params ListWebhookDeliveriesParams)
+	// Get a specific webhook delivery with its attempt log
+	// (GET /webhooks/{webhookId}/deliveries/{deliveryId})
+	GetWebhookDeliveryById(c *gin.Context, webhookId string, deliveryId string)
+	// Schedule a webhook delivery to be sent again
+	// (POST /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver)
+	RedeliverWebhookDelivery(c *gin.Context, webhookId string, deliveryId string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -198,8 +692,36 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// CreateForm operation middleware
-func (siw *ServerInterfaceWrapper) CreateForm(c *gin.Context) {
+// IngestEvents operation middleware
+func (siw *ServerInterfaceWrapper) IngestEvents(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params IngestEventsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -208,21 +730,38 @@ func (siw *ServerInterfaceWrapper) CreateForm(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateForm(c)
+	siw.Handler.IngestEvents(c, params)
 }
 
-// GetFormById operation middleware
-func (siw *ServerInterfaceWrapper) GetFormById(c *gin.Context) {
+// CreateForm operation middleware
+func (siw *ServerInterfaceWrapper) CreateForm(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "formId" -------------
-	var formId string
+	c.Set(BearerAuthScopes, []string{"editor"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateFormParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
 
-	err = runtime.BindStyledParameterWithOptions("simple", "formId", c.Param("formId"), &formId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter formId: %w", err), http.StatusBadRequest)
-		return
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -232,11 +771,11 @@ func (siw *ServerInterfaceWrapper) GetFormById(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetFormById(c, formId)
+	siw.Handler.CreateForm(c, params)
 }
 
-// UpdateFormById operation middleware
-func (siw *ServerInterfaceWrapper) UpdateFormById(c *gin.Context) {
+// GetFormById operation middleware
+func (siw *ServerInterfaceWrapper) GetFormById(c *gin.Context) {
 
 	var err error
 
@@ -249,6 +788,51 @@ func (siw *ServerInterfaceWrapper) UpdateFormById(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFormByIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSince
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -256,11 +840,11 @@ func (siw *ServerInterfaceWrapper) UpdateFormById(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateFormById(c, formId)
+	siw.Handler.GetFormById(c, formId, params)
 }
 
-// DeleteFormStepById operation middleware
-func (siw *ServerInterfaceWrapper) DeleteFormStepById(c *gin.Context) {
+// UpdateFormById operation middleware
+func (siw *ServerInterfaceWrapper) UpdateFormById(c *gin.Context) {
 
 	var err error
 
@@ -273,13 +857,30 @@ func (siw *ServerInterfaceWrapper) DeleteFormStepById(c *gin.Context) {
 		return
 	}
 
-	// ------------- Path parameter "stepId" -------------
-	var stepId string
+	c.Set(BearerAuthScopes, []string{"editor"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateFormByIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
 
-	err = runtime.BindStyledParameterWithOptions("simple", "stepId", c.Param("stepId"), &stepId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stepId: %w", err), http.StatusBadRequest)
-		return
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -289,11 +890,11 @@ func (siw *ServerInterfaceWrapper) DeleteFormStepById(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteFormStepById(c, formId, stepId)
+	siw.Handler.UpdateFormById(c, formId, params)
 }
 
-// GetFormStepById operation middleware
-func (siw *ServerInterfaceWrapper) GetFormStepById(c *gin.Context) {
+// GetFormAnalytics operation middleware
+func (siw *ServerInterfaceWrapper) GetFormAnalytics(c *gin.Context) {
 
 	var err error
 
@@ -306,23 +907,161 @@ func (siw *ServerInterfaceWrapper) GetFormStepById(c *gin.Context) {
 		return
 	}
 
-	// ------------- Path parameter "stepId" -------------
-	var stepId string
+	c.Set(BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "stepId", c.Param("stepId"), &stepId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFormAnalyticsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stepId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "bucket" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bucket", c.Request.URL.Query(), &params.Bucket)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter bucket: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
 			return
 		}
 	}
 
-	siw.Handler.GetFormStepById(c, formId, stepId)
+	siw.Handler.GetFormAnalytics(c, formId, params)
+}
+
+// DeleteFormStepById operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFormStepById(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "formId" -------------
+	var formId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "formId", c.Param("formId"), &formId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter formId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "stepId" -------------
+	var stepId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "stepId", c.Param("stepId"), &stepId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stepId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"editor"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteFormStepByIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteFormStepById(c, formId, stepId, params)
+}
+
+// GetFormStepById operation middleware
+func (siw *ServerInterfaceWrapper) GetFormStepById(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "formId" -------------
+	var formId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "formId", c.Param("formId"), &formId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter formId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "stepId" -------------
+	var stepId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "stepId", c.Param("stepId"), &stepId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stepId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFormStepByIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetFormStepById(c, formId, stepId, params)
 }
 
 // UpdateFormStepById operation middleware
@@ -348,6 +1087,85 @@ func (siw *ServerInterfaceWrapper) UpdateFormStepById(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"editor"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateFormStepByIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateFormStepById(c, formId, stepId, params)
+}
+
+// RenderFormStepById operation middleware
+func (siw *ServerInterfaceWrapper) RenderFormStepById(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "formId" -------------
+	var formId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "formId", c.Param("formId"), &formId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter formId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "stepId" -------------
+	var stepId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "stepId", c.Param("stepId"), &stepId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter stepId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RenderFormStepByIdParams
+
+	// ------------- Required query parameter "recipient" -------------
+
+	if paramValue := c.Query("recipient"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument recipient is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "recipient", c.Request.URL.Query(), &params.Recipient)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter recipient: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -355,7 +1173,7 @@ func (siw *ServerInterfaceWrapper) UpdateFormStepById(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateFormStepById(c, formId, stepId)
+	siw.Handler.RenderFormStepById(c, formId, stepId, params)
 }
 
 // LoginUser operation middleware
@@ -371,6 +1189,277 @@ func (siw *ServerInterfaceWrapper) LoginUser(c *gin.Context) {
 	siw.Handler.LoginUser(c)
 }
 
+// TrackClick operation middleware
+func (siw *ServerInterfaceWrapper) TrackClick(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", c.Param("token"), &token, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter token: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.TrackClick(c, token)
+}
+
+// TrackOpen operation middleware
+func (siw *ServerInterfaceWrapper) TrackOpen(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pixel" -------------
+	var pixel string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pixel", c.Param("pixel"), &pixel, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pixel: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.TrackOpen(c, pixel)
+}
+
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWebhooks(c)
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"editor"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateWebhookParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateWebhook(c, params)
+}
+
+// DeleteWebhookById operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhookById(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", c.Param("webhookId"), &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"editor"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteWebhookById(c, webhookId)
+}
+
+// GetWebhookById operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookById(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", c.Param("webhookId"), &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhookById(c, webhookId)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", c.Param("webhookId"), &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageSize: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWebhookDeliveries(c, webhookId, params)
+}
+
+// GetWebhookDeliveryById operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDeliveryById(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", c.Param("webhookId"), &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "deliveryId" -------------
+	var deliveryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deliveryId", c.Param("deliveryId"), &deliveryId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter deliveryId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhookDeliveryById(c, webhookId, deliveryId)
+}
+
+// RedeliverWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) RedeliverWebhookDelivery(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", c.Param("webhookId"), &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "deliveryId" -------------
+	var deliveryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deliveryId", c.Param("deliveryId"), &deliveryId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter deliveryId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"editor"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RedeliverWebhookDelivery(c, webhookId, deliveryId)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -398,11 +1487,203 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.POST(options.BaseURL+"/events", wrapper.IngestEvents)
 	router.POST(options.BaseURL+"/form", wrapper.CreateForm)
 	router.GET(options.BaseURL+"/form/:formId", wrapper.GetFormById)
 	router.PATCH(options.BaseURL+"/form/:formId", wrapper.UpdateFormById)
+	router.GET(options.BaseURL+"/form/:formId/analytics", wrapper.GetFormAnalytics)
 	router.DELETE(options.BaseURL+"/form/:formId/steps/:stepId", wrapper.DeleteFormStepById)
 	router.GET(options.BaseURL+"/form/:formId/steps/:stepId", wrapper.GetFormStepById)
 	router.PATCH(options.BaseURL+"/form/:formId/steps/:stepId", wrapper.UpdateFormStepById)
+	router.GET(options.BaseURL+"/form/:formId/steps/:stepId/render", wrapper.RenderFormStepById)
 	router.POST(options.BaseURL+"/login", wrapper.LoginUser)
+	router.GET(options.BaseURL+"/t/c/:token", wrapper.TrackClick)
+	router.GET(options.BaseURL+"/t/o/:pixel", wrapper.TrackOpen)
+	router.GET(options.BaseURL+"/webhooks", wrapper.ListWebhooks)
+	router.POST(options.BaseURL+"/webhooks", wrapper.CreateWebhook)
+	router.DELETE(options.BaseURL+"/webhooks/:webhookId", wrapper.DeleteWebhookById)
+	router.GET(options.BaseURL+"/webhooks/:webhookId", wrapper.GetWebhookById)
+	router.GET(options.BaseURL+"/webhooks/:webhookId/deliveries", wrapper.ListWebhookDeliveries)
+	router.GET(options.BaseURL+"/webhooks/:webhookId/deliveries/:deliveryId", wrapper.GetWebhookDeliveryById)
+	router.POST(options.BaseURL+"/webhooks/:webhookId/deliveries/:deliveryId/redeliver", wrapper.RedeliverWebhookDelivery)
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+x9W3MbN7LwX0HNtw+7taOb42R3/VUeFF+y2nXWKUvenDqRjwPONEmshsAEwEhiXPrv",
+	"p7oBzIXEkENalK0cvkmcGaAb6Hs3Gh+TTM1KJUFakzz7mEyB56Dpz+c8m8JzJa1WBf6fg8m0KK1QMnlG",
+	"T4WcsFxoyKy4BsMyJcdiUmnI2VhpZqfAtKosJGlisinMOI5i5yUkzxJjtZCT5O4uTV5e8Mny+BdTYNeg",
+	"jVCSqbEbDIyqdAYpDV8ZYEKys/HBD9xmU8Zljv/8S0lwv6yZ9jU39geVi7GAPD793y8ufmQ5txAAKLix",
+	"LJtyOQFmVQemNZO9Bavnp2MLOj6VrGYj0DiPgUzJ3OD4N1xYNoKx0jiP1XMcLTKPkBYmoJM7nKnkms/A",
+	"+j08y2FWKgsym/8T5stzn7JKil8rYFcwZ9lUGZBsNCfMskKAtMxOuWUzfgXG4/trBcYyw8e0BgTXIUP8",
+	"BBh2I+yU3jN85gbFbRmpfE6PhPSDWJAIAbsRMlc37I9PnrKpqrTByXMY86qwf2ITsGGNSyVNvQ1joY0N",
+	"kKTNnDWy9uAtlAWfQ84cPTMD9vBSJmkiEGv3Y5Imks8gedZepQNcpvYaz/jta5ATO02ePfn66zSyt2dj",
+	"R27RjUXiDnAHcsa/PRUJw0bcQM6UPGQXrfUdc1H45Xx68oSJLgewKTdsBCDZzFMwM0JmsArH8SCuOBsH",
+	"njjHASMUIxu+CCD77RGGfXX8lP1LWRYGiQIuVeCiADY7m0iFcuNmCrLLxThqqcGAtGuw81MeOMDXoYkz",
+	"9Owb7pnBTfMbZtr8MFVFboYhrmQg2Rm+klVar8VioPi6S5MwO/H5K6VHIs9B4j+ZkhakxT95WRYi44jY",
+	"0X+MosfNqH/QME6eJf/vqNEBR+6pOXqptdJv/Ry0Zu2xSq1GBcz+vNmYP7qvHPzdNa8RSGmxKwOaFTy7",
+	"MowzrQqgX1UJmuYnNhEaTHKXtrn3nzA/k+8MPNZVOK35vytIRYMiCVVhmLGiKNgIUAeXWmVgDOTLy/EW",
+	"KgP5Y12Pi+ky7jfcMF5o4PkcycTZGpzlYjwGjRzqlxDX4kKpH7icv3W/mMe8DF78wG0GkEPupCq3wAox",
+	"EzaomJpDkrRtypH5cVDbHzEw/NtHLUvljkDx8OF3p5WdgrQeT/yl1DijFU4KldyYG6V7zKnw1G1Yd6gl",
+	"vZomKAKcXIwNFp4OGoykJQmMPHn2czNy2kD8vv5Ijf4DGVFPF916u5fQtuoKZBzMf/x0wejxNnC6cWOQ",
+	"vZQTPoEZSPtdlV2BXYZpRL+fW65tHDKDjwLZuLeTNBkrPeM2eZagej+wghZpaWuyQmRXF1Otqsn0LbeR",
+	"LXrnrEp60bBcXAuk2dE8mJuqBGlSduwUvp2CBsY1MKncow4oqhoVLTicrVzDYdZZ1PQWg2tycVrjCmm/",
+	"eZqkS1Z0mjgQ1gyLL200qkP9+SCYc2GskJllGjJRIucbZ4kTMpBvMuObIdj0TohoDptvgXrbFBiWtAtS",
+	"vYELaxMhsNVMcG65Ncs8sCfTPZmuJdNdEGbHOlgmS5X3KDXADxk9j63TWEDRo1vpkV96TlaRDeOl6IoI",
+	"izbjlVQ3UV07A2P4JObtsfr/6wBfeHmd+vJ4hNejK4V0+V3wwrrL5Gk2vlDXbucVE3ICxiZpIizMzFq7",
+	"Db97rgF3DtHmt2fus6+Pj9NkJqT/96SGlWvNMSBwe6B4KQ4QpwnIA7i1mh9YPqEpr3khchIw9QKkMyG/",
+	"PUln/PZbHDsX1xDctmaFPIqrV6afkHiWQWkhX8c0frWMVZpYZJmycq3KcvBA/m02AqI1ZjXPrtAPEShD",
+	"DR8VrQgg8uN6LqxRaWDpXRW/f0vLgTOd9eBw9qIOHik9oz8IGTaCQskJktISPW+x6VUlcsJNZeTt56cR",
+	"6+snr0s8BFNekuxKQ+DLhNiiFTMKIDgadxbjMAOtlo7R+I0qOSo3kYO0GKzQTYDVf8aUZtfCCKt0knZD",
+	"YN8Qn4T/T+5h0RpOefL1N7R6xkI5cCfx1d1vpxspBs6VkDmxRm2MOEiSNAFZzZC2rwXceAsoaJDE4fgB",
+	"RVQBtkPt24OrJKjxtzid0/o0FVuYCNGpdBHHxqtt9u7taxdkXzBK2pRw/PSv28OsZiivSztPK124vT9+",
+	"+tdlCUnjp4G1a8poU3hMTrxSenYqeTG3IjN9zlGPXyR+qwPNxIAGKLLtvjExbhtrNRvgZGkolbaQM42h",
+	"z5TxQkwk5IHb3QQ0/2A+H1dSQmQvz5Er3EPMkRCTKO1ijYMU5Sv6FodJ7hZ0IfInFON1I5xDMT7L3dva",
+	"r/tCmLVhmRK0xz9lqsgpAC70Bnp90R+OAG1VfI/AMfCOdsgqy4sN4HeuzCIb0IJ7QiNM0qR22f0M9TrX",
+	"VNHHGH3609m2Xpe/lKTGl5fsTOYYvwCDZiV90tH/4L+r5x4pVQCXOHl/NAeftMV6V9SceONsN0rn5PjY",
+	"qewS5GbY4xeDkUceXM92Ss+Q6dwWnTrSXaAFH7ly4/XtcTAav4/Fhr6sjV5imV1vxGbCa5Nt+x7saRA3",
+	"VYkkt9oAJAtG6ZAoEmSkQ2koxt1KNueDxY3PWA1Kp+OIKRMy0yR5KAmJql7PF3LcAcwavvX2vBdYHVqN",
+	"72waJ8cGlfZS9pF7wzMxd7sO+EcMHvcwLAkC+jkM3mEMswzdw0hGmjcKHdkUbfCYkBt4fZ48wg75mdZv",
+	"smOymGtD2h4Bqp0DM9jk6UzRDQ+c7DA8cNKEB9JkSZLsCMm2fojYSs1rMoeVoazhvNW4ay63GcQ1Zc7a",
+	"srqRZsP1SOCNpSE2l/Y7ofSuPNyA4Fcr8m2W/3e20JvrPBp1peL7xP1sQFq1s+/K/MvWV42P/kUqrBh4",
+	"TmPd9ax674rv2h7eqUUbxbZx3yP0FUJBWyd3wgjtTR6Q58HI7pvxeN28i9NhQAujzZVluchd9ZiHYDsA",
+	"4rnAF1qVB2o8bqcB3dR1AlAqqmT0AMXm7k8CPm4RSxhvTTArFmxglm7Zn0hqqNIWSTdE1t3tmCAOBTcx",
+	"E+vtq+fsL389/gvztTwsB8tFccgot2coCazBVlpC7lZPGOaQakq3+mqCaimOINWp5br0y+VCDBM2pepZ",
+	"blgno4iu2L+dHBRKLjyyU9A3wvhK0CGJx1NmLMqWlM2opBsONPAcf2nlIw/ZG++jSgta8uKDzy2WoGfC",
+	"oLL7kIMUyCxCkpT+IGRZ2eZfj94HZKGUXdcIfMBSV/wu1Ih+kMp+GKtK5imbgZ2qnH7hRaGIGUtNJdKi",
+	"83Gm5LgQmaW/fKXlB+c4pqySvkIOsfoA0go7bwDLNFAmhBcGX8UiHaXFbziqVerDjMt5AN64ZV3iTEcd",
+	"UUqC27LgkjBlpoRMjEXmHGthmE8TuXrVpVFpidea9AukYHabKRbSWB4tDHYlXnbaBFNpyeLpKXq0PsVT",
+	"13hzE2TUfx34ar6DsxdNBW5dSbs0l7HcVmZFgb97oT/rboUt4mwzVdoyU81mXM8DxEFe+MTFEjjxTNIp",
+	"e/f2LCTksMx/aSzMDmpxjYlVrVwCswtxXw2Zh4OQqFcjdbIgJhW9AonmDeklkl5cyADl2QuSUlMNY1wE",
+	"3j4S0ZU/U6Li2Ea8e/u6PmOh+ksCxVqC6f96YV0E6goCKLYICzwVyTX3M9g/zt/8q8MIcY5rBGBgPjic",
+	"HDpv/ucn7w8bnwJuOao3wmXp4fBSjovIpKGWo8nWI6xp4LWCy0mFjyVMlBUcTT7Sbqekog5e++cxOOgc",
+	"Sp+U8EdU6oMRQhU0uK4KCFIITwooCZ0FQPcjMhd+thZhfMntAkZXw5Sd4Wf8di3huJ1v1tnPPoCKdlAR",
+	"tL2GeIiKnxq+AYtjYpAUwpCru0i2g8NdC5PEQl0/wWiq1FVf9JhS3xfzElZVIpGEprKNHApxPTzN6id/",
+	"GSZJ7nYSZsTwoq8OQBv1MCNkqUZnduij6yR5uv/k4Lw8U428jXfYrSNYVXEzpjobxTRkQJTkaofUOGXo",
+	"uUcfMV4U9GGt7W+UvjIlj9hHW9UahHoOA5nuLQCgZ+5ogVXMiIkM2yrApGwCErQThWi54+hOjqyKwnxz",
+	"b3GObzpxmN5Kjqm15R/Nn0iz+vXlGlipjCW87rOYo6mX0UVKJQ8GtcS3CMOK2g6EPW0zWExMeA554TZg",
+	"fmppLZb5lLsHaxJu/i3SADOeD0/j55U7VvFDRAz8Xd0wrDrqzGCVukIinomiEP5M57AgBQSLYxGHeWcC",
+	"5/j0W7zPe3XKotXbOLL+5KfnS50yPjIgLSpkqRpjG1fPv5MPKO1r7UxnIQds949eRXX3uhaty7g1jMqU",
+	"98pLp4o2kcdh+jVZkrLXzvIOaJh7eZPxwbn4refzGb8Vs2rWDq00eJWg+8elYpD4oPQoOuQW8Ri3mn4J",
+	"WugECAbs7crMhiea1ypyMvy0KAIXOC7ermaoR7JE9jlMti7+5Viygc3rsEAMfsHnLJtnRXz7vGJeKcPq",
+	"YZAN/QeD5RhJ2/V+t58Dcqc7Uu+aZrxgPNPKGKahQz/xiS68s7upCSTh1vr9WLkU+F4tEIVhOGZeUTwI",
+	"HZoSZE4tCqKUvnKdSj4vFF/l4eUqq1ztWlCpHdl5H/HbELjYgIrP3Uc94dOw++3tacUEajpv8G+T5ACe",
+	"Pl8RbMFpwEUIAgkfsheNXCPX7AqgJD6izLDveyCC2wm3Dn3BCzbi2RVG6zH2ADJnVcly4JjKy8DvhNVz",
+	"NqryCRBxwO2UV7hVLoYXqnM9keA6VJk7W4lqCnikJrdB+GWbvJdxxc8cqqF2prGhl+qD2ya5J9Bgh/tY",
+	"9+K/3jAnmCOm+Qq4h9UV3LiXzYaidI26jLy17AkPEIAeuu3l30B3rhaCn+LNLS7CepephaEXJqbtPHmT",
+	"DOVb7Ry1NywidVb4OmJCcTz3TsqULOaNOXizuOKiveCfKN56PZcVHsvq6IMXcktuxWoZ5lao0sLOzxFI",
+	"RxnfAdeg8QAw/jei/14FCvvHTxfJ4hHtdwYYd4GKlYd+XecIkymkMJIQzVShqwGpFRJ+qvBtV6gfwqwy",
+	"GBa7Bh8rhFxYpevYXX32O6RpXRGf86dRTAYqOQytJSiPTNM3i4tOm/PVhBxHS6aFQULgkvnAGTv98YyA",
+	"mHHJJ0hOzZQUsTy8lJfyZTuS5LYWg7UFJlow5aU06WqvRhcCjCERcCldoP+QnVelr9UOQUo35ks5KYSZ",
+	"sj86M8Z1kknZeckl/sxlfilfaZDZ9P93Q5zk7wvT1IB76+25C7U2oHgIEKc3zYqTfuKS3R5olD/uUD7c",
+	"WpAk82k369P6kJMVj5uaMqUvJf7nD/jzPNdgjGO+SraoB80a76G1zvxz6xLIDtxLaUBfg657MXmie164",
+	"dKzCZ80IqBo5e/rkby3njhC5lK2WAWtRHgO3lYYWvm64nD09fspupsL37wjvqfGlbEFuGMYL0HZjqNH9",
+	"wkcRuZR1MoN6hsyQ+FoFN8+Sk8Pjw+NQdMFLkTxLvqKf0KixU+Luo+ZsIYqXiDL0KdjFUz0t8hjN/Y4d",
+	"GJH7M3CgzSHDI6RE++2zM0QA4dzc2DHtzPMpcrRP6Gi/bsQJofojHKlzyNdcjlokOaOjYS/D+Zx286ef",
+	"45K4eeVooTnU3fs6M/edyuf31yyjOeS5EAOyuoLFTjZPjp/sYOZWr46l7hov/Q75I4jOdXD5Yq/Znh4f",
+	"3xtMfUmBnTcR+Y7nTT7V574Z5egdiiePtTvKu07KPmDWyuo7/J4+VvyIPptDoIvy0mH3t74JauY6ivVJ",
+	"wm+fPNn0W99UiD4eMPFiFx5E0afNaxHGOBsho8bOUhqKRXvlQQETf+a7rWyb33H4I5SvbfneFZsu2/PK",
+	"lTt9kUKzdVBrkNC8P+4NJvoyIT73aSNylI0ZV0Ux34vH34l4/Op30DlOSFONxyIT/kypD42Yloh8nF3h",
+	"6ihSc3witIYjEzr0Q4NbYaxBn4LxQM1btpK7lJ+oHFqONcnVtkv9c+J81+T93fu2LnAShnEm4YaQboT5",
+	"0UcXOrlDeCYQEerfg0Wp+d2cIpwLUn19SwjlY43XENozor/QNGesz6B3RfHKPpPrtUmrD+Wg17vdOZ32",
+	"6SiC43tVQZ2Q3jJlnteaYMGBpCV1VZGm24qOugoftNoKr2pG12lB3GoVvOobesf39z1oN/hd9VGnGTAh",
+	"+pUzGHuidNE2pstNXlstQ7ur8ICI7LXzXjs/Au389BFjOCNZQCXrC87N9xRgq+u+nUqjSFQ2XdZg7oDS",
+	"pykxlyp6UBXm1dcOPSG3MMM8oc+tALcV9HtZvZfVe1n9sLI6TZ6ePHnMXaHrvvpU0di5C6BjjU7ENcj2",
+	"dRnb+WdODrvcHx32m/R5aUe83X5rlb/W9Ona0mkrlbZMyftUeQtCPtrFi5qD+UZR7c59BnCpcz434e6O",
+	"X6z6JUD3awV63gLPdXRqgBlSxrAM4ctID6s++KS66QHGqnsAZVgPtdj0dUerBgQPN8Ewb5XM4F0hSep/",
+	"vAG4itS87Nw3bgg3FrRvYtg1J7RJ977jpnsdv08m3Yu3Aj2Ey3slPdV4HH10rRnvHNsWYGFZ4r+g30MD",
+	"jLiPs50MH6IpHFSMuyiql7yD5Fm052gYMK53Wo0qd+VqdURbJFh13krTsHCWp4PEPnmzdzn2LscDCFyS",
+	"GHu/Y2u/40UQ3R3Rtcqr6FcwQ1TFBKzvxOjVxI48ixXKZXUyakfqpZWM2rX1vNSFbtPsEi3U50wxrc8S",
+	"EYgPlyraRxD36nyvzj+DOl+X8qk11tq8z6epLZf7WWgL+uCaa1UG6gHcot1koFodGz9DFmo7ZbnXI3s9",
+	"stcjj8stfMxVisOqE/fe7z1l3bxVsS4ge6RB5u7e16jD7Hp8f6rxETqJdi5g+hxes/S33NyX7bHN7Vm2",
+	"1alZGA+UO1nUk/uqv90IvIfx0Tst4GOWB659QDfa2335fKfbNLBIzGYfht5nxb50r86xQTsC6e8dbziX",
+	"JHGhJkL2Hzx6jY/fGRJRu3BVFm7ofmBXpefC7NXeiluxLUSAkvBm3Htka60wSDeiwPd7dl9k962P/jX2",
+	"T9fMMaA9NSwd7fNEghxmj7Kjj9ReoX0UpIvAv0Gjana9E4y/xC0oJfo2RcZVOjeMtw9qNz0v6jL/hVb/",
+	"vhE/nfDWkAsNWXNjp9JiIiQvsInFIbvgs5L0Ps0Ymob/BzIbP8xNVwQ89/dDrrXAomjFLZ/waHvL4it3",
+	"KrsLxVuPfgz7buzhdWjL29OPvvmubuQr5FWyCsK7x61x3klqsc2UZrYmk+5W7oLHiLoO6okCAS8zXHgl",
+	"8Jw6+liKWyj6ee4ttY1Bfjq5PUFcpCm5Rp76/uyVa3tCI6BF3MuWbKxcm/fQ6eKXw4kY/9J0ljhkp7J1",
+	"p7kzr5GR6RaXYh5h4O5dGp5/e/nvjbumdTv28z0xAtQUubiN8yStxUqeLLm1oPHL//n59OC/+cFvxwd/",
+	"+3B48P7Pl5c4/h+SbWrexIxP4Ggixl0yrov9RkJyPY8MHXH4Ixu9E6rFPWmINqzcSoqtOyL1ub2vhbE/",
+	"hZd2aBMtNt6KLWRR1C2WTDWqH5n/G17DZ2ld0DHukRaaHlp3aY/x7k6n+g39UhsHdPtZP3DvgFgjuGF9",
+	"BFIfQnEfBh1BEl1JWLxzoNu3bB8/+D3lK1Zzc50Z2C6Ueu7E6wgYr0WuVc5W8L1GOurj6KP/a1Blqyf/",
+	"zWOoN7VMiVgKNQSfGBvcpEQ0rA16OcKadgvVPSUuUeKj9kQ80S5GvrYu0Qu0vKJA78vmk+MHVoe9ZW6B",
+	"CUOR2z6G/Aj5qK8uqGaTPnVz1JK5A5yYppPyZ2epNH7pj7tQ3HWXTRndE0E5IstOerJivrt85DzYCV2v",
+	"gU3y29ektLrVr7mpMNpOvw8E39k+AsaTY7pGw8Ph7zbth+oBZE3n5oSo004b0VmDFNvtNP3z9yfT9vLz",
+	"C5Sf5KS3GJfOpG0iRY8++r/na7pILbDSF2GmpINmrDvrx6du8P/STKTojSsbmEoBs72t9Kh4vd62zYym",
+	"5jvafHRQwy0ghZpsLgyO6ttM+jP3b8MrCxS7lww7at68mWS4aN+M074Khso0xGwGueAWmntr5vtAxu80",
+	"kNEnVDaOFXoSaoUK66GtYiNghk7JT7jwlTb9U7ihqaN9n5AYceMvUKbCoqKgexVA5qUSrgcyXZmRHPFS",
+	"HF2fYEnK/w4AD/5LbL2pAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
 }
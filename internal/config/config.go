@@ -1,8 +1,7 @@
 package config
 
 import (
-	"gopkg.in/yaml.v3"
-	"os"
+	"time"
 )
 
 type Config struct {
@@ -10,23 +9,201 @@ type Config struct {
 		Port      int    `yaml:"port"`
 		BaseURL   string `yaml:"baseUrl"`
 		PublicUrl string `yaml:"publicUrl"`
+		// ShutdownTimeout bounds draining requests and stopping workers on
+		// SIGTERM, it should stay below the grace period of the orchestrator
+		ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+		// DrainDelay is how long the server keeps serving after reporting
+		// that it is not ready, so that load balancers stop routing to it
+		// before it stops accepting connections. It is part of
+		// ShutdownTimeout.
+		DrainDelay time.Duration `yaml:"drainDelay"`
 	} `yaml:"server"`
-	Log struct {
-		Level  string `yaml:"level"`
-		Pretty bool   `yaml:"pretty"`
-	} `yaml:"log"`
+	Log      LogConfig      `yaml:"log"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Tracking struct {
+		OpenDedupWindow time.Duration `yaml:"openDedupWindow"`
+		// MaxEventAge is how far in the past ingested events may have
+		// occurred, bounding how many hours of rollups a batch can reopen
+		MaxEventAge time.Duration `yaml:"maxEventAge"`
+		// Secret signs tracking links
+		Secret string `yaml:"secret" env:"TRACKING_SECRET_KEY" secret:"true"`
+	} `yaml:"tracking"`
+	Analytics struct {
+		RollupInterval time.Duration `yaml:"rollupInterval"`
+	} `yaml:"analytics"`
+	Webhooks    WebhooksConfig    `yaml:"webhooks"`
+	Outbox      OutboxConfig      `yaml:"outbox"`
+	Cache       CacheConfig       `yaml:"cache"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Validation  ValidationConfig  `yaml:"validation"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	CORS        CORSConfig        `yaml:"cors"`
+	// RateLimits holds the limits named by the x-rate-limit extension of
+	// operations in the spec. Operations naming no configured limit are not
+	// limited.
+	RateLimits map[string]RateLimitConfig `yaml:"rateLimits"`
+	// Features switches the features named by the Feature constants on and
+	// off. Features that are not listed are on.
+	Features map[string]bool `yaml:"features"`
+
+	// File is the configuration file that was read, empty when there was
+	// none
+	File string `yaml:"-"`
 }
 
-func LoadConfig(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
+const (
+	// FeatureIngest serves the operations whose x-feature extension in the
+	// spec is "ingest", i.e. event batches. They respond 404 while it is off.
+	FeatureIngest = "ingest"
+	// FeatureWebhookDeliveries sends webhook deliveries. While it is off they
+	// are kept pending, and sent once it is on again.
+	FeatureWebhookDeliveries = "webhookDeliveries"
+)
+
+var features = []string{FeatureIngest, FeatureWebhookDeliveries}
+
+// Enabled reports whether feature is on.
+func (c *Config) Enabled(feature string) bool {
+	enabled, ok := c.Features[feature]
+	return enabled || !ok
+}
+
+type DatabaseConfig struct {
+	DSN string `yaml:"dsn" env:"DATABASE_DSN" secret:"true"`
+	// MigrateOnStartup applies the pending migrations before serving, one
+	// replica at a time
+	MigrateOnStartup bool `yaml:"migrateOnStartup"`
+	// ConnectTimeout is how long to retry at startup while the database does
+	// not accept connections yet
+	ConnectTimeout time.Duration `yaml:"connectTimeout"`
+	// MaxOpenConns bounds the connection pool, 0 for no limit
+	MaxOpenConns int `yaml:"maxOpenConns"`
+	// MaxIdleConns is how many unused connections are kept open, 0 for the
+	// default of database/sql
+	MaxIdleConns int `yaml:"maxIdleConns"`
+	// ConnMaxLifetime closes connections once they are this old, 0 for never
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
+	// StatementTimeout makes Postgres cancel statements running longer, 0
+	// for no limit. Migrations are not limited.
+	StatementTimeout time.Duration `yaml:"statementTimeout"`
+	// QueryTimeout cancels the queries of an API request once the request
+	// has run this long, 0 for no limit
+	QueryTimeout time.Duration `yaml:"queryTimeout"`
+}
+
+type AuthConfig struct {
+	// JWTSecret signs the tokens issued by /login
+	JWTSecret string `yaml:"jwtSecret" env:"JWT_SECRET_KEY" secret:"true"`
+}
+
+type LogConfig struct {
+	Level     string          `yaml:"level"`
+	Pretty    bool            `yaml:"pretty"`
+	Redaction RedactionConfig `yaml:"redaction"`
+}
+
+type RedactionConfig struct {
+	// Keys are regular expressions matched case-insensitively against field
+	// names and query or DSN parameters whose values are masked
+	Keys []string `yaml:"keys"`
+	// KeepIPs disables truncating IP addresses to their /24 or /48 network
+	KeepIPs bool `yaml:"keepIps"`
+}
+
+type WebhooksConfig struct {
+	PollInterval   time.Duration `yaml:"pollInterval"`
+	BatchSize      int           `yaml:"batchSize"`
+	Timeout        time.Duration `yaml:"timeout"`
+	MaxAttempts    int           `yaml:"maxAttempts"`
+	InitialBackoff time.Duration `yaml:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`
+	// AllowedNetworks are the CIDRs or addresses of non-public networks,
+	// e.g. loopback or private ranges, that receivers may be in. Any other
+	// non-public target is refused at delivery time.
+	AllowedNetworks []string `yaml:"allowedNetworks"`
+}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+type OutboxConfig struct {
+	PollInterval   time.Duration `yaml:"pollInterval"`
+	BatchSize      int           `yaml:"batchSize"`
+	InitialBackoff time.Duration `yaml:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`
+	Retention      time.Duration `yaml:"retention"`
+	NATS           struct {
+		URL           string `yaml:"url"`
+		SubjectPrefix string `yaml:"subjectPrefix"`
+	} `yaml:"nats"`
+	Kafka struct {
+		// Brokers are the host:port of the Kafka brokers to produce events
+		// to, none disabling the Kafka sink
+		Brokers []string `yaml:"brokers"`
+		Topic   string   `yaml:"topic"`
+	} `yaml:"kafka"`
+}
+
+type CacheConfig struct {
+	Forms struct {
+		Size int           `yaml:"size"`
+		TTL  time.Duration `yaml:"ttl"`
+	} `yaml:"forms"`
+	InvalidationChannel string `yaml:"invalidationChannel"`
+	// Control maps routes such as "GET /form/:formId" to the Cache-Control
+	// header of their responses
+	Control map[string]string `yaml:"control"`
+}
+
+type IdempotencyConfig struct {
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type ValidationConfig struct {
+	// Responses enables validating responses as well, which buffers every
+	// response and is meant for debugging
+	Responses bool `yaml:"responses"`
+}
+
+type MetricsConfig struct {
+	// Address is where /metrics is served, e.g. ":9090" to keep it off the
+	// public port. Empty serves it on the API port.
+	Address string `yaml:"address"`
+}
+
+type CORSConfig struct {
+	// AllowedOrigins lists the origins of browsers allowed to call the API,
+	// "*" allowing any. Empty disables CORS.
+	AllowedOrigins []string      `yaml:"allowedOrigins"`
+	MaxAge         time.Duration `yaml:"maxAge"`
+}
+
+// RateLimitConfig lets each client make Requests requests Per period, in
+// bursts of up to Burst requests.
+type RateLimitConfig struct {
+	Requests int           `yaml:"requests"`
+	Per      time.Duration `yaml:"per"`
+	// Burst defaults to Requests
+	Burst int `yaml:"burst"`
+}
+
+type TracingConfig struct {
+	// Exporter is "otlp", "stdout" for local runs, or empty to record no
+	// spans
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the host:port of the OTLP/HTTP collector, defaulting to
+	// the OTEL_EXPORTER_OTLP_ENDPOINT environment variable or localhost:4318
+	Endpoint string `yaml:"endpoint"`
+	Insecure bool   `yaml:"insecure"`
+	// SampleRatio is the share of new traces that are recorded, 1 when unset
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+// LoadConfig reads the YAML file at path over the defaults, without
+// environment variables, flags or validation, see Load for those.
+func LoadConfig(path string) (*Config, error) {
+	cfg := Defaults()
+	if err := readFile(path, cfg); err != nil {
 		return nil, err
 	}
-
-	return &cfg, nil
+	return cfg, nil
 }
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// watchDebounce groups the events of one save, editors and Kubernetes
+// writing a file in several steps
+const watchDebounce = 200 * time.Millisecond
+
+// Snapshot is a configuration applied by Live, numbered from 1 on every
+// applied reload.
+type Snapshot struct {
+	*Config
+	Version uint64
+}
+
+// Live holds the configuration of a running server and reloads the settings
+// that are safe to change without a restart: log.level, cors, rateLimits and
+// features. Changes to other settings are reported and ignored until the
+// next restart.
+type Live struct {
+	log      *zerolog.Logger
+	load     func() (*Config, error)
+	current  atomic.Pointer[Snapshot]
+	mu       sync.Mutex
+	onReload []func(*Config)
+}
+
+// NewLive starts from cfg, load being called on every reload, typically
+// Load with the arguments and environment of the process.
+func NewLive(log *zerolog.Logger, cfg *Config, load func() (*Config, error)) *Live {
+	l := &Live{log: log, load: load}
+	l.current.Store(&Snapshot{Config: cfg, Version: 1})
+	return l
+}
+
+// Current returns the applied configuration, which must not be modified.
+func (l *Live) Current() *Snapshot {
+	return l.current.Load()
+}
+
+// OnReload registers fn to be called with the new configuration after every
+// applied reload.
+func (l *Live) OnReload(fn func(*Config)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onReload = append(l.onReload, fn)
+}
+
+// Reload loads and validates the configuration again and applies its
+// reloadable settings. An invalid configuration is rejected as a whole,
+// keeping the current one.
+func (l *Live) Reload() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	loaded, err := l.load()
+	if err == nil {
+		err = loaded.Validate()
+	}
+	if err != nil {
+		l.log.Error().Err(err).Msg("Rejected configuration reload, keeping the current configuration")
+		return err
+	}
+
+	current := l.current.Load()
+	next := *current.Config
+	next.Log.Level = loaded.Log.Level
+	next.CORS = loaded.CORS
+	next.RateLimits = loaded.RateLimits
+	next.Features = loaded.Features
+	if !reflect.DeepEqual(next, *loaded) {
+		l.log.Warn().Msg("Configuration changes other than log.level, cors, rateLimits and features are applied on restart only")
+	}
+	if reflect.DeepEqual(next, *current.Config) {
+		l.log.Debug().Uint64("version", current.Version).Msg("Configuration reloaded without changes")
+		return nil
+	}
+
+	l.current.Store(&Snapshot{Config: &next, Version: current.Version + 1})
+	for _, fn := range l.onReload {
+		fn(&next)
+	}
+	l.log.Info().Uint64("version", current.Version+1).Msg("Configuration reloaded")
+	return nil
+}
+
+// Watch reloads the configuration on SIGHUP and whenever its file changes,
+// until ctx is done.
+func (l *Live) Watch(ctx context.Context) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	var events chan fsnotify.Event
+	var watchErrors chan error
+	if file := l.Current().File; file != "" {
+		watcher, err := watchFile(file)
+		if err != nil {
+			l.log.Error().Err(err).Str("file", file).Msg("Failed to watch the configuration file, reload with SIGHUP")
+		} else {
+			defer watcher.Close()
+			events, watchErrors = watcher.Events, watcher.Errors
+		}
+	}
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangups:
+			l.Reload()
+		case event := <-events:
+			if concerns(event, l.Current().File) {
+				debounce.Reset(watchDebounce)
+			}
+		case <-debounce.C:
+			l.Reload()
+		case err := <-watchErrors:
+			l.log.Warn().Err(err).Msg("Configuration file watcher failed")
+		}
+	}
+}
+
+// watchFile watches the directory of file, since editors and Kubernetes
+// replace files rather than write them, which ends watches of the file
+// itself.
+func watchFile(file string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		return nil, errors.Join(err, watcher.Close())
+	}
+	return watcher, nil
+}
+
+// concerns tells whether event may have changed file. Mounted ConfigMaps
+// change through the swap of a hidden ..data directory.
+func concerns(event fsnotify.Event, file string) bool {
+	name := filepath.Base(event.Name)
+	return name == filepath.Base(file) || strings.HasPrefix(name, "..")
+}
@@ -0,0 +1,264 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// DefaultPath is the configuration file read when no -config flag is
+	// given. Unlike an explicit path, it may be missing.
+	DefaultPath = "server.cfg.yaml"
+	// EnvPrefix starts the environment variables overriding settings, e.g.
+	// FORMS_SERVER_PORT for server.port
+	EnvPrefix = "FORMS_"
+	// fileSuffix marks environment variables naming a file to read a setting
+	// from, e.g. FORMS_AUTH_JWT_SECRET_FILE for Docker or Kubernetes secrets
+	fileSuffix = "_FILE"
+)
+
+// Defaults returns the configuration used for everything that neither the
+// file, the environment nor the flags set.
+func Defaults() *Config {
+	cfg := &Config{}
+	cfg.Server.Port = 8080
+	cfg.Server.BaseURL = "/api/v1"
+	// Stays below the 30s grace period of Docker and Kubernetes
+	cfg.Server.ShutdownTimeout = 25 * time.Second
+	cfg.Server.DrainDelay = 5 * time.Second
+	cfg.Log.Level = "info"
+	cfg.Log.Redaction.Keys = []string{
+		"password", "passwd", "secret", "token", "authorization", "api[_-]?key", "cookie", "dsn", "username",
+	}
+	cfg.Tracking.MaxEventAge = 24 * time.Hour
+	cfg.RateLimits = map[string]RateLimitConfig{
+		"login":    {Requests: 10, Per: time.Minute},
+		"ingest":   {Requests: 600, Per: time.Minute},
+		"tracking": {Requests: 120, Per: time.Minute},
+	}
+	cfg.Tracing.SampleRatio = 1
+	return cfg
+}
+
+// Load builds the configuration from, in increasing precedence, the
+// defaults, the YAML file, FORMS_* environment variables and command line
+// flags. Every setting has a flag named after its path, e.g.
+// -server.port=8081, and fs may carry flags of the caller. The result is not
+// validated, see Config.Validate.
+func Load(fs *flag.FlagSet, args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Defaults()
+	settings := settingsOf(cfg)
+
+	path := fs.String("config", DefaultPath, "path of the YAML configuration `file`")
+	var overrides []func()
+	for _, s := range settings {
+		fs.Func(s.path, "overrides "+s.path+" (env "+s.env[0]+")", func(raw string) error {
+			parsed, err := parse(s.value.Type(), raw)
+			if err != nil {
+				return err
+			}
+			overrides = append(overrides, func() { s.value.Set(parsed) })
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := readFile(*path, cfg); err == nil {
+		cfg.File = *path
+	} else if *path != DefaultPath || !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	var errs []error
+	for _, s := range settings {
+		if err := s.fromEnv(lookupEnv); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	for _, override := range overrides {
+		override()
+	}
+	return cfg, nil
+}
+
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// setting is a leaf of the configuration that can be set from text.
+type setting struct {
+	// path joins the YAML keys leading to the setting, e.g. "server.port"
+	path string
+	// env lists the environment variables of the setting by precedence,
+	// the FORMS_* one first and then a legacy name, if any
+	env    []string
+	secret bool
+	value  reflect.Value
+}
+
+func settingsOf(cfg *Config) []setting {
+	return collectSettings(reflect.ValueOf(cfg).Elem(), "", nil)
+}
+
+func collectSettings(v reflect.Value, prefix string, settings []setting) []setting {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		path := prefix + name
+
+		if field.Type.Kind() == reflect.Struct {
+			settings = collectSettings(v.Field(i), path+".", settings)
+			continue
+		}
+		if !settable(field.Type) {
+			continue
+		}
+		env := []string{envName(path)}
+		if legacy := field.Tag.Get("env"); legacy != "" {
+			env = append(env, legacy)
+		}
+		settings = append(settings, setting{
+			path:   path,
+			env:    env,
+			secret: field.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+	return settings
+}
+
+// fromEnv sets the setting from the first of its environment variables
+// that is set, either directly or through a file named by its _FILE variant.
+func (s setting) fromEnv(lookupEnv func(string) (string, bool)) error {
+	for _, name := range s.env {
+		raw, ok := lookupEnv(name)
+		if file, fileOk := lookupEnv(name + fileSuffix); fileOk {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name+fileSuffix, err)
+			}
+			raw, ok = strings.TrimRight(string(data), "\r\n"), true
+		}
+		if !ok {
+			continue
+		}
+		// Empty strings and lists are meaningful, e.g. an empty
+		// metrics.address, but there is no empty number or duration
+		if raw == "" && s.value.Kind() != reflect.String && s.value.Kind() != reflect.Slice {
+			continue
+		}
+
+		parsed, err := parse(s.value.Type(), raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		s.value.Set(parsed)
+		return nil
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func settable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	default:
+		return false
+	}
+}
+
+func parse(t reflect.Type, raw string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch {
+	case t == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(int64(d))
+	case t.Kind() == reflect.String:
+		v.SetString(raw)
+	case t.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return v, fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(b)
+	case t.Kind() == reflect.Int || t.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(n)
+	case t.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case t.Kind() == reflect.Slice:
+		// Comma separated, e.g. FORMS_LOG_REDACTION_KEYS=password,token
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	}
+	return v, nil
+}
+
+// envName turns a path such as "server.shutdownTimeout" into
+// FORMS_SERVER_SHUTDOWN_TIMEOUT.
+func envName(path string) string {
+	var b strings.Builder
+	b.WriteString(EnvPrefix)
+	var previous rune
+	for _, r := range path {
+		switch {
+		case r == '.':
+			b.WriteByte('_')
+		case unicode.IsUpper(r) && (unicode.IsLower(previous) || unicode.IsDigit(previous)):
+			b.WriteByte('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(unicode.ToUpper(r))
+		}
+		previous = r
+	}
+	return b.String()
+}
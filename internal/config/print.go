@@ -0,0 +1,26 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+	"io"
+)
+
+const masked = "[REDACTED]"
+
+// Print writes the configuration as YAML, with the values of settings
+// tagged secret masked.
+func Print(w io.Writer, cfg *Config) error {
+	copied := *cfg
+	for _, s := range settingsOf(&copied) {
+		if s.secret && s.value.String() != "" {
+			s.value.SetString(masked)
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&copied); err != nil {
+		return err
+	}
+	return enc.Close()
+}
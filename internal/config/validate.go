@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Validate checks the whole configuration and reports every problem at
+// once, each prefixed with the path of its setting.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(path string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	for _, s := range settingsOf(c) {
+		switch s.value.Kind() {
+		case reflect.Int, reflect.Int64:
+			if s.value.Int() < 0 {
+				fail(s.path, "must not be negative")
+			}
+		case reflect.Float64:
+			if s.value.Float() < 0 {
+				fail(s.path, "must not be negative")
+			}
+		}
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("server.port", "must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if c.Server.DrainDelay < 0 || c.Server.DrainDelay >= c.Server.ShutdownTimeout {
+		fail("server.drainDelay", "must be between 0 and server.shutdownTimeout (%s), got %s", c.Server.ShutdownTimeout, c.Server.DrainDelay)
+	}
+	if !strings.HasPrefix(c.Server.BaseURL, "/") {
+		fail("server.baseUrl", "must start with /, got %q", c.Server.BaseURL)
+	}
+	if c.Server.PublicUrl != "" {
+		if u, err := url.Parse(c.Server.PublicUrl); err != nil || u.Scheme == "" || u.Host == "" {
+			fail("server.publicUrl", "must be an absolute URL, got %q", c.Server.PublicUrl)
+		}
+	}
+
+	if _, err := zerolog.ParseLevel(c.Log.Level); err != nil {
+		fail("log.level", "unknown level %q", c.Log.Level)
+	}
+	for _, key := range c.Log.Redaction.Keys {
+		if _, err := regexp.Compile(key); err != nil {
+			fail("log.redaction.keys", "invalid pattern %q", key)
+		}
+	}
+
+	if c.Database.DSN == "" {
+		fail("database.dsn", "is required")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("database.maxIdleConns", "must not exceed database.maxOpenConns (%d), got %d", c.Database.MaxOpenConns, c.Database.MaxIdleConns)
+	}
+	if c.Auth.JWTSecret == "" {
+		fail("auth.jwtSecret", "is required")
+	}
+	if c.Tracking.Secret == "" {
+		fail("tracking.secret", "is required")
+	}
+	if c.Tracking.MaxEventAge <= 0 {
+		fail("tracking.maxEventAge", "must be positive, got %s", c.Tracking.MaxEventAge)
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" {
+			fail("cors.allowedOrigins", "must be * or scheme://host[:port], got %q", origin)
+		}
+	}
+
+	for name, limit := range c.RateLimits {
+		path := "rateLimits." + name
+		if limit.Requests <= 0 {
+			fail(path+".requests", "must be positive, got %d", limit.Requests)
+		}
+		if limit.Per <= 0 {
+			fail(path+".per", "must be positive, got %s", limit.Per)
+		}
+		if limit.Burst < 0 {
+			fail(path+".burst", "must not be negative, got %d", limit.Burst)
+		}
+	}
+
+	for feature := range c.Features {
+		if !slices.Contains(features, feature) {
+			fail("features."+feature, "unknown feature, known are %s", strings.Join(features, ", "))
+		}
+	}
+
+	if len(c.Outbox.Kafka.Brokers) > 0 && c.Outbox.Kafka.Topic == "" {
+		fail("outbox.kafka.topic", "is required with outbox.kafka.brokers")
+	}
+
+	for _, network := range c.Webhooks.AllowedNetworks {
+		if _, err := netip.ParsePrefix(network); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(network); err != nil {
+			fail("webhooks.allowedNetworks", "must be CIDRs or IP addresses, got %q", network)
+		}
+	}
+
+	if c.Metrics.Address != "" {
+		if _, _, err := net.SplitHostPort(c.Metrics.Address); err != nil {
+			fail("metrics.address", "must be host:port, got %q", c.Metrics.Address)
+		}
+	}
+	switch c.Tracing.Exporter {
+	case "", "otlp", "stdout":
+	default:
+		fail("tracing.exporter", "must be otlp, stdout or empty, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio > 1 {
+		fail("tracing.sampleRatio", "must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+	}
+
+	return errors.Join(errs...)
+}
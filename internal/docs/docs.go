@@ -0,0 +1,93 @@
+package docs
+
+import (
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/config"
+	"strings"
+)
+
+const (
+	specCacheControl = "public, max-age=300"
+
+	// initializer points the bundled Swagger UI at the served spec instead of
+	// the petstore example, relative to /docs/ so it works behind a prefix
+	initializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "../openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl
+    ],
+    layout: "StandaloneLayout"
+  });
+};
+`
+)
+
+// Handler serves the OpenAPI spec embedded in the binary and a Swagger UI
+// to explore it. The UI is bundled, so it works without access to a CDN.
+type Handler struct {
+	json []byte
+	yaml []byte
+}
+
+// NewHandler renders the spec once, with its servers pointing at the public
+// URL of this deployment.
+func NewHandler(cfg *config.Config) (*Handler, error) {
+	doc, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded OpenAPI spec: %w", err)
+	}
+	doc.Servers = openapi3.Servers{{
+		URL:         strings.TrimRight(cfg.Server.PublicUrl, "/") + cfg.Server.BaseURL,
+		Description: "This deployment",
+	}}
+
+	jsonSpec, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	yamlSpec, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{json: jsonSpec, yaml: yamlSpec}, nil
+}
+
+// Register adds the public documentation routes to the router.
+func (h *Handler) Register(r gin.IRouter) {
+	r.GET("/openapi.json", h.spec("application/json", h.json))
+	r.GET("/openapi.yaml", h.spec("application/yaml", h.yaml))
+	r.GET("/docs", func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, "docs/")
+	})
+	r.GET("/docs/*filepath", h.explorer)
+}
+
+func (h *Handler) spec(contentType string, data []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", specCacheControl)
+		c.Data(http.StatusOK, contentType, data)
+	}
+}
+
+func (h *Handler) explorer(c *gin.Context) {
+	path := c.Param("filepath")
+	if path == "/swagger-initializer.js" {
+		c.Data(http.StatusOK, "text/javascript; charset=utf-8", []byte(initializer))
+		return
+	}
+	c.FileFromFS(path, http.FS(swaggerFiles.FS))
+}
@@ -0,0 +1,47 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Format returns the strong entity tag of a resource version.
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// Matches evaluates an If-Match header against the current version of a
+// resource. An empty header matches, as does "*". Weak tags never match,
+// since If-Match requires strong comparison.
+func Matches(ifMatch string, version int) bool {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+
+	current := Format(version)
+	for _, tag := range strings.Split(ifMatch, ",") {
+		if strings.TrimSpace(tag) == current {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesWeak evaluates an If-None-Match header against the current version
+// of a resource. "*" matches, and tags are compared weakly as If-None-Match
+// requires.
+func MatchesWeak(ifNoneMatch string, version int) bool {
+	ifNoneMatch = strings.TrimSpace(ifNoneMatch)
+	if ifNoneMatch == "*" {
+		return true
+	}
+
+	current := Format(version)
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == current {
+			return true
+		}
+	}
+	return false
+}
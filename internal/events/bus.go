@@ -0,0 +1,45 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+type Handler func(ctx context.Context, event Event) error
+
+// Bus is an in-process sink that hands events to its subscribers. Handlers
+// run synchronously; if any of them fails the event is relayed again, so
+// handlers must be idempotent.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
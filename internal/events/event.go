@@ -0,0 +1,26 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"salesforge-assignment/internal/api"
+	"time"
+)
+
+// Event is a domain event as published to sinks. Events of a form are
+// published in Sequence order, but may be published more than once, so
+// consumers should de-duplicate on ID.
+type Event struct {
+	ID         string               `json:"id"`
+	Type       api.WebhookEventType `json:"type"`
+	FormID     string               `json:"formId"`
+	Sequence   int64                `json:"sequence"`
+	OccurredAt time.Time            `json:"occurredAt"`
+	Data       json.RawMessage      `json:"data"`
+}
+
+// Sink is a destination events are relayed to.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event Event) error
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaWriter is the part of *kafka.Writer the sink needs.
+type KafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// NewKafkaWriter returns a writer producing to topic on brokers. Writes
+// return once all in-sync replicas acknowledged them, and are not batched,
+// since the relay publishes one event at a time.
+func NewKafkaWriter(brokers []string, topic string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchSize:    1,
+	}
+}
+
+// KafkaSink produces events keyed by form ID, so that all events of a form
+// land in the same partition and keep their order. The event ID and type
+// are sent as headers, for consumers to de-duplicate and route on.
+type KafkaSink struct {
+	writer KafkaWriter
+}
+
+func NewKafkaSink(writer KafkaWriter) *KafkaSink {
+	return &KafkaSink{writer: writer}
+}
+
+func (s *KafkaSink) Name() string {
+	return "kafka"
+}
+
+func (s *KafkaSink) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.FormID),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: "event-id", Value: []byte(event.ID)},
+			{Key: "event-type", Value: []byte(event.Type)},
+		},
+	})
+}
+
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
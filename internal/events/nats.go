@@ -0,0 +1,48 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/nats-io/nats.go"
+)
+
+// NATSSink publishes events to the subject <prefix>.<event type>. The event
+// ID is sent as Nats-Msg-Id so that JetStream streams drop republished
+// events within their duplicate window.
+type NATSSink struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+func NewNATSSink(url string, subjectPrefix string) (*NATSSink, error) {
+	conn, err := nats.Connect(url, nats.Name("forms-api-outbox"))
+	if err != nil {
+		return nil, err
+	}
+	return &NATSSink{conn: conn, subjectPrefix: subjectPrefix}, nil
+}
+
+func (s *NATSSink) Name() string {
+	return "nats"
+}
+
+func (s *NATSSink) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(s.subjectPrefix + "." + string(event.Type))
+	msg.Header.Set(nats.MsgIdHdr, event.ID)
+	msg.Data = data
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+
+	// Wait for the server to acknowledge the message before it is marked as published
+	return s.conn.FlushWithContext(ctx)
+}
+
+func (s *NATSSink) Close() {
+	s.conn.Close()
+}
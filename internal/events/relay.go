@@ -0,0 +1,241 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/retry"
+	"salesforge-assignment/internal/tracing"
+	"time"
+)
+
+const (
+	defaultPollInterval   = time.Second
+	defaultBatchSize      = 100
+	defaultInitialBackoff = 5 * time.Second
+	defaultMaxBackoff     = 10 * time.Minute
+
+	pruneInterval  = time.Hour
+	maxErrorLength = 512
+
+	// claimLease is how long claimed events are held for the relay that
+	// claimed them, they are picked up again if it dies before publishing
+	claimLease = 5 * time.Minute
+)
+
+// Relay publishes outbox events to the sinks. Every event goes to every
+// sink and is only marked as published once all of them accepted it, so
+// delivery is at least once. A failed event is retried with backoff and
+// holds back the later events of its form until it succeeds. Events are
+// claimed in a short transaction and published after it committed, so no
+// transaction or lock is held while waiting for the sinks.
+type Relay struct {
+	log            *zerolog.Logger
+	txManager      repository.TxManager
+	repository     repository.OutboxRepository
+	sinks          []Sink
+	pollInterval   time.Duration
+	batchSize      int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	retention      time.Duration
+	prunedAt       time.Time
+}
+
+func NewRelay(
+	log *zerolog.Logger,
+	txManager repository.TxManager,
+	repository repository.OutboxRepository,
+	sinks []Sink,
+	cfg config.OutboxConfig,
+) *Relay {
+	r := &Relay{
+		log:            log,
+		txManager:      txManager,
+		repository:     repository,
+		sinks:          sinks,
+		pollInterval:   orDefault(cfg.PollInterval, defaultPollInterval),
+		batchSize:      cfg.BatchSize,
+		initialBackoff: orDefault(cfg.InitialBackoff, defaultInitialBackoff),
+		maxBackoff:     orDefault(cfg.MaxBackoff, defaultMaxBackoff),
+		retention:      cfg.Retention,
+	}
+	if r.batchSize <= 0 {
+		r.batchSize = defaultBatchSize
+	}
+	return r
+}
+
+// Run relays pending events until the context is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	r.log.Info().Dur("pollInterval", r.pollInterval).Int("sinks", len(r.sinks)).Msg("Outbox relay started")
+	for {
+		// Keep draining while full batches come back
+		if r.RelayPending(ctx) == r.batchSize && ctx.Err() == nil {
+			continue
+		}
+		r.prune(ctx)
+
+		select {
+		case <-ctx.Done():
+			r.log.Info().Msg("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes one batch of pending events and returns its size.
+func (r *Relay) RelayPending(ctx context.Context) int {
+	ctx, span := tracing.Tracer().Start(ctx, "Relay.RelayPending")
+	defer span.End()
+
+	pending, err := r.claim(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Error().Ctx(ctx).Err(err).Msg("Failed to claim outbox events")
+		}
+		return 0
+	}
+	span.SetAttributes(attribute.Int("outbox.events", len(pending)))
+
+	blocked := make(map[string]bool)
+	published := make([]string, 0, len(pending))
+	var held []string
+	for i := range pending {
+		event := &pending[i]
+		if blocked[event.FormID] {
+			held = append(held, event.ID)
+			continue
+		}
+
+		if err := r.publish(ctx, event); err != nil {
+			blocked[event.FormID] = true
+			if err := r.retryLater(ctx, event, err); err != nil && ctx.Err() == nil {
+				r.log.Error().Err(err).Str("eventId", event.ID).Msg("Failed to schedule outbox event retry")
+			}
+			continue
+		}
+		published = append(published, event.ID)
+	}
+
+	if err := r.repository.MarkPublished(ctx, published, time.Now().UTC()); err != nil && ctx.Err() == nil {
+		r.log.Error().Err(err).Int("events", len(published)).Msg("Failed to mark outbox events as published")
+	}
+	// Events behind a failed one wait for it rather than for the lease
+	if err := r.repository.PostponeEvents(ctx, held, time.Now().UTC()); err != nil && ctx.Err() == nil {
+		r.log.Error().Err(err).Int("events", len(held)).Msg("Failed to release held outbox events")
+	}
+
+	return len(pending)
+}
+
+// claim takes a batch of pending events for this relay, unless another
+// replica is claiming at the same time.
+func (r *Relay) claim(ctx context.Context) ([]model.OutboxEventModel, error) {
+	var pending []model.OutboxEventModel
+	err := r.txManager.RunInTransaction(ctx, func(txCtx context.Context) error {
+		locked, err := r.repository.TryLockRelay(txCtx)
+		if err != nil || !locked {
+			return err
+		}
+
+		pending, err = r.repository.FindPendingEvents(txCtx, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, len(pending))
+		for i := range pending {
+			ids[i] = pending[i].ID
+		}
+		return r.repository.PostponeEvents(txCtx, ids, time.Now().UTC().Add(claimLease))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+func (r *Relay) publish(ctx context.Context, outboxEvent *model.OutboxEventModel) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Relay.publish", trace.WithAttributes(
+		attribute.String("outbox.event_id", outboxEvent.ID),
+		attribute.String("outbox.event_type", outboxEvent.EventType),
+		attribute.String("outbox.form_id", outboxEvent.FormID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	event := Event{
+		ID:         outboxEvent.ID,
+		Type:       api.WebhookEventType(outboxEvent.EventType),
+		FormID:     outboxEvent.FormID,
+		Sequence:   outboxEvent.Sequence,
+		OccurredAt: outboxEvent.OccurredAt,
+		Data:       json.RawMessage(outboxEvent.Payload),
+	}
+
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (r *Relay) retryLater(ctx context.Context, event *model.OutboxEventModel, cause error) error {
+	event.Attempts++
+	event.NextAttemptAt = time.Now().UTC().Add(retry.Backoff(event.Attempts, r.initialBackoff, r.maxBackoff))
+	event.LastError = truncate(cause.Error(), maxErrorLength)
+
+	r.log.Warn().Ctx(ctx).Err(cause).
+		Str("eventId", event.ID).
+		Str("formId", event.FormID).
+		Int("attempts", event.Attempts).
+		Time("nextAttemptAt", event.NextAttemptAt).
+		Msg("Failed to publish outbox event, retry scheduled")
+
+	return r.repository.MarkFailed(ctx, event)
+}
+
+// prune deletes published events older than the retention period.
+func (r *Relay) prune(ctx context.Context) {
+	if r.retention <= 0 || time.Since(r.prunedAt) < pruneInterval {
+		return
+	}
+	r.prunedAt = time.Now()
+
+	deleted, err := r.repository.DeletePublishedBefore(ctx, time.Now().UTC().Add(-r.retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Error().Err(err).Msg("Failed to prune published outbox events")
+		}
+		return
+	}
+	if deleted > 0 {
+		r.log.Debug().Int64("deleted", deleted).Msg("Pruned published outbox events")
+	}
+}
+
+func orDefault(value time.Duration, fallback time.Duration) time.Duration {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length]
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+)
+
+// The Idempotency-Key header is handled by the idempotency middleware.
+func (h *FormHandler) IngestEvents(c *gin.Context, _ api.IngestEventsParams) {
+	var req api.EventBatch
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, &apierrors.InvalidRequestBodyError{Err: err})
+		return
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response, err := h.tracking.IngestEvents(c.Request.Context(), req, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response)
+}
+
+func (h *FormHandler) GetFormAnalytics(c *gin.Context, formId string, params api.GetFormAnalyticsParams) {
+	analytics, err := h.analytics.GetFormAnalytics(c.Request.Context(), formId, params)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
@@ -2,28 +2,40 @@ package handler
 
 import (
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"net/http"
 	"salesforge-assignment/internal/api"
 	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/etag"
+	"salesforge-assignment/internal/i18n"
+	"salesforge-assignment/internal/metrics"
 	"salesforge-assignment/internal/service"
+	"time"
 )
 
 var _ api.ServerInterface = (*FormHandler)(nil)
 
 type FormHandler struct {
-	svc service.FormService
+	svc       service.FormService
+	tracking  service.TrackingService
+	analytics service.AnalyticsService
+	webhooks  service.WebhookService
 }
 
 func NewFormHandler(
 	svc service.FormService,
+	tracking service.TrackingService,
+	analytics service.AnalyticsService,
+	webhooks service.WebhookService,
 ) *FormHandler {
 	return &FormHandler{
-		svc: svc,
+		svc:       svc,
+		tracking:  tracking,
+		analytics: analytics,
+		webhooks:  webhooks,
 	}
 }
 
-var validate = validator.New()
+var validate = i18n.Validator()
 
 func (h *FormHandler) LoginUser(c *gin.Context) {
 	var req api.Authentication
@@ -34,6 +46,7 @@ func (h *FormHandler) LoginUser(c *gin.Context) {
 	}
 
 	token, err := h.svc.LoginUser(c.Request.Context(), req)
+	metrics.ObserveLogin(err)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -46,7 +59,8 @@ func (h *FormHandler) LoginUser(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-func (h *FormHandler) CreateForm(c *gin.Context) {
+// The Idempotency-Key header is handled by the idempotency middleware.
+func (h *FormHandler) CreateForm(c *gin.Context, _ api.CreateFormParams) {
 
 	var req api.FormCreate
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -68,17 +82,34 @@ func (h *FormHandler) CreateForm(c *gin.Context) {
 	c.JSON(http.StatusCreated, self)
 }
 
-func (h *FormHandler) GetFormById(c *gin.Context, formId string) {
+func (h *FormHandler) GetFormById(c *gin.Context, formId string, params api.GetFormByIdParams) {
+	// Compare the version before reading the whole form with its steps
+	if params.IfNoneMatch != nil || params.IfModifiedSince != nil {
+		version, updatedAt, err := h.svc.GetFormVersion(c.Request.Context(), formId)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+		if notModified(version, updatedAt, stringValue(params.IfNoneMatch), stringValue(params.IfModifiedSince)) {
+			c.Header("ETag", etag.Format(version))
+			c.Header("Last-Modified", updatedAt.UTC().Format(http.TimeFormat))
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
 	form, err := h.svc.GetFormById(c.Request.Context(), formId)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
+	c.Header("ETag", etag.Format(form.Version))
+	c.Header("Last-Modified", form.UpdatedAt.UTC().Format(http.TimeFormat))
 	c.JSON(http.StatusOK, form)
 }
 
-func (h *FormHandler) UpdateFormById(c *gin.Context, formId string) {
+func (h *FormHandler) UpdateFormById(c *gin.Context, formId string, params api.UpdateFormByIdParams) {
 	var req api.FormUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
 		HandleError(c, &apierrors.InvalidRequestBodyError{Err: err})
@@ -90,26 +121,39 @@ func (h *FormHandler) UpdateFormById(c *gin.Context, formId string) {
 		return
 	}
 
-	form, err := h.svc.UpdateFormById(c.Request.Context(), formId, req)
+	form, err := h.svc.UpdateFormById(c.Request.Context(), formId, req, stringValue(params.IfMatch))
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
+	c.Header("ETag", etag.Format(form.Version))
+	c.Header("Last-Modified", form.UpdatedAt.UTC().Format(http.TimeFormat))
 	c.JSON(http.StatusOK, form)
 }
 
-func (h *FormHandler) GetFormStepById(c *gin.Context, formId string, stepId string) {
+func (h *FormHandler) GetFormStepById(c *gin.Context, formId string, stepId string, params api.GetFormStepByIdParams) {
 	step, err := h.svc.GetFormStepById(c.Request.Context(), formId, stepId)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
+	c.Header("ETag", etag.Format(step.Version))
+	if notModified(step.Version, time.Time{}, stringValue(params.IfNoneMatch), "") {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, step)
 }
 
-func (h *FormHandler) UpdateFormStepById(c *gin.Context, formId string, stepId string) {
+func (h *FormHandler) UpdateFormStepById(
+	c *gin.Context,
+	formId string,
+	stepId string,
+	params api.UpdateFormStepByIdParams,
+) {
 	var req api.FormStepUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
 		HandleError(c, &apierrors.InvalidRequestBodyError{Err: err})
@@ -121,17 +165,23 @@ func (h *FormHandler) UpdateFormStepById(c *gin.Context, formId string, stepId s
 		return
 	}
 
-	step, err := h.svc.UpdateFormStepById(c.Request.Context(), formId, stepId, req)
+	step, err := h.svc.UpdateFormStepById(c.Request.Context(), formId, stepId, req, stringValue(params.IfMatch))
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
+	c.Header("ETag", etag.Format(step.Version))
 	c.JSON(http.StatusOK, step)
 }
 
-func (h *FormHandler) DeleteFormStepById(c *gin.Context, formId string, stepId string) {
-	err := h.svc.DeleteFormStepById(c.Request.Context(), formId, stepId)
+func (h *FormHandler) DeleteFormStepById(
+	c *gin.Context,
+	formId string,
+	stepId string,
+	params api.DeleteFormStepByIdParams,
+) {
+	err := h.svc.DeleteFormStepById(c.Request.Context(), formId, stepId, stringValue(params.IfMatch))
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -139,3 +189,28 @@ func (h *FormHandler) DeleteFormStepById(c *gin.Context, formId string, stepId s
 
 	c.Status(http.StatusNoContent)
 }
+
+// notModified evaluates the conditional GET headers against the current
+// version of a resource. If-Modified-Since is only considered without
+// If-None-Match, and only when the modification time is known.
+func notModified(version int, updatedAt time.Time, ifNoneMatch string, ifModifiedSince string) bool {
+	if ifNoneMatch != "" {
+		return etag.MatchesWeak(ifNoneMatch, version)
+	}
+	if ifModifiedSince == "" || updatedAt.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !updatedAt.Truncate(time.Second).After(since)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
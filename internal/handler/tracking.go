@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/tracking"
+)
+
+func (h *FormHandler) RenderFormStepById(c *gin.Context, formId string, stepId string, params api.RenderFormStepByIdParams) {
+	step, err := h.tracking.RenderFormStepById(c.Request.Context(), formId, stepId, params.Recipient)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, step)
+}
+
+// TrackOpen always answers with the pixel, even for unknown or tampered
+// tokens, so that a broken image never shows up in the recipient's client.
+func (h *FormHandler) TrackOpen(c *gin.Context, pixel string) {
+	if token, ok := tracking.TokenFromPixel(pixel); ok {
+		err := h.tracking.RecordOpen(c.Request.Context(), token, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			logger.FromContext(c).Debug().Err(err).Msg("Open event was not recorded")
+		}
+	}
+
+	c.Header("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
+	c.Header("Pragma", "no-cache")
+	c.Data(http.StatusOK, tracking.PixelContentType, tracking.TransparentGIF)
+}
+
+func (h *FormHandler) TrackClick(c *gin.Context, token string) {
+	link, err := h.tracking.RecordClick(c.Request.Context(), token, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, link)
+}
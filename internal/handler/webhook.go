@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+)
+
+// The Idempotency-Key header is handled by the idempotency middleware.
+func (h *FormHandler) CreateWebhook(c *gin.Context, _ api.CreateWebhookParams) {
+	var req api.WebhookCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, &apierrors.InvalidRequestBodyError{Err: err})
+		return
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	webhook, err := h.webhooks.CreateWebhook(c.Request.Context(), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+func (h *FormHandler) ListWebhooks(c *gin.Context) {
+	webhooks, err := h.webhooks.ListWebhooks(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+func (h *FormHandler) GetWebhookById(c *gin.Context, webhookId string) {
+	webhook, err := h.webhooks.GetWebhookById(c.Request.Context(), webhookId)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *FormHandler) DeleteWebhookById(c *gin.Context, webhookId string) {
+	if err := h.webhooks.DeleteWebhookById(c.Request.Context(), webhookId); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FormHandler) ListWebhookDeliveries(c *gin.Context, webhookId string, params api.ListWebhookDeliveriesParams) {
+	page, err := h.webhooks.ListWebhookDeliveries(c.Request.Context(), webhookId, params)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *FormHandler) GetWebhookDeliveryById(c *gin.Context, webhookId string, deliveryId string) {
+	delivery, err := h.webhooks.GetWebhookDeliveryById(c.Request.Context(), webhookId, deliveryId)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+func (h *FormHandler) RedeliverWebhookDelivery(c *gin.Context, webhookId string, deliveryId string) {
+	delivery, err := h.webhooks.RedeliverWebhookDelivery(c.Request.Context(), webhookId, deliveryId)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"net/http"
+	"salesforge-assignment/internal/repository"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout bounds the checks of a readiness probe, so that a hanging
+// dependency fails the probe instead of stalling it
+const checkTimeout = 2 * time.Second
+
+// Check reports whether a dependency of the application is usable.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Handler serves the probes of the orchestrator: /healthz reports whether
+// the process is alive, /readyz whether it should receive traffic.
+type Handler struct {
+	log      *zerolog.Logger
+	checks   []namedCheck
+	draining atomic.Bool
+	version  func() uint64
+}
+
+type status struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+	// ConfigVersion tells whether a configuration reload reached the server
+	ConfigVersion uint64 `json:"configVersion,omitempty"`
+}
+
+func NewHandler(log *zerolog.Logger) *Handler {
+	return &Handler{log: log}
+}
+
+// AddCheck adds a check to the readiness probe.
+func (h *Handler) AddCheck(name string, check Check) {
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// ReportConfigVersion adds the version of the applied configuration to the
+// readiness probe.
+func (h *Handler) ReportConfigVersion(version func() uint64) {
+	h.version = version
+}
+
+// Drain fails the readiness probe from now on, so that load balancers stop
+// sending requests while the server shuts down.
+func (h *Handler) Drain() {
+	h.draining.Store(true)
+}
+
+// Register adds the probe routes to the router.
+func (h *Handler) Register(r gin.IRouter) {
+	r.GET("/healthz", h.live)
+	r.GET("/readyz", h.ready)
+}
+
+func (h *Handler) live(c *gin.Context) {
+	c.JSON(http.StatusOK, status{Status: "ok"})
+}
+
+func (h *Handler) ready(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, status{Status: "draining"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), checkTimeout)
+	defer cancel()
+
+	results := make([]error, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check.check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	code, resp := http.StatusOK, status{Status: "ok", Checks: map[string]string{}}
+	if h.version != nil {
+		resp.ConfigVersion = h.version()
+	}
+	for i, check := range h.checks {
+		if results[i] != nil {
+			h.log.Warn().Err(results[i]).Str("check", check.name).Msg("Readiness check failed")
+			code, resp.Status = http.StatusServiceUnavailable, "unavailable"
+			resp.Checks[check.name] = results[i].Error()
+			continue
+		}
+		resp.Checks[check.name] = "ok"
+	}
+	c.JSON(code, resp)
+}
+
+// Database checks that the database accepts connections.
+func Database(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// Migrations checks that the schema is at the version the application
+// expects, i.e. that no migration is pending or failed.
+func Migrations(repository repository.MigrationRepository, expected uint64) Check {
+	return func(ctx context.Context) error {
+		version, dirty, err := repository.GetVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("migration %d failed", version)
+		}
+		if version < expected {
+			return fmt.Errorf("schema version %d, expected %d", version, expected)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package i18n
+
+// catalog holds the translations of API error messages by locale. Keys are
+// the message keys of apierrors, or "openapi." and the violated schema
+// keyword for the OpenAPI validation; parameters are written as {0}. English
+// messages are the fallbacks defined with the errors and not repeated here.
+var catalog = map[string]map[string]string{
+	"es": {
+		"internal_error":              "Estado de la aplicación no válido",
+		"permission_denied":           "Permiso denegado",
+		"invalid_input":               "Entrada no válida",
+		"invalid_input.field":         "Valor de '{0}' no válido",
+		"invalid_request_body":        "Cuerpo de la solicitud no válido.",
+		"invalid_request_body.detail": "Cuerpo de la solicitud no válido: {0}",
+		"validation_failed":           "Uno o más campos no superaron la validación.",
+		"resource_not_found":          "Recurso no encontrado",
+		"method_not_allowed":          "Método no permitido",
+		"precondition_failed":         "La condición previa falló",
+		"conflict":                    "Conflicto",
+		"conflict.field":              "El valor de '{0}' ya está en uso",
+		"concurrent_update":           "Actualización concurrente en conflicto, repita la solicitud",
+		"unprocessable_entity":        "Entidad no procesable",
+		"unprocessable_entity.field":  "El '{0}' referenciado no existe",
+		"invalid_credentials":         "Credenciales no válidas",
+		"unauthorized":                "Acceso no autorizado",
+		"unauthorized.missing_token":  "Se requiere la cabecera Authorization",
+		"unauthorized.invalid_token":  "Token no válido",
+		"too_many_requests":           "Demasiadas solicitudes, inténtelo más tarde",
+
+		"openapi.required":             "{0} es un campo requerido",
+		"openapi.additionalProperties": "{0} no es un campo admitido",
+		"openapi.type":                 "{0} tiene un tipo no válido",
+		"openapi.minLength":            "{0} debe tener al menos {1} caracteres",
+		"openapi.maxLength":            "{0} debe tener un máximo de {1} caracteres",
+		"openapi.minItems":             "{0} debe contener al menos {1} elementos",
+		"openapi.maxItems":             "{0} debe contener como máximo {1} elementos",
+		"openapi.minimum":              "{0} debe ser {1} o más",
+		"openapi.maximum":              "{0} debe ser {1} o menos",
+		"openapi.enum":                 "{0} debe ser uno de [{1}]",
+		"openapi.format":               "{0} no tiene el formato {1}",
+	},
+	"fr": {
+		"internal_error":              "État de l'application invalide",
+		"permission_denied":           "Permission refusée",
+		"invalid_input":               "Entrée invalide",
+		"invalid_input.field":         "Valeur de '{0}' invalide",
+		"invalid_request_body":        "Corps de la requête invalide.",
+		"invalid_request_body.detail": "Corps de la requête invalide : {0}",
+		"validation_failed":           "Un ou plusieurs champs n'ont pas passé la validation.",
+		"resource_not_found":          "Ressource introuvable",
+		"method_not_allowed":          "Méthode non autorisée",
+		"precondition_failed":         "La précondition a échoué",
+		"conflict":                    "Conflit",
+		"conflict.field":              "La valeur de '{0}' est déjà utilisée",
+		"concurrent_update":           "Mise à jour concurrente en conflit, réessayez la requête",
+		"unprocessable_entity":        "Entité non traitable",
+		"unprocessable_entity.field":  "Le '{0}' référencé n'existe pas",
+		"invalid_credentials":         "Identifiants invalides",
+		"unauthorized":                "Accès non autorisé",
+		"unauthorized.missing_token":  "L'en-tête Authorization est requis",
+		"unauthorized.invalid_token":  "Jeton invalide",
+		"too_many_requests":           "Trop de requêtes, réessayez plus tard",
+
+		"openapi.required":             "{0} est un champ obligatoire",
+		"openapi.additionalProperties": "{0} n'est pas un champ pris en charge",
+		"openapi.type":                 "{0} a un type invalide",
+		"openapi.minLength":            "{0} doit faire au moins {1} caractères",
+		"openapi.maxLength":            "{0} doit faire au maximum {1} caractères",
+		"openapi.minItems":             "{0} doit contenir au moins {1} éléments",
+		"openapi.maxItems":             "{0} doit contenir au maximum {1} éléments",
+		"openapi.minimum":              "{0} doit être supérieur ou égal à {1}",
+		"openapi.maximum":              "{0} doit être inférieur ou égal à {1}",
+		"openapi.enum":                 "{0} doit être l'un des [{1}]",
+		"openapi.format":               "{0} n'a pas le format {1}",
+	},
+}
@@ -0,0 +1,135 @@
+package i18n
+
+import (
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	estranslations "github.com/go-playground/validator/v10/translations/es"
+	frtranslations "github.com/go-playground/validator/v10/translations/fr"
+	"golang.org/x/text/language"
+	"reflect"
+	"strings"
+)
+
+// DefaultLocale is used when the client accepts none of the supported
+// locales.
+const DefaultLocale = "en"
+
+// supported lists the locales in the order of the matcher's tags, the
+// default first.
+var supported = []struct {
+	tag      language.Tag
+	register func(*validator.Validate, ut.Translator) error
+}{
+	{language.English, entranslations.RegisterDefaultTranslations},
+	{language.Spanish, estranslations.RegisterDefaultTranslations},
+	{language.French, frtranslations.RegisterDefaultTranslations},
+}
+
+var (
+	universal = ut.New(en.New(), en.New(), es.New(), fr.New())
+	matcher   = newMatcher()
+	validate  = newValidator()
+)
+
+func init() {
+	for locale, messages := range catalog {
+		trans, _ := universal.GetTranslator(locale)
+		for key, text := range messages {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// Translator returns the translator of the locale that best matches an
+// Accept-Language header.
+func Translator(acceptLanguage string) ut.Translator {
+	trans, _ := universal.GetTranslator(Locale(acceptLanguage))
+	return trans
+}
+
+// Locale returns the supported locale that best matches an Accept-Language
+// header.
+func Locale(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	base, _ := supported[index].tag.Base()
+	return base.String()
+}
+
+// Translate returns the message with the key in the translator's locale, or
+// the fallback if the locale has no such message. English messages live
+// with the code producing them and are passed as fallback.
+func Translate(trans ut.Translator, key string, fallback string, params ...string) string {
+	if key == "" {
+		return fallback
+	}
+	message, err := trans.T(key, params...)
+	if err != nil || message == "" {
+		return fallback
+	}
+	return message
+}
+
+// Validator returns the validator that reports JSON field names and
+// translates its errors to all supported locales. It is shared, since the
+// translations of a validator are registered with the translators.
+func Validator() *validator.Validate {
+	return validate
+}
+
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonName)
+
+	for _, locale := range supported {
+		base, _ := locale.tag.Base()
+		trans, _ := universal.GetTranslator(base.String())
+		if err := locale.register(validate, trans); err != nil {
+			panic(err)
+		}
+	}
+	return validate
+}
+
+// FieldPath returns the JSON path of the field that failed validation, e.g.
+// steps[2].content, without the name of the validated struct.
+func FieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+func newMatcher() language.Matcher {
+	tags := make([]language.Tag, 0, len(supported))
+	for _, locale := range supported {
+		tags = append(tags, locale.tag)
+	}
+	return language.NewMatcher(tags)
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
@@ -0,0 +1,237 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/middleware/auth"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/problem"
+	"salesforge-assignment/internal/repository"
+	"time"
+)
+
+const (
+	KeyHeader      = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	defaultTTL     = 24 * time.Hour
+	maxKeyLength   = 255
+	pruneInterval  = time.Hour
+	releaseTimeout = 5 * time.Second
+	// abandonAfter is how long a request may hold its key without
+	// completing before a retry takes the key over
+	abandonAfter = time.Minute
+)
+
+// Guard makes POST requests carrying an Idempotency-Key safe to retry. The
+// first request with a key is processed and its response stored; identical
+// retries by the same user within the TTL get the stored response replayed.
+// A retry while the first request is still in flight gets 409, and reusing
+// a key for a different request gets 422.
+type Guard struct {
+	log        *zerolog.Logger
+	repository repository.IdempotencyRepository
+	ttl        time.Duration
+}
+
+func NewGuard(log *zerolog.Logger, repository repository.IdempotencyRepository, cfg config.IdempotencyConfig) *Guard {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Guard{
+		log:        log,
+		repository: repository,
+		ttl:        ttl,
+	}
+}
+
+func (g *Guard) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(KeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			problem.Abort(c, &apierrors.InvalidInputError{})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			problem.Abort(c, &apierrors.InvalidRequestBodyError{Err: err})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Postgres keeps microseconds, and the reservation is identified by
+		// its creation time when it is completed or released
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		record := &model.IdempotencyKeyModel{
+			UserID:      userId(c),
+			Key:         key,
+			RequestHash: fingerprint(c.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(g.ttl),
+		}
+		log := g.log.With().Str("idempotencyKey", key).Str("userId", record.UserID).Logger()
+
+		reserved, err := g.repository.ReserveKey(c.Request.Context(), record, now.Add(-abandonAfter))
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to reserve idempotency key")
+			problem.Abort(c, &apierrors.InvalidApplicationStateError{})
+			return
+		}
+		if !reserved {
+			g.replay(c, &log, record)
+			return
+		}
+
+		// A panicking handler must not hold the key until it is abandoned
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				g.release(c, &log, record)
+				panic(recovered)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Server errors are not stored, so the request can be retried with the same key
+		if recorder.Status() >= http.StatusInternalServerError {
+			g.release(c, &log, record)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), releaseTimeout)
+		defer cancel()
+
+		status := recorder.Status()
+		record.StatusCode = &status
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.ResponseBody = recorder.body.Bytes()
+		if err := g.repository.CompleteKey(ctx, record); errors.Is(err, repository.ErrKeyTakenOver) {
+			log.Warn().Msg("Idempotency key was taken over by a retry, not storing the response")
+		} else if err != nil {
+			log.Error().Err(err).Msg("Failed to store idempotent response")
+		}
+	}
+}
+
+// Run deletes expired keys until the context is cancelled.
+func (g *Guard) Run(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := g.repository.DeleteExpiredKeys(ctx, time.Now().UTC())
+		if err != nil {
+			if ctx.Err() == nil {
+				g.log.Error().Err(err).Msg("Failed to delete expired idempotency keys")
+			}
+			continue
+		}
+		if deleted > 0 {
+			g.log.Debug().Int64("deleted", deleted).Msg("Deleted expired idempotency keys")
+		}
+	}
+}
+
+func (g *Guard) release(c *gin.Context, log *zerolog.Logger, record *model.IdempotencyKeyModel) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), releaseTimeout)
+	defer cancel()
+	if err := g.repository.ReleaseKey(ctx, record); errors.Is(err, repository.ErrKeyTakenOver) {
+		log.Warn().Msg("Idempotency key was taken over by a retry, leaving it to the retry")
+	} else if err != nil {
+		log.Error().Err(err).Msg("Failed to release idempotency key")
+	}
+}
+
+func (g *Guard) replay(c *gin.Context, log *zerolog.Logger, record *model.IdempotencyKeyModel) {
+	existing, err := g.repository.GetKey(c.Request.Context(), record.UserID, record.Key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The first request failed and released the key in the meantime
+			problem.Abort(c, &apierrors.ConflictError{Err: err})
+			return
+		}
+		log.Error().Err(err).Msg("Failed to retrieve idempotency key")
+		problem.Abort(c, &apierrors.InvalidApplicationStateError{})
+		return
+	}
+
+	if existing.RequestHash != record.RequestHash {
+		log.Debug().Msg("Idempotency key reused for a different request")
+		problem.Abort(c, &apierrors.UnprocessableEntityError{})
+		return
+	}
+	if existing.StatusCode == nil {
+		log.Debug().Msg("Request with idempotency key is still in flight")
+		problem.Abort(c, &apierrors.ConflictError{})
+		return
+	}
+
+	log.Debug().Msg("Replaying idempotent response")
+	c.Header(ReplayedHeader, "true")
+	c.Data(*existing.StatusCode, existing.ContentType, existing.ResponseBody)
+	c.Abort()
+}
+
+// userId scopes keys to the authenticated user, so that users cannot see
+// each other's responses.
+func userId(c *gin.Context) string {
+	if claims, ok := c.Get(auth.ClaimsKey); ok {
+		if claims, ok := claims.(*auth.Claims); ok {
+			return claims.UserId
+		}
+	}
+	return ""
+}
+
+// fingerprint identifies a request by its method, path, query and body.
+func fingerprint(r *http.Request, body []byte) string {
+	target := r.URL.Path
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + target + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(data string) (int, error) {
+	r.body.WriteString(data)
+	return r.ResponseWriter.WriteString(data)
+}
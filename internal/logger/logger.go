@@ -3,49 +3,60 @@ package logger
 import (
 	"github.com/gin-gonic/gin"
 	zerolog "github.com/rs/zerolog"
+	"io"
 	"log"
 	"os"
+	"salesforge-assignment/internal/config"
 	"time"
 )
 
-func InitLogger(level string, pretty bool) *zerolog.Logger {
+// InitLogger creates the root logger and sets the redaction of Fields and
+// logged errors.
+func InitLogger(cfg config.LogConfig) *zerolog.Logger {
 	var logger zerolog.Logger
 
-	logLevel, err := zerolog.ParseLevel(level)
+	logLevel, err := zerolog.ParseLevel(cfg.Level)
 	if err != nil {
-		log.Printf("Invalid log level '%s', defaulting to 'info'", level)
+		log.Printf("Invalid log level '%s', defaulting to 'info'", cfg.Level)
 		logLevel = zerolog.InfoLevel
 	}
 
 	zerolog.SetGlobalLevel(logLevel)
+	SetRedaction(cfg.Redaction)
 
-	if pretty {
-		logger = zerolog.New(zerolog.ConsoleWriter{
+	var out io.Writer = os.Stderr
+	if cfg.Pretty {
+		out = zerolog.ConsoleWriter{
 			Out:        zerolog.ConsoleWriter{Out: log.Writer()},
 			TimeFormat: time.RFC3339,
-		}).
-			With().
-			Timestamp().
-			Caller().
-			Logger()
-	} else {
-		logger = zerolog.New(os.Stderr).
-			With().
-			Timestamp().
-			Caller().
-			Logger()
+		}
 	}
+	logger = zerolog.New(out).
+		With().
+		Timestamp().
+		Caller().
+		Logger()
 
 	log.SetFlags(0)
 	log.SetOutput(logger)
 
 	logger.Info().
-		Str("logLevel", level).
+		Str("logLevel", cfg.Level).
 		Msg("Initialized logger")
 
 	return &logger
 }
 
+// SetLevel changes the level of all loggers, e.g. on a configuration reload.
+func SetLevel(level string) error {
+	logLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	zerolog.SetGlobalLevel(logLevel)
+	return nil
+}
+
 func FromContext(c *gin.Context) *zerolog.Logger {
 	logger, exists := c.Get("logger")
 	if !exists {
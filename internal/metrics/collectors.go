@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+// countTimeout bounds the queries of a scrape, which Prometheus abandons
+// after its own timeout anyway
+const countTimeout = 5 * time.Second
+
+var (
+	formsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "forms"),
+		"Forms stored.", nil, nil,
+	)
+	stepsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "form_steps"),
+		"Form steps stored.", nil, nil,
+	)
+)
+
+// FormCounter counts the stored forms and steps.
+type FormCounter interface {
+	CountFormsAndSteps(ctx context.Context) (forms int64, steps int64, err error)
+}
+
+// formCollector reports business gauges, counted when scraped so that
+// they are never stale.
+type formCollector struct {
+	log     *zerolog.Logger
+	counter FormCounter
+}
+
+func (fc *formCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- formsDesc
+	ch <- stepsDesc
+}
+
+func (fc *formCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), countTimeout)
+	defer cancel()
+
+	forms, steps, err := fc.counter.CountFormsAndSteps(ctx)
+	if err != nil {
+		fc.log.Error().Err(err).Msg("Failed to count forms for metrics")
+		ch <- prometheus.NewInvalidMetric(formsDesc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(formsDesc, prometheus.GaugeValue, float64(forms))
+	ch <- prometheus.MustNewConstMetric(stepsDesc, prometheus.GaugeValue, float64(steps))
+}
+
+// RegisterDatabase adds to registry, usually Registry, the stats of the
+// connection pool and the business gauges counted in the database.
+func RegisterDatabase(registry prometheus.Registerer, log *zerolog.Logger, db *sql.DB, counter FormCounter) error {
+	if err := registry.Register(collectors.NewDBStatsCollector(db, "forms")); err != nil {
+		return err
+	}
+	return registry.Register(&formCollector{log: log, counter: counter})
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"strconv"
+	"time"
+)
+
+const (
+	namespace = "forms_api"
+	// unmatchedRoute labels requests that matched no route, so that scanners
+	// probing random paths do not create a series per path
+	unmatchedRoute = "unmatched"
+)
+
+// Registry holds the metrics of the application. It is separate from the
+// default registry so that only metrics registered here are exposed.
+var Registry = prometheus.NewRegistry()
+
+var (
+	requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by method, route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Login attempts by outcome: success, invalid_credentials or error.",
+	}, []string{"outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requests,
+		requestDuration,
+		logins,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		// A failing collector should not hide the other metrics
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// Middleware counts requests and observes their latency. Requests are
+// labelled with the route template, e.g. /api/v1/form/:formId, since raw
+// paths would create a series per resource.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		requests.WithLabelValues(c.Request.Method, route, status).Inc()
+		requestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveLogin counts a login attempt by the error it ended with.
+func ObserveLogin(err error) {
+	var invalidCredentials *apierrors.InvalidCredentialsError
+	switch {
+	case err == nil:
+		logins.WithLabelValues("success").Inc()
+	case errors.As(err, &invalidCredentials):
+		logins.WithLabelValues("invalid_credentials").Inc()
+	default:
+		logins.WithLabelValues("error").Inc()
+	}
+}
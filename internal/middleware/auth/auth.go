@@ -1,25 +1,43 @@
 package auth
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
-	"os"
-	"salesforge-assignment/internal/logger"
+	"github.com/rs/zerolog"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/problem"
+	"slices"
 	"time"
 )
 
+const (
+	// ClaimsKey is the gin context key of the Claims of an authenticated request.
+	ClaimsKey = "user_claims"
+	// BearerScheme is the name of the security scheme of the spec that
+	// Authenticate implements.
+	BearerScheme = "BearerAuth"
+	// RoleEditor lets users change forms and webhooks. Operations requiring
+	// a role list it as a scope of their BearerScheme requirement in the
+	// spec.
+	RoleEditor = "editor"
+)
+
 type Claims struct {
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	UserId   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
 	jwt.StandardClaims
 }
 
-func GenerateToken(userId string, username string, jwtKey []byte) (string, error) {
+func GenerateToken(userId string, username string, roles []string, jwtKey []byte) (string, error) {
 	expirationTime := time.Now().Add(1 * time.Hour)
 
 	claims := &Claims{
 		UserId:   userId,
 		Username: username,
+		Roles:    roles,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expirationTime.Unix(),
 		},
@@ -30,22 +48,29 @@ func GenerateToken(userId string, username string, jwtKey []byte) (string, error
 	return token.SignedString(jwtKey)
 }
 
-func AuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		log := logger.FromContext(c)
+// AuthMiddleware authenticates requests by the JWT in their Authorization
+// header, signed with jwtSecret.
+func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+	authenticate := Authenticate(jwtSecret)
 
-		jwtSecret := os.Getenv("JWT_SECRET_KEY")
-
-		if jwtSecret == "" {
-			log.Fatal().Msg("Environment variable JWT_SECRET_KEY is not set")
+	return func(c *gin.Context) {
+		if err := authenticate(c, nil); err != nil {
+			problem.Abort(c, err)
 		}
+	}
+}
 
-		jwtKey := []byte(jwtSecret)
+// Authenticate returns the authenticator of the BearerScheme: it stores the
+// Claims of the JWT in the Authorization header, signed with jwtSecret, and
+// checks that the user has all roles, or returns why the request is not
+// authenticated or not allowed.
+func Authenticate(jwtSecret string) func(c *gin.Context, roles []string) error {
+	jwtKey := []byte(jwtSecret)
 
+	return func(c *gin.Context, roles []string) error {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(401, gin.H{"error": "Authorization header is required"})
-			return
+			return &apierrors.UnauthorizedError{Reason: apierrors.ReasonMissingToken}
 		}
 
 		tokenString := authHeader[len("Bearer "):]
@@ -56,11 +81,19 @@ func AuthMiddleware() gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(401, gin.H{"error": "Invalid token"})
-			return
+			return &apierrors.UnauthorizedError{Reason: apierrors.ReasonInvalidToken, Err: err}
 		}
 
-		c.Set("user_claims", claims)
-		c.Next()
+		c.Set(ClaimsKey, claims)
+		middleware.UpdateLogger(c, func(log zerolog.Context) zerolog.Context {
+			return log.Str("userId", claims.UserId)
+		})
+
+		for _, role := range roles {
+			if !slices.Contains(claims.Roles, role) {
+				return &apierrors.PermissionDeniedError{Err: fmt.Errorf("user lacks the role %q", role)}
+			}
+		}
+		return nil
 	}
 }
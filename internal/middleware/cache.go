@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+// CacheControl sets the Cache-Control header configured for the matched
+// route. Rules are keyed by method and route relative to the base URL, e.g.
+// "GET /form/:formId".
+func CacheControl(baseURL string, rules map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + strings.TrimPrefix(c.FullPath(), baseURL)
+		if value, ok := rules[route]; ok {
+			c.Header("Cache-Control", value)
+		}
+		c.Next()
+	}
+}
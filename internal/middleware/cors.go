@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"salesforge-assignment/internal/config"
+	"slices"
+	"strconv"
+)
+
+const (
+	corsAllowedMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowedHeaders = "Authorization, Content-Type, If-Match, Idempotency-Key, X-Request-ID"
+	corsExposedHeaders = "ETag, Location, Retry-After, X-Request-ID"
+)
+
+// CORS lets browsers on the configured origins call the API and answers
+// their preflight requests. The configuration is read from current on every
+// request, so that reloads apply at once.
+func CORS(current func() config.CORSConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Responses differ by origin even when a request carries none, so
+		// that caches must not serve them to browsers on allowed origins
+		c.Writer.Header().Add("Vary", "Origin")
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		cfg := current()
+		if !slices.Contains(cfg.AllowedOrigins, "*") && !slices.Contains(cfg.AllowedOrigins, origin) {
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Expose-Headers", corsExposedHeaders)
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", corsAllowedMethods)
+			c.Header("Access-Control-Allow-Headers", corsAllowedHeaders)
+			if cfg.MaxAge > 0 {
+				c.Header("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
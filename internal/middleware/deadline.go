@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// QueryDeadline bounds the database work of a request. Services and
+// repositories query with the request context, so their queries are
+// cancelled once the request has run for timeout, or as soon as the client
+// goes away. A timeout of 0 sets no deadline.
+func QueryDeadline(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
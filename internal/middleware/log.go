@@ -9,25 +9,61 @@ import (
 
 const LoggerKey = "logger"
 
+// InjectLogger gives every request a child of log carrying its request ID
+// and route. The logger is stored both in the gin context and in the
+// context.Context of the request, so that services and repositories can log
+// through zerolog.Ctx(ctx) and their lines can be correlated.
 func InjectLogger(log *zerolog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Set(LoggerKey, log)
+		logContext := log.With()
+		if requestId := c.GetString(RequestIDKey); requestId != "" {
+			logContext = logContext.Str("requestId", requestId)
+		}
+		if route := c.FullPath(); route != "" {
+			logContext = logContext.Str("route", route)
+		}
+		requestLog := logContext.Logger()
+		setLogger(c, &requestLog)
 		c.Next()
 	}
 }
 
+// UpdateLogger adds fields to the logger of the request once they become
+// known, e.g. the user ID after authentication.
+func UpdateLogger(c *gin.Context, update func(zerolog.Context) zerolog.Context) {
+	log, ok := c.Get(LoggerKey)
+	if !ok {
+		return
+	}
+	updated := update(log.(*zerolog.Logger).With()).Logger()
+	setLogger(c, &updated)
+}
+
+func setLogger(c *gin.Context, log *zerolog.Logger) {
+	c.Set(LoggerKey, log)
+	if c.Request != nil {
+		c.Request = c.Request.WithContext(log.WithContext(c.Request.Context()))
+	}
+}
+
 func GinLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		log := logger.FromContext(c)
-
 		start := time.Now()
-		path := c.Request.URL.Path
+		// The route template keeps path parameters such as tracking tokens
+		// out of the log; unmatched requests have none
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
 		if c.Request.URL.RawQuery != "" {
 			path = path + "?" + c.Request.URL.RawQuery
 		}
 
 		c.Next()
 
+		// Fetched after the handlers, so that fields added on the way, such
+		// as the user ID, are part of the line
+		log := logger.FromContext(c)
 		latency := time.Since(start)
 		statusCode := c.Writer.Status()
 
@@ -40,10 +76,9 @@ func GinLogger() gin.HandlerFunc {
 
 		event.
 			Str("method", c.Request.Method).
-			Str("path", path).
 			Int("status", statusCode).
 			Dur("latency", latency).
-			Str("ip", c.ClientIP()).
+			EmbedObject(logger.Fields{"path": path, "ip": c.ClientIP()}).
 			Msg("Request handled")
 	}
 }
@@ -0,0 +1,124 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm/schema"
+	"salesforge-assignment/internal/repository"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Drift is a difference between what a model declares and the schema the
+// migrations created. Models are not used to create tables, so their tags
+// can go stale unnoticed.
+type Drift struct {
+	Table   string
+	Column  string
+	Problem string
+}
+
+func (d Drift) String() string {
+	if d.Column == "" {
+		return fmt.Sprintf("%s: %s", d.Table, d.Problem)
+	}
+	return fmt.Sprintf("%s.%s: %s", d.Table, d.Column, d.Problem)
+}
+
+// CheckDrift compares the tags of the models with the live schema: every
+// table and column must exist, columns declared not null must be, and unique
+// columns must have a unique constraint or index.
+func CheckDrift(ctx context.Context, repo repository.MigrationRepository, models ...any) ([]Drift, error) {
+	var drifts []Drift
+	for _, model := range models {
+		expected, err := ModelSchema(model)
+		if err != nil {
+			return nil, err
+		}
+		live, err := repo.DescribeTable(ctx, expected.Name)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", expected.Name, err)
+		}
+		drifts = append(drifts, compareTables(expected, live)...)
+	}
+	return drifts, nil
+}
+
+// ModelSchema derives the table a model expects from its gorm tags.
+func ModelSchema(model any) (*repository.TableSchema, error) {
+	parsed, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		return nil, err
+	}
+
+	table := &repository.TableSchema{Name: parsed.Table, Columns: map[string]repository.ColumnSchema{}}
+	var primaryKey []string
+	for _, field := range parsed.Fields {
+		// Relations have no column
+		if field.DBName == "" {
+			continue
+		}
+		table.Columns[field.DBName] = repository.ColumnSchema{NotNull: field.NotNull || field.PrimaryKey}
+		if field.PrimaryKey {
+			primaryKey = append(primaryKey, field.DBName)
+		}
+		if field.Unique {
+			table.Unique = append(table.Unique, []string{field.DBName})
+		}
+	}
+	if len(primaryKey) > 0 {
+		table.Unique = append(table.Unique, primaryKey)
+	}
+	for _, index := range parsed.ParseIndexes() {
+		if index.Class != "UNIQUE" || index.Where != "" {
+			continue
+		}
+		columns := make([]string, len(index.Fields))
+		for i, option := range index.Fields {
+			columns[i] = option.DBName
+		}
+		table.Unique = append(table.Unique, columns)
+	}
+	return table, nil
+}
+
+func compareTables(expected *repository.TableSchema, live *repository.TableSchema) []Drift {
+	if live == nil {
+		return []Drift{{Table: expected.Name, Problem: "table does not exist"}}
+	}
+
+	var drifts []Drift
+	names := make([]string, 0, len(expected.Columns))
+	for name := range expected.Columns {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		column, ok := live.Columns[name]
+		switch {
+		case !ok:
+			drifts = append(drifts, Drift{Table: expected.Name, Column: name, Problem: "column does not exist"})
+		case expected.Columns[name].NotNull && !column.NotNull:
+			drifts = append(drifts, Drift{Table: expected.Name, Column: name, Problem: "model declares not null, column is nullable"})
+		}
+	}
+
+	for _, columns := range expected.Unique {
+		if !slices.ContainsFunc(live.Unique, func(unique []string) bool { return sameColumns(unique, columns) }) {
+			drifts = append(drifts, Drift{
+				Table:   expected.Name,
+				Column:  strings.Join(columns, ","),
+				Problem: "model declares unique, no unique constraint or index",
+			})
+		}
+	}
+	return drifts
+}
+
+func sameColumns(a []string, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
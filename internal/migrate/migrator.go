@@ -0,0 +1,135 @@
+// Package migrate applies the embedded migrations to the database.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/migrations"
+)
+
+// Status describes the schema of the database relative to the migrations.
+type Status struct {
+	// Version is the applied schema version, 0 before the first migration
+	Version uint64
+	// Dirty tells that a migration failed halfway, which needs a manual fix
+	Dirty   bool
+	Pending []migrations.Migration
+}
+
+type Migrator struct {
+	log        *zerolog.Logger
+	repository repository.MigrationRepository
+	migrations []migrations.Migration
+}
+
+// NewMigrator applies all, which must be ordered by version.
+func NewMigrator(
+	log *zerolog.Logger,
+	repository repository.MigrationRepository,
+	all []migrations.Migration,
+) *Migrator {
+	return &Migrator{
+		log:        log,
+		repository: repository,
+		migrations: all,
+	}
+}
+
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	if err := m.repository.EnsureVersionTable(ctx); err != nil {
+		return Status{}, err
+	}
+	version, dirty, err := m.repository.GetVersion(ctx)
+	if err != nil && !errors.Is(err, repository.ErrNoMigration) {
+		return Status{}, err
+	}
+
+	status := Status{Version: version, Dirty: dirty}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// Up applies the pending migrations in order and returns how many were
+// applied. Each migration is applied in a transaction of its own, holding the
+// migration lock throughout so that concurrent runs apply each migration
+// once.
+func (m *Migrator) Up(ctx context.Context) (applied int, err error) {
+	err = m.repository.WithLock(ctx, func(ctx context.Context) error {
+		status, err := m.clean(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range status.Pending {
+			if err := m.repository.Migrate(ctx, migration.Up, migration.Version); err != nil {
+				return fmt.Errorf("migration %s: %w", migration.Name, err)
+			}
+			m.log.Info().Str("migration", migration.Name).Msg("Applied migration")
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts up to steps migrations, newest first, and returns how many
+// were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted int, err error) {
+	err = m.repository.WithLock(ctx, func(ctx context.Context) error {
+		status, err := m.clean(ctx)
+		if err != nil {
+			return err
+		}
+
+		applied := len(m.migrations) - len(status.Pending)
+		for ; reverted < steps && applied > 0; reverted++ {
+			migration := m.migrations[applied-1]
+			if migration.Down == "" {
+				return fmt.Errorf("migration %s cannot be reverted", migration.Name)
+			}
+			var previous uint64
+			if applied > 1 {
+				previous = m.migrations[applied-2].Version
+			}
+			if err := m.repository.Migrate(ctx, migration.Down, previous); err != nil {
+				return fmt.Errorf("migration %s: %w", migration.Name, err)
+			}
+			m.log.Info().Str("migration", migration.Name).Msg("Reverted migration")
+			applied--
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// clean returns the status, failing when the schema is dirty or at a version
+// this binary does not know, e.g. one applied by a newer release.
+func (m *Migrator) clean(ctx context.Context) (Status, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	if status.Dirty {
+		return Status{}, fmt.Errorf("migration %d failed halfway, fix the schema and its version manually", status.Version)
+	}
+	if status.Version != 0 && !m.known(status.Version) {
+		return Status{}, fmt.Errorf("schema version %d is unknown to this release", status.Version)
+	}
+	return status, nil
+}
+
+func (m *Migrator) known(version uint64) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
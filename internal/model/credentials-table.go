@@ -4,6 +4,10 @@ type CredentialsModel struct {
 	ID       string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
 	Username string `gorm:"type:text;not null;unique"`
 	Password string `gorm:"not null;type:text"`
+	// Disabled users cannot log in
+	Disabled bool `gorm:"not null;default:false"`
+	// Roles are put in the tokens of the user, see auth.RoleEditor
+	Roles []string `gorm:"type:jsonb;not null;default:'[\"editor\"]';serializer:json"`
 }
 
 func (*CredentialsModel) TableName() string {
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"salesforge-assignment/internal/api"
+	"time"
 )
 
 const (
@@ -15,6 +16,8 @@ type FormModel struct {
 	OpenTrackingEnabled  *bool           `gorm:"not null;default:false"`
 	ClickTrackingEnabled *bool           `gorm:"not null;default:false"`
 	Name                 string          `gorm:"not null;unique"`
+	Version              int             `gorm:"not null;default:1"`
+	UpdatedAt            time.Time       `gorm:"not null;default:now()"`
 	Steps                []FormStepModel `gorm:"foreignKey:FormID"`
 }
 
@@ -33,6 +36,8 @@ func (s *FormModel) ToResponse(publicUrl string, baseUrl string) *api.FormRespon
 		OpenTrackingEnabled:  *s.OpenTrackingEnabled,
 		ClickTrackingEnabled: *s.ClickTrackingEnabled,
 		Steps:                steps,
+		Version:              s.Version,
+		UpdatedAt:            s.UpdatedAt,
 		Self: api.SelfId{
 			Id:   s.ID,
 			Href: GetFormHref(s.ID, publicUrl, baseUrl),
@@ -42,6 +47,26 @@ func (s *FormModel) ToResponse(publicUrl string, baseUrl string) *api.FormRespon
 	return response
 }
 
+func (s *FormModel) HasStep(stepId string) bool {
+	for _, step := range s.Steps {
+		if step.ID == stepId {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLastStep reports whether the step is the final step of the form.
+func (s *FormModel) IsLastStep(stepId string) bool {
+	var last *FormStepModel
+	for i := range s.Steps {
+		if last == nil || s.Steps[i].StepOrder > last.StepOrder {
+			last = &s.Steps[i]
+		}
+	}
+	return last != nil && last.ID == stepId
+}
+
 func GetFormHref(formId string, publicUrl string, baseUrl string) string {
 	return fmt.Sprintf(formHref, publicUrl, baseUrl, formId)
 }
@@ -8,6 +8,7 @@ type FormStepModel struct {
 	Content   string `gorm:"not null"`
 	StepOrder int    `gorm:"not null"`
 	FormID    string `gorm:"not null;type:uuid;"`
+	Version   int    `gorm:"not null;default:1"`
 }
 
 func (*FormStepModel) TableName() string {
@@ -19,6 +20,7 @@ func (s *FormStepModel) ToResponse(publicUrl string, baseUrl string) *api.FormSt
 		Name:    s.Name,
 		Content: s.Content,
 		Step:    s.StepOrder,
+		Version: s.Version,
 		Self: api.SelfId{
 			Id:   s.ID,
 			Href: GetFormStepHref(s.FormID, s.ID, publicUrl, baseUrl),
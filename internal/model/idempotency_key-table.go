@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// IdempotencyKeyModel records the first request made with an idempotency key
+// and, once it completed, its response. StatusCode is nil while the request
+// is in flight.
+type IdempotencyKeyModel struct {
+	UserID       string `gorm:"primaryKey;type:text"`
+	Key          string `gorm:"primaryKey;type:text"`
+	RequestHash  string `gorm:"type:text;not null"`
+	StatusCode   *int
+	ContentType  string    `gorm:"type:text;not null;default:''"`
+	ResponseBody []byte    `gorm:"type:bytea"`
+	CreatedAt    time.Time `gorm:"not null;default:now()"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+}
+
+func (*IdempotencyKeyModel) TableName() string {
+	return "public.idempotency_keys"
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// OutboxEventModel is a domain event recorded in the same transaction as the
+// change it describes. Events are relayed to the configured sinks in
+// sequence order per form and marked as published afterwards.
+type OutboxEventModel struct {
+	ID            string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Sequence      int64     `gorm:"type:bigserial;not null;uniqueIndex;autoIncrement"`
+	FormID        string    `gorm:"not null;type:uuid"`
+	EventType     string    `gorm:"type:text;not null"`
+	Payload       string    `gorm:"type:jsonb;not null"`
+	OccurredAt    time.Time `gorm:"not null;default:now()"`
+	PublishedAt   *time.Time
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null;default:now()"`
+	LastError     string    `gorm:"type:text;not null;default:''"`
+}
+
+func (*OutboxEventModel) TableName() string {
+	return "public.outbox_events"
+}
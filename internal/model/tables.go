@@ -0,0 +1,20 @@
+package model
+
+// Tables returns a model of every table the application maps, e.g. to check
+// them against the database schema.
+func Tables() []any {
+	return []any{
+		&CredentialsModel{},
+		&FormModel{},
+		&FormStepModel{},
+		&IdempotencyKeyModel{},
+		&OutboxEventModel{},
+		&TrackingEventModel{},
+		&TrackingEventRollupModel{},
+		&TrackingEventRecipientRollupModel{},
+		&TrackingEventRollupStateModel{},
+		&WebhookModel{},
+		&WebhookDeliveryModel{},
+		&WebhookDeliveryAttemptModel{},
+	}
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	TrackingEventView          = "view"
+	TrackingEventOpen          = "open"
+	TrackingEventClick         = "click"
+	TrackingEventStepCompleted = "step_completed"
+)
+
+type TrackingEventModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	EventType  string    `gorm:"type:text;not null"`
+	FormID     string    `gorm:"not null;type:uuid"`
+	StepID     string    `gorm:"not null;type:uuid"`
+	Recipient  string    `gorm:"type:text;not null"`
+	URL        string    `gorm:"type:text;not null;default:''"`
+	UserAgent  string    `gorm:"type:text;not null;default:''"`
+	IPHash     string    `gorm:"type:text;not null;default:''"`
+	OccurredAt time.Time `gorm:"not null;default:now()"`
+}
+
+func (*TrackingEventModel) TableName() string {
+	return "public.tracking_events"
+}
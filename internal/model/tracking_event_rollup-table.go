@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// TrackingEventRollupModel holds the number of tracking events per form step,
+// event type and hour. Rows are maintained by the analytics aggregator.
+type TrackingEventRollupModel struct {
+	FormID      string    `gorm:"primaryKey;type:uuid"`
+	StepID      string    `gorm:"primaryKey;type:uuid"`
+	EventType   string    `gorm:"primaryKey;type:text"`
+	BucketStart time.Time `gorm:"primaryKey"`
+	Events      int64     `gorm:"not null;default:0"`
+}
+
+func (*TrackingEventRollupModel) TableName() string {
+	return "public.tracking_event_rollups"
+}
+
+// TrackingEventRecipientRollupModel records that a recipient produced events
+// of a type for a form step in an hour, so that distinct recipients are
+// counted over hours rather than over every raw event. Rows are maintained
+// by the analytics aggregator along with the TrackingEventRollupModel.
+type TrackingEventRecipientRollupModel struct {
+	FormID      string    `gorm:"primaryKey;type:uuid"`
+	EventType   string    `gorm:"primaryKey;type:text"`
+	BucketStart time.Time `gorm:"primaryKey"`
+	StepID      string    `gorm:"primaryKey;type:uuid"`
+	Recipient   string    `gorm:"primaryKey;type:text"`
+}
+
+func (*TrackingEventRecipientRollupModel) TableName() string {
+	return "public.tracking_event_recipient_rollups"
+}
+
+// TrackingEventRollupStateModel is a single row table recording up to which
+// hour tracking events have been rolled up. Events at or after RolledUpUntil
+// are only available in the raw table.
+type TrackingEventRollupStateModel struct {
+	ID            int       `gorm:"primaryKey;default:1"`
+	RolledUpUntil time.Time `gorm:"not null"`
+}
+
+func (*TrackingEventRollupStateModel) TableName() string {
+	return "public.tracking_event_rollup_state"
+}
+
+// EventCount is a row of an aggregate query over tracking events. Fields the
+// query does not group by are left at their zero value.
+type EventCount struct {
+	Bucket    time.Time
+	StepID    string
+	EventType string
+	Count     int64
+}
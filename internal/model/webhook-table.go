@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"salesforge-assignment/internal/api"
+	"time"
+)
+
+const (
+	webhookHref         = "%s%s/webhooks/%s"
+	webhookDeliveryHref = "%s%s/webhooks/%s/deliveries/%s"
+)
+
+// WebhookModel is a subscription to form events. Webhooks without a form
+// receive the events of every form in the workspace.
+type WebhookModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	FormID     *string   `gorm:"type:uuid"`
+	URL        string    `gorm:"type:text;not null"`
+	Secret     string    `gorm:"type:text;not null"`
+	EventTypes []string  `gorm:"type:jsonb;serializer:json;not null"`
+	CreatedAt  time.Time `gorm:"not null;default:now()"`
+}
+
+func (*WebhookModel) TableName() string {
+	return "public.webhooks"
+}
+
+func (s *WebhookModel) ToResponse(publicUrl string, baseUrl string) *api.WebhookResponseGet {
+	eventTypes := make([]api.WebhookEventType, 0, len(s.EventTypes))
+	for _, eventType := range s.EventTypes {
+		eventTypes = append(eventTypes, api.WebhookEventType(eventType))
+	}
+
+	return &api.WebhookResponseGet{
+		Url:        s.URL,
+		FormId:     s.FormID,
+		EventTypes: eventTypes,
+		CreatedAt:  s.CreatedAt,
+		Self: api.SelfId{
+			Id:   s.ID,
+			Href: GetWebhookHref(s.ID, publicUrl, baseUrl),
+		},
+	}
+}
+
+func GetWebhookHref(webhookId string, publicUrl string, baseUrl string) string {
+	return fmt.Sprintf(webhookHref, publicUrl, baseUrl, webhookId)
+}
+
+func GetWebhookDeliveryHref(webhookId string, deliveryId string, publicUrl string, baseUrl string) string {
+	return fmt.Sprintf(webhookDeliveryHref, publicUrl, baseUrl, webhookId, deliveryId)
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"salesforge-assignment/internal/api"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookDeliveryModel is a single event to be posted to a webhook. The
+// payload is stored verbatim so that redeliveries are byte-identical.
+type WebhookDeliveryModel struct {
+	ID            string                        `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	WebhookID     string                        `gorm:"not null;type:uuid;uniqueIndex:idx_webhook_deliveries_webhook_event"`
+	EventID       string                        `gorm:"not null;type:uuid;uniqueIndex:idx_webhook_deliveries_webhook_event"`
+	EventType     string                        `gorm:"type:text;not null"`
+	Payload       string                        `gorm:"type:text;not null"`
+	Status        string                        `gorm:"type:text;not null;default:pending"`
+	Attempts      int                           `gorm:"not null;default:0"`
+	NextAttemptAt time.Time                     `gorm:"not null;default:now()"`
+	CreatedAt     time.Time                     `gorm:"not null;default:now()"`
+	Webhook       *WebhookModel                 `gorm:"foreignKey:WebhookID"`
+	AttemptLog    []WebhookDeliveryAttemptModel `gorm:"foreignKey:DeliveryID"`
+}
+
+func (*WebhookDeliveryModel) TableName() string {
+	return "public.webhook_deliveries"
+}
+
+func (s *WebhookDeliveryModel) ToResponse(publicUrl string, baseUrl string) *api.WebhookDeliveryResponseGet {
+	response := &api.WebhookDeliveryResponseGet{
+		EventId:   s.EventID,
+		EventType: api.WebhookEventType(s.EventType),
+		Status:    api.WebhookDeliveryStatus(s.Status),
+		Attempts:  s.Attempts,
+		Payload:   s.Payload,
+		CreatedAt: s.CreatedAt,
+		Self: api.SelfId{
+			Id:   s.ID,
+			Href: GetWebhookDeliveryHref(s.WebhookID, s.ID, publicUrl, baseUrl),
+		},
+	}
+
+	if s.Status == WebhookDeliveryPending {
+		nextAttemptAt := s.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	if s.AttemptLog != nil {
+		attempts := make([]api.WebhookDeliveryAttempt, 0, len(s.AttemptLog))
+		for _, attempt := range s.AttemptLog {
+			attempts = append(attempts, *attempt.ToResponse())
+		}
+		response.AttemptLog = &attempts
+	}
+
+	return response
+}
+
+type WebhookDeliveryAttemptModel struct {
+	ID          string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DeliveryID  string    `gorm:"not null;type:uuid"`
+	AttemptedAt time.Time `gorm:"not null"`
+	StatusCode  *int
+	Error       string `gorm:"type:text;not null;default:''"`
+	DurationMs  int64  `gorm:"not null"`
+}
+
+func (*WebhookDeliveryAttemptModel) TableName() string {
+	return "public.webhook_delivery_attempts"
+}
+
+func (s *WebhookDeliveryAttemptModel) ToResponse() *api.WebhookDeliveryAttempt {
+	response := &api.WebhookDeliveryAttempt{
+		AttemptedAt: s.AttemptedAt,
+		StatusCode:  s.StatusCode,
+		DurationMs:  s.DurationMs,
+	}
+	if s.Error != "" {
+		response.Error = &s.Error
+	}
+	return response
+}
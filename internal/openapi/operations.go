@@ -0,0 +1,233 @@
+package openapi
+
+import (
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"regexp"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/problem"
+	"sort"
+	"strings"
+)
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Operations maps the routes of the router to the operations of the spec,
+// so that behaviour declared in the spec, like security requirements, is
+// applied per operation instead of per hand-maintained route group.
+type Operations struct {
+	// byRoute holds the operations by method and gin path, e.g.
+	// "GET /api/v1/form/:formId"
+	byRoute map[string]*openapi3.Operation
+	// security holds the requirements of operations that do not override them
+	security openapi3.SecurityRequirements
+}
+
+// NewOperations indexes the operations of the spec embedded in the api
+// package, served under baseURL.
+func NewOperations(baseURL string) (*Operations, error) {
+	doc, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded OpenAPI spec: %w", err)
+	}
+	return OperationsOf(doc, baseURL), nil
+}
+
+// OperationsOf indexes the operations of doc, served under baseURL.
+func OperationsOf(doc *openapi3.T, baseURL string) *Operations {
+	byRoute := map[string]*openapi3.Operation{}
+	for path, item := range doc.Paths.Map() {
+		ginPath := baseURL + pathParam.ReplaceAllString(path, ":$1")
+		for method, operation := range item.Operations() {
+			byRoute[routeKey(method, ginPath)] = operation
+		}
+	}
+	return &Operations{byRoute: byRoute, security: doc.Security}
+}
+
+// Authenticator authenticates a request by a security scheme of the spec and
+// authorizes it for the scopes the requirement lists for the scheme, such as
+// the roles of the user. It returns the error to respond with when the
+// request lacks valid credentials or scopes instead of responding itself, so
+// that the alternatives of a requirement can be tried.
+type Authenticator func(c *gin.Context, scopes []string) error
+
+// check is an Authenticator bound to the scopes of a requirement.
+type check struct {
+	authenticator Authenticator
+	scopes        []string
+}
+
+// Security returns a middleware that authenticates and authorizes, for each
+// operation, the request by the security requirements of the operation.
+// schemes maps the names of the security schemes of the spec to their
+// authenticators, which are given the scopes of the requirement. The
+// requirements of an operation are alternatives: the request is let through
+// once all schemes of one of them authenticate it, and otherwise rejected
+// with the error of the first requirement. Routes missing from the spec are
+// left alone.
+func (o *Operations) Security(schemes map[string]Authenticator) (gin.HandlerFunc, error) {
+	alternatives := map[string][][]check{}
+	for route, operation := range o.byRoute {
+		requirements := o.security
+		if operation.Security != nil {
+			requirements = *operation.Security
+		}
+
+		var chains [][]check
+		for _, requirement := range requirements {
+			if len(requirement) == 0 {
+				// An empty requirement makes the operation public
+				chains = nil
+				break
+			}
+			names := make([]string, 0, len(requirement))
+			for name := range requirement {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			chain := make([]check, 0, len(names))
+			for _, name := range names {
+				authenticator, ok := schemes[name]
+				if !ok {
+					return nil, fmt.Errorf("no authenticator for security scheme %q of %s", name, route)
+				}
+				chain = append(chain, check{authenticator: authenticator, scopes: requirement[name]})
+			}
+			chains = append(chains, chain)
+		}
+		if len(chains) > 0 {
+			alternatives[route] = chains
+		}
+	}
+
+	return func(c *gin.Context) {
+		chains, ok := alternatives[routeKey(c.Request.Method, c.FullPath())]
+		if !ok {
+			return
+		}
+
+		var rejection error
+		for _, chain := range chains {
+			err := authenticate(c, chain)
+			if err == nil {
+				return
+			}
+			if rejection == nil {
+				rejection = err
+			}
+		}
+		problem.Abort(c, rejection)
+	}, nil
+}
+
+func authenticate(c *gin.Context, chain []check) error {
+	for _, check := range chain {
+		if err := check.authenticator(c, check.scopes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	// RateLimitExtension names, on an operation of the spec, the rate limit
+	// its requests count against.
+	RateLimitExtension = "x-rate-limit"
+	// FeatureExtension names, on an operation of the spec, the feature that
+	// must be on to serve it.
+	FeatureExtension = "x-feature"
+)
+
+// RateLimits returns a middleware that passes the requests of the operations
+// naming a rate limit with the RateLimitExtension to limit, and rejects them
+// with the error it returns.
+func (o *Operations) RateLimits(limit func(c *gin.Context, name string) error) (gin.HandlerFunc, error) {
+	return o.byExtension(RateLimitExtension, limit)
+}
+
+// Features returns a middleware that responds 404 to the requests of the
+// operations naming a feature with the FeatureExtension while enabled
+// reports it off, as if the operations did not exist.
+func (o *Operations) Features(enabled func(name string) bool) (gin.HandlerFunc, error) {
+	return o.byExtension(FeatureExtension, func(c *gin.Context, name string) error {
+		if !enabled(name) {
+			return &apierrors.ResourceNotFoundError{Err: fmt.Errorf("feature %q is off", name)}
+		}
+		return nil
+	})
+}
+
+// byExtension returns a middleware that passes the requests of the
+// operations naming something with the extension to check, and rejects them
+// with the error it returns.
+func (o *Operations) byExtension(extension string, check func(c *gin.Context, name string) error) (gin.HandlerFunc, error) {
+	names := map[string]string{}
+	for route, operation := range o.byRoute {
+		value, ok := operation.Extensions[extension]
+		if !ok {
+			continue
+		}
+		name, ok := value.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s of %s must be a name, got %v", extension, route, value)
+		}
+		names[route] = name
+	}
+
+	return func(c *gin.Context) {
+		name, ok := names[routeKey(c.Request.Method, c.FullPath())]
+		if !ok {
+			return
+		}
+		if err := check(c, name); err != nil {
+			problem.Abort(c, err)
+		}
+	}, nil
+}
+
+// WithHeader returns a middleware that runs middleware only for the
+// operations that declare the header parameter name, e.g. the
+// Idempotency-Key of the operations that can be retried safely.
+func (o *Operations) WithHeader(name string, middleware gin.HandlerFunc) gin.HandlerFunc {
+	routes := map[string]bool{}
+	for route, operation := range o.byRoute {
+		if operation.Parameters.GetByInAndName(openapi3.ParameterInHeader, name) != nil {
+			routes[route] = true
+		}
+	}
+
+	return func(c *gin.Context) {
+		if routes[routeKey(c.Request.Method, c.FullPath())] {
+			middleware(c)
+		}
+	}
+}
+
+// CheckRoutes fails when an operation of the spec has no route, e.g. because
+// a path of the spec changed without regenerating the server.
+func (o *Operations) CheckRoutes(routes gin.RoutesInfo) error {
+	registered := map[string]bool{}
+	for _, route := range routes {
+		registered[routeKey(route.Method, route.Path)] = true
+	}
+
+	var missing []string
+	for route, operation := range o.byRoute {
+		if !registered[route] {
+			missing = append(missing, fmt.Sprintf("%s (%s)", route, operation.OperationID))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("operations of the OpenAPI spec without a handler: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func routeKey(method string, path string) string {
+	return method + " " + path
+}
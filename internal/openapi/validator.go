@@ -0,0 +1,316 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/rs/zerolog"
+	"io"
+	"regexp"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/i18n"
+	"salesforge-assignment/internal/problem"
+	"strconv"
+	"strings"
+)
+
+// Validator checks requests, and in debug mode responses, against the
+// OpenAPI specification, so that the spec stays the single source of truth
+// for the API contract. Requests to paths missing from the spec are left to
+// the router.
+type Validator struct {
+	log       *zerolog.Logger
+	router    routers.Router
+	responses bool
+	options   *openapi3filter.Options
+}
+
+// NewValidator validates against the spec embedded in the api package.
+func NewValidator(log *zerolog.Logger, cfg config.ValidationConfig) (*Validator, error) {
+	doc, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded OpenAPI spec: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+	rejectUnknownFields(doc)
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Validator{
+		log:       log,
+		router:    router,
+		responses: cfg.Responses,
+		options: &openapi3filter.Options{
+			MultiError: true,
+			// Authentication is checked by the auth middleware
+			AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+			IncludeResponseStatus: true,
+			SkipSettingDefaults:   true,
+		},
+	}, nil
+}
+
+func (v *Validator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, pathParams, err := v.router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    v.options,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), requestInput); err != nil {
+			v.log.Debug().Err(err).Str("path", c.Request.URL.Path).Msg("Request does not match the OpenAPI spec")
+			details := validationErrors(err)
+			localize(i18n.Translator(c.GetHeader("Accept-Language")), details)
+			problem.RespondValidationErrors(c, details)
+			c.Abort()
+			return
+		}
+
+		if !v.responses {
+			c.Next()
+			return
+		}
+
+		recorder := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 recorder.Status(),
+			Header:                 recorder.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+			Options:                v.options,
+		}
+		if err := openapi3filter.ValidateResponse(c.Request.Context(), responseInput); err != nil {
+			problem.Respond(c, fmt.Errorf("%d response does not match the OpenAPI spec: %w", recorder.Status(), err))
+			return
+		}
+		if recorder.body.Len() > 0 {
+			recorder.ResponseWriter.Write(recorder.body.Bytes())
+		}
+	}
+}
+
+// validationErrors converts the errors of openapi3filter into the fields of
+// a ValidationErrorResponse.
+func validationErrors(err error) api.ValidationErrors {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		details := api.ValidationErrors{}
+		for _, err := range multi {
+			details = append(details, validationErrors(err)...)
+		}
+		return details
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		// Schema errors of a single value may come as a multi error as well
+		var nested openapi3.MultiError
+		if errors.As(schemaErr.Origin, &nested) {
+			return validationErrors(nested)
+		}
+		return api.ValidationErrors{schemaValidationError(err, schemaErr)}
+	}
+
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) {
+		detail := api.ValidationError{Field: "body", Rule: "schema", Message: requestErr.Error()}
+		if requestErr.Parameter != nil {
+			detail.Field = requestErr.Parameter.Name
+			detail.Rule = "parameter"
+		}
+		return api.ValidationErrors{detail}
+	}
+
+	var responseErr *openapi3filter.ResponseError
+	if errors.As(err, &responseErr) {
+		return api.ValidationErrors{{Field: "response", Rule: "schema", Message: responseErr.Error()}}
+	}
+
+	return api.ValidationErrors{{Field: "request", Rule: "schema", Message: err.Error()}}
+}
+
+// localize translates the messages of the schema rules that have
+// translations, given the name of the field and the parameter of the rule.
+func localize(trans ut.Translator, details api.ValidationErrors) {
+	for i := range details {
+		detail := &details[i]
+		name := detail.Field
+		if index := strings.LastIndexAny(name, ".]"); index >= 0 {
+			name = name[index+1:]
+		}
+		var param string
+		if detail.Param != nil {
+			param = *detail.Param
+		}
+		detail.Message = i18n.Translate(trans, "openapi."+detail.Rule, detail.Message, name, param)
+	}
+}
+
+var unsupportedPropertyPattern = regexp.MustCompile(`^property "(.*)" is unsupported$`)
+
+func schemaValidationError(err error, schemaErr *openapi3.SchemaError) api.ValidationError {
+	pointer := schemaErr.JSONPointer()
+	detail := api.ValidationError{
+		Rule:    schemaErr.SchemaField,
+		Message: schemaErr.Reason,
+	}
+	// Unknown properties are reported on the enclosing object
+	if match := unsupportedPropertyPattern.FindStringSubmatch(schemaErr.Reason); match != nil {
+		pointer = append(pointer, match[1])
+		detail.Rule = "additionalProperties"
+	}
+	detail.Field = fieldPath(pointer)
+
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) && requestErr.Parameter != nil {
+		detail.Field = requestErr.Parameter.Name
+	}
+	if detail.Field == "" {
+		detail.Field = "body"
+	}
+	if param := ruleParam(schemaErr); param != "" {
+		detail.Param = &param
+	}
+	return detail
+}
+
+// fieldPath joins a JSON pointer the way the validator of the handlers
+// reports fields, e.g. steps[2].content.
+func fieldPath(pointer []string) string {
+	var path strings.Builder
+	for _, segment := range pointer {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path.WriteString("[" + segment + "]")
+			continue
+		}
+		if path.Len() > 0 {
+			path.WriteString(".")
+		}
+		path.WriteString(segment)
+	}
+	return path.String()
+}
+
+func ruleParam(schemaErr *openapi3.SchemaError) string {
+	schema := schemaErr.Schema
+	if schema == nil {
+		return ""
+	}
+
+	switch schemaErr.SchemaField {
+	case "minLength":
+		return strconv.FormatUint(schema.MinLength, 10)
+	case "maxLength":
+		return formatLimit(schema.MaxLength)
+	case "minItems":
+		return strconv.FormatUint(schema.MinItems, 10)
+	case "maxItems":
+		return formatLimit(schema.MaxItems)
+	case "minimum":
+		return formatFloat(schema.Min)
+	case "maximum":
+		return formatFloat(schema.Max)
+	case "pattern":
+		return schema.Pattern
+	case "format":
+		return schema.Format
+	case "enum":
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		return strings.Join(values, " ")
+	default:
+		return ""
+	}
+}
+
+func formatLimit(limit *uint64) string {
+	if limit == nil {
+		return ""
+	}
+	return strconv.FormatUint(*limit, 10)
+}
+
+func formatFloat(limit *float64) string {
+	if limit == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*limit, 'f', -1, 64)
+}
+
+// rejectUnknownFields closes the object schemas of request bodies that do
+// not allow additional properties explicitly, so that misspelled fields are
+// reported instead of silently ignored.
+func rejectUnknownFields(doc *openapi3.T) {
+	visited := map[*openapi3.Schema]bool{}
+	for _, path := range doc.Paths.Map() {
+		for _, operation := range path.Operations() {
+			if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+				continue
+			}
+			for _, mediaType := range operation.RequestBody.Value.Content {
+				closeSchema(mediaType.Schema, visited)
+			}
+		}
+	}
+}
+
+func closeSchema(ref *openapi3.SchemaRef, visited map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || visited[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	visited[schema] = true
+
+	if len(schema.Properties) > 0 && schema.AdditionalProperties.Has == nil && schema.AdditionalProperties.Schema == nil {
+		closed := false
+		schema.AdditionalProperties.Has = &closed
+	}
+	for _, property := range schema.Properties {
+		closeSchema(property, visited)
+	}
+	closeSchema(schema.Items, visited)
+	for _, alternative := range schema.OneOf {
+		closeSchema(alternative, visited)
+	}
+}
+
+// bufferedWriter holds back the response body until it has been validated.
+// The status is only recorded by the gin writer until the body is written.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(data string) (int, error) {
+	return w.body.WriteString(data)
+}
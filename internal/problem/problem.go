@@ -0,0 +1,187 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"math"
+	"net/http"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/i18n"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/middleware"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the media type of RFC 7807 problem details. Clients opt in
+// to problem details by accepting it; everyone else keeps getting the
+// ErrorResponse and ValidationErrorResponse bodies.
+const ContentType = "application/problem+json"
+
+// Respond writes err as the error response of the request.
+func Respond(c *gin.Context, err error) {
+	log := logger.FromContext(c)
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		log.Debug().Err(err).Msg("Validation error occurred")
+		respondValidationError(c, ve)
+		return
+	}
+
+	var httpErr apierrors.HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = &apierrors.InvalidApplicationStateError{Err: err}
+	}
+
+	var tooMany *apierrors.TooManyRequestsError
+	if errors.As(httpErr, &tooMany) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+	}
+
+	response := httpErr.APIErrorResponse()
+	// Client errors are expected and logged at Warn by GinLogger already
+	if response.Code >= http.StatusInternalServerError {
+		log.Error().Err(err).Msg("Error occurred while handling request")
+	} else {
+		log.Debug().Err(err).Msg("Error occurred while handling request")
+	}
+	trans := translator(c)
+	key, params := httpErr.MessageKey()
+	response.Message = i18n.Translate(trans, key, response.Message, params...)
+	if !acceptsProblem(c) {
+		c.JSON(response.Code, response)
+		return
+	}
+
+	problem := newProblem(c, httpErr.ErrorCode(), response.Code)
+	problem.Detail = &response.Message
+	problem.Field = response.Field
+	write(c, problem)
+}
+
+// Abort writes err as the error response and stops the handler chain.
+func Abort(c *gin.Context, err error) {
+	Respond(c, err)
+	c.Abort()
+}
+
+// NoRoute handles requests to unknown routes.
+func NoRoute() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		Respond(c, &apierrors.ResourceNotFoundError{})
+	}
+}
+
+// NoMethod handles requests with a method the route does not support.
+func NoMethod() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		Respond(c, &apierrors.MethodNotAllowedError{})
+	}
+}
+
+// Recovery turns panics into internal error responses.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		Abort(c, &apierrors.InvalidApplicationStateError{Err: fmt.Errorf("panic: %v", recovered)})
+	})
+}
+
+// BindingErrorHandler reports parameter binding errors of the generated
+// route wrappers.
+func BindingErrorHandler(c *gin.Context, err error, _ int) {
+	Respond(c, &apierrors.InvalidInputError{Err: err})
+}
+
+func respondValidationError(c *gin.Context, ve validator.ValidationErrors) {
+	trans := translator(c)
+	details := api.ValidationErrors{}
+	for _, fe := range ve {
+		details = append(details, validationError(trans, fe))
+	}
+	respondValidationErrors(c, trans, details)
+}
+
+// RespondValidationErrors writes validation errors that were not found by
+// the validator of the handlers, e.g. by the OpenAPI request validation.
+func RespondValidationErrors(c *gin.Context, details api.ValidationErrors) {
+	respondValidationErrors(c, translator(c), details)
+}
+
+func respondValidationErrors(c *gin.Context, trans ut.Translator, details api.ValidationErrors) {
+	message := i18n.Translate(trans, string(apierrors.CodeValidationFailed), "One or more fields failed validation.")
+	if !acceptsProblem(c) {
+		c.JSON(http.StatusBadRequest, api.ValidationErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: message,
+			Errors:  details,
+		})
+		return
+	}
+
+	problem := newProblem(c, apierrors.CodeValidationFailed, http.StatusBadRequest)
+	problem.Detail = &message
+	problem.Errors = &details
+	write(c, problem)
+}
+
+func validationError(trans ut.Translator, fe validator.FieldError) api.ValidationError {
+	detail := api.ValidationError{
+		Field: i18n.FieldPath(fe),
+		Rule:  fe.Tag(),
+	}
+	if param := fe.Param(); param != "" {
+		detail.Param = &param
+	}
+
+	// Errors of validators without registered translations translate to
+	// their Go error text, which is no better than the generic message
+	detail.Message = fe.Translate(trans)
+	if detail.Message == "" || detail.Message == fe.Error() {
+		detail.Message = fmt.Sprint("Field validation for '", fe.Tag(), "' failed.")
+	}
+	return detail
+}
+
+// translator returns the translator of the request's Accept-Language and
+// reports the chosen language in the response.
+func translator(c *gin.Context) ut.Translator {
+	var acceptLanguage string
+	if c.Request != nil {
+		acceptLanguage = c.GetHeader("Accept-Language")
+	}
+	trans := i18n.Translator(acceptLanguage)
+	c.Header("Content-Language", trans.Locale())
+	return trans
+}
+
+func newProblem(c *gin.Context, code apierrors.Code, status int) api.Problem {
+	problem := api.Problem{
+		Type:   code.TypeURI(),
+		Title:  code.Title(),
+		Status: status,
+		Code:   string(code),
+	}
+	if c.Request != nil {
+		instance := c.Request.URL.Path
+		problem.Instance = &instance
+	}
+	if requestId := c.GetString(middleware.RequestIDKey); requestId != "" {
+		problem.RequestId = &requestId
+	}
+	return problem
+}
+
+func write(c *gin.Context, problem api.Problem) {
+	// gin keeps a content type that is already set
+	c.Header("Content-Type", ContentType)
+	c.JSON(problem.Status, problem)
+}
+
+func acceptsProblem(c *gin.Context) bool {
+	return c.Request != nil && strings.Contains(c.GetHeader("Accept"), ContentType)
+}
@@ -0,0 +1,85 @@
+// Package ratelimit limits how often each client may call the operations
+// that name a rate limit in the spec.
+package ratelimit
+
+import (
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/cache"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/middleware/auth"
+	"sync"
+	"time"
+)
+
+// maxClients bounds the token buckets kept in memory. The buckets of the
+// clients seen least recently are dropped first, which only lets them start
+// over with a full burst.
+const maxClients = 100_000
+
+// Limiter keeps a token bucket per limit and client. The limits are read
+// from current on every request, so that configuration reloads apply at
+// once.
+type Limiter struct {
+	current func() map[string]config.RateLimitConfig
+	mu      sync.Mutex
+	buckets *cache.LRU[string, *rate.Limiter]
+}
+
+func NewLimiter(current func() map[string]config.RateLimitConfig) *Limiter {
+	return &Limiter{
+		current: current,
+		buckets: cache.NewLRU[string, *rate.Limiter](maxClients, 0),
+	}
+}
+
+// Allow takes a token from the bucket of client for the limit name. It
+// reports how long until the client may retry when the bucket is empty.
+// Limits that are not configured allow everything.
+func (l *Limiter) Allow(name string, client string) (bool, time.Duration) {
+	cfg, ok := l.current()[name]
+	if !ok {
+		return true, 0
+	}
+	limit := rate.Limit(float64(cfg.Requests) / cfg.Per.Seconds())
+	burst := cfg.Burst
+	if burst == 0 {
+		burst = cfg.Requests
+	}
+
+	l.mu.Lock()
+	key := name + " " + client
+	bucket, ok := l.buckets.Get(key)
+	if !ok {
+		bucket = rate.NewLimiter(limit, burst)
+		l.buckets.Add(key, bucket)
+	} else if bucket.Limit() != limit || bucket.Burst() != burst {
+		bucket.SetLimit(limit)
+		bucket.SetBurst(burst)
+	}
+	l.mu.Unlock()
+
+	now := time.Now()
+	reservation := bucket.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// Check returns the error to reject the request with if its client is over
+// the limit name. Authenticated requests are limited per user, the others
+// per client address.
+func (l *Limiter) Check(c *gin.Context, name string) error {
+	client := "ip:" + c.ClientIP()
+	if claims, ok := c.Get(auth.ClaimsKey); ok {
+		client = "user:" + claims.(*auth.Claims).UserId
+	}
+
+	if ok, retryAfter := l.Allow(name, client); !ok {
+		return &apierrors.TooManyRequestsError{RetryAfter: retryAfter}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"salesforge-assignment/internal/model"
+	"time"
+)
+
+const (
+	rollupEventsQuery = `
+INSERT INTO public.tracking_event_rollups (form_id, step_id, event_type, bucket_start, events)
+SELECT form_id, step_id, event_type, date_trunc('hour', occurred_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', count(*)
+FROM public.tracking_events
+WHERE occurred_at >= @from AND occurred_at < @to
+GROUP BY 1, 2, 3, 4
+ON CONFLICT (form_id, step_id, event_type, bucket_start) DO UPDATE SET events = EXCLUDED.events`
+
+	rollupRecipientsQuery = `
+INSERT INTO public.tracking_event_recipient_rollups (form_id, event_type, bucket_start, step_id, recipient)
+SELECT DISTINCT form_id, event_type, date_trunc('hour', occurred_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', step_id, recipient
+FROM public.tracking_events
+WHERE occurred_at >= @from AND occurred_at < @to
+ON CONFLICT DO NOTHING`
+
+	// Hours before the watermark are read from the rollups, everything after
+	// it from the raw events.
+	countEventsQuery = `
+WITH state AS (
+    SELECT COALESCE((SELECT rolled_up_until FROM public.tracking_event_rollup_state WHERE id = 1),
+                    '-infinity'::timestamptz) AS until
+)
+SELECT date_trunc(@bucket, e.occurred_at AT TIME ZONE 'UTC') AS bucket, e.event_type, sum(e.events)::bigint AS count
+FROM (
+    SELECT r.bucket_start AS occurred_at, r.event_type, r.events
+    FROM public.tracking_event_rollups r, state
+    WHERE r.form_id = @form AND r.event_type IN @types
+      AND r.bucket_start >= @from AND r.bucket_start < LEAST(@to, state.until)
+    UNION ALL
+    SELECT t.occurred_at, t.event_type, 1
+    FROM public.tracking_events t, state
+    WHERE t.form_id = @form AND t.event_type IN @types
+      AND t.occurred_at >= GREATEST(@from, state.until) AND t.occurred_at < @to
+) e
+GROUP BY 1, 2`
+
+	// recipientsQuery lists who produced the events, once per hour and step
+	// from the recipient rollups before the watermark, and once per raw
+	// event after it. Distinct recipients are counted over its rows.
+	recipientsQuery = `
+WITH state AS (
+    SELECT COALESCE((SELECT rolled_up_until FROM public.tracking_event_rollup_state WHERE id = 1),
+                    '-infinity'::timestamptz) AS until
+)
+SELECT r.bucket_start AS occurred_at, r.step_id, r.event_type, r.recipient
+FROM public.tracking_event_recipient_rollups r, state
+WHERE r.form_id = @form AND r.event_type IN @types
+  AND r.bucket_start >= @from AND r.bucket_start < LEAST(@to, state.until)
+UNION ALL
+SELECT t.occurred_at, t.step_id, t.event_type, t.recipient
+FROM public.tracking_events t, state
+WHERE t.form_id = @form AND t.event_type IN @types
+  AND t.occurred_at >= GREATEST(@from, state.until) AND t.occurred_at < @to`
+
+	countRecipientsPerBucketQuery = `
+SELECT date_trunc(@bucket, e.occurred_at AT TIME ZONE 'UTC') AS bucket, e.event_type, count(DISTINCT e.recipient) AS count
+FROM (` + recipientsQuery + `) e
+GROUP BY 1, 2`
+
+	countRecipientsPerStepQuery = `
+SELECT e.step_id, e.event_type, count(DISTINCT e.recipient) AS count
+FROM (` + recipientsQuery + `) e
+GROUP BY 1, 2`
+
+	countRecipientsQuery = `
+SELECT e.event_type, count(DISTINCT e.recipient) AS count
+FROM (` + recipientsQuery + `) e
+GROUP BY 1`
+)
+
+type AnalyticsRepository interface {
+	RollupEvents(ctx context.Context, until time.Time) (time.Time, error)
+	CountEvents(ctx context.Context, formId string, bucket string, types []string, from time.Time, to time.Time) ([]model.EventCount, error)
+	CountRecipientsPerBucket(ctx context.Context, formId string, bucket string, types []string, from time.Time, to time.Time) ([]model.EventCount, error)
+	CountRecipientsPerStep(ctx context.Context, formId string, types []string, from time.Time, to time.Time) ([]model.EventCount, error)
+	CountRecipients(ctx context.Context, formId string, types []string, from time.Time, to time.Time) ([]model.EventCount, error)
+}
+
+type AnalyticsRepositoryImpl struct {
+	log *zerolog.Logger
+	db  *gorm.DB
+}
+
+func NewAnalyticsRepository(
+	log *zerolog.Logger,
+	db *gorm.DB,
+) AnalyticsRepository {
+	return &AnalyticsRepositoryImpl{
+		log: log,
+		db:  db,
+	}
+}
+
+// RollupEvents aggregates all raw events from the current watermark up to
+// the given hour into the hourly rollups of events and of recipients, and
+// advances the watermark. The
+// state row is locked for the duration so concurrent aggregators on several
+// replicas serialize. It returns the hour the rollup started from.
+func (ar *AnalyticsRepositoryImpl) RollupEvents(ctx context.Context, until time.Time) (time.Time, error) {
+	until = until.UTC().Truncate(time.Hour)
+	var from time.Time
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ar.ensureRollupState(tx, until); err != nil {
+			return err
+		}
+
+		var state model.TrackingEventRollupStateModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&state, 1).Error
+		if err != nil {
+			return err
+		}
+
+		from = state.RolledUpUntil.UTC()
+		if !from.Before(until) {
+			return nil
+		}
+
+		hours := map[string]interface{}{"from": from, "to": until}
+		if err := tx.Exec(rollupEventsQuery, hours).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(rollupRecipientsQuery, hours).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&state).Update("rolled_up_until", until).Error
+	})
+	if err != nil {
+		return time.Time{}, TranslateError(err)
+	}
+
+	return from, nil
+}
+
+// ensureRollupState creates the watermark on the first run, starting at the
+// hour of the earliest recorded event.
+func (ar *AnalyticsRepositoryImpl) ensureRollupState(tx *gorm.DB, until time.Time) error {
+	var state model.TrackingEventRollupStateModel
+	err := tx.First(&state, 1).Error
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	var earliest sql.NullTime
+	err = tx.Model(&model.TrackingEventModel{}).Select("min(occurred_at)").Scan(&earliest).Error
+	if err != nil {
+		return err
+	}
+
+	state = model.TrackingEventRollupStateModel{ID: 1, RolledUpUntil: until}
+	if earliest.Valid {
+		state.RolledUpUntil = earliest.Time.UTC().Truncate(time.Hour)
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&state).Error
+}
+
+func (ar *AnalyticsRepositoryImpl) CountEvents(
+	ctx context.Context,
+	formId string,
+	bucket string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) ([]model.EventCount, error) {
+	return ar.count(ctx, countEventsQuery, formId, bucket, types, from, to)
+}
+
+func (ar *AnalyticsRepositoryImpl) CountRecipientsPerBucket(
+	ctx context.Context,
+	formId string,
+	bucket string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) ([]model.EventCount, error) {
+	return ar.count(ctx, countRecipientsPerBucketQuery, formId, bucket, types, from, to)
+}
+
+func (ar *AnalyticsRepositoryImpl) CountRecipientsPerStep(
+	ctx context.Context,
+	formId string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) ([]model.EventCount, error) {
+	return ar.count(ctx, countRecipientsPerStepQuery, formId, "", types, from, to)
+}
+
+func (ar *AnalyticsRepositoryImpl) CountRecipients(
+	ctx context.Context,
+	formId string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) ([]model.EventCount, error) {
+	return ar.count(ctx, countRecipientsQuery, formId, "", types, from, to)
+}
+
+func (ar *AnalyticsRepositoryImpl) count(
+	ctx context.Context,
+	query string,
+	formId string,
+	bucket string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) ([]model.EventCount, error) {
+	var counts []model.EventCount
+	err := ar.db.WithContext(ctx).
+		Raw(query, map[string]interface{}{
+			"form":   formId,
+			"bucket": bucket,
+			"types":  types,
+			"from":   from,
+			"to":     to,
+		}).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"salesforge-assignment/internal/cache"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/retry"
+	"sync"
+	"time"
+)
+
+const (
+	listenInitialBackoff = time.Second
+	listenMaxBackoff     = time.Minute
+)
+
+// CachedFormRepository is a FormRepository that keeps recently read forms in
+// memory. Every change to a form invalidates it locally and, when a channel
+// is configured, on all replicas through Postgres LISTEN/NOTIFY.
+type CachedFormRepository interface {
+	FormRepository
+	Invalidate(formId string)
+	Listen(ctx context.Context, dsn string)
+}
+
+type CachedFormRepositoryImpl struct {
+	FormRepository
+	log     *zerolog.Logger
+	db      *gorm.DB
+	forms   *cache.LRU[string, model.FormModel]
+	channel string
+
+	// generation is bumped on every invalidation so that a read which
+	// started before it does not store a stale form
+	mu         sync.Mutex
+	generation uint64
+}
+
+func NewCachedFormRepository(
+	log *zerolog.Logger,
+	db *gorm.DB,
+	formRepository FormRepository,
+	forms *cache.LRU[string, model.FormModel],
+	channel string,
+) CachedFormRepository {
+	return &CachedFormRepositoryImpl{
+		FormRepository: formRepository,
+		log:            log,
+		db:             db,
+		forms:          forms,
+		channel:        channel,
+	}
+}
+
+func (cr *CachedFormRepositoryImpl) GetFormById(ctx context.Context, id string) (*model.FormModel, error) {
+	// Reads inside a transaction may see uncommitted changes, so they are
+	// neither served from nor stored in the cache
+	if inTransaction(ctx) {
+		return cr.FormRepository.GetFormById(ctx, id)
+	}
+
+	if form, ok := cr.forms.Get(id); ok {
+		return cloneForm(&form), nil
+	}
+
+	cr.mu.Lock()
+	generation := cr.generation
+	cr.mu.Unlock()
+
+	form, err := cr.FormRepository.GetFormById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cr.mu.Lock()
+	if generation == cr.generation {
+		cr.forms.Add(id, *cloneForm(form))
+	}
+	cr.mu.Unlock()
+
+	return form, nil
+}
+
+// GetFormVersion serves the version from the cached form when there is one.
+func (cr *CachedFormRepositoryImpl) GetFormVersion(ctx context.Context, id string) (*model.FormModel, error) {
+	if !inTransaction(ctx) {
+		if form, ok := cr.forms.Get(id); ok {
+			return &model.FormModel{ID: form.ID, Version: form.Version, UpdatedAt: form.UpdatedAt}, nil
+		}
+	}
+	return cr.FormRepository.GetFormVersion(ctx, id)
+}
+
+func (cr *CachedFormRepositoryImpl) UpdateForm(ctx context.Context, form *model.FormModel) (*model.FormModel, error) {
+	updated, err := cr.FormRepository.UpdateForm(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	return updated, cr.invalidate(ctx, form.ID)
+}
+
+func (cr *CachedFormRepositoryImpl) UpdateFormStep(ctx context.Context, step *model.FormStepModel) (*model.FormStepModel, error) {
+	updated, err := cr.FormRepository.UpdateFormStep(ctx, step)
+	if err != nil {
+		return nil, err
+	}
+	return updated, cr.invalidate(ctx, step.FormID)
+}
+
+func (cr *CachedFormRepositoryImpl) DeleteFormStepById(ctx context.Context, id string) error {
+	step, err := cr.FormRepository.GetFormStepById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := cr.FormRepository.DeleteFormStepById(ctx, id); err != nil {
+		return err
+	}
+	return cr.invalidate(ctx, step.FormID)
+}
+
+func (cr *CachedFormRepositoryImpl) DeleteFormStep(ctx context.Context, step *model.FormStepModel) error {
+	if err := cr.FormRepository.DeleteFormStep(ctx, step); err != nil {
+		return err
+	}
+	return cr.invalidate(ctx, step.FormID)
+}
+
+// Invalidate drops the cached copy of a form.
+func (cr *CachedFormRepositoryImpl) Invalidate(formId string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	cr.generation++
+	cr.forms.Remove(formId)
+}
+
+// Listen applies invalidations sent by other replicas until the context is
+// cancelled, reconnecting with backoff when the connection is lost.
+func (cr *CachedFormRepositoryImpl) Listen(ctx context.Context, dsn string) {
+	if cr.channel == "" {
+		return
+	}
+
+	attempt := 0
+	for {
+		err := cr.listen(ctx, dsn, func() { attempt = 0 })
+		if ctx.Err() != nil {
+			return
+		}
+
+		attempt++
+		delay := retry.Backoff(attempt, listenInitialBackoff, listenMaxBackoff)
+		zerolog.Ctx(ctx).Warn().Err(err).Dur("retryIn", delay).Msg("Form cache invalidation listener disconnected")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (cr *CachedFormRepositoryImpl) listen(ctx context.Context, dsn string, connected func()) error {
+	listener, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer listener.Close(context.Background())
+
+	if _, err := listener.Exec(ctx, "LISTEN "+pgx.Identifier{cr.channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	// Invalidations may have been missed while not listening
+	cr.purge()
+	connected()
+	zerolog.Ctx(ctx).Info().Str("channel", cr.channel).Msg("Listening for form cache invalidations")
+
+	for {
+		notification, err := listener.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		cr.Invalidate(notification.Payload)
+	}
+}
+
+// invalidate drops the form now and again once the surrounding transaction
+// commits, so a read racing the commit cannot keep the old form. The
+// notification to other replicas is part of the transaction and only
+// delivered on commit.
+func (cr *CachedFormRepositoryImpl) invalidate(ctx context.Context, formId string) error {
+	cr.Invalidate(formId)
+	AfterCommit(ctx, func() { cr.Invalidate(formId) })
+
+	if cr.channel == "" {
+		return nil
+	}
+	return TranslateError(conn(ctx, cr.db).Exec("SELECT pg_notify(?, ?)", cr.channel, formId).Error)
+}
+
+func (cr *CachedFormRepositoryImpl) purge() {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	cr.generation++
+	cr.forms.Purge()
+}
+
+// cloneForm copies a form so callers cannot change the cached copy.
+func cloneForm(form *model.FormModel) *model.FormModel {
+	clone := *form
+	clone.OpenTrackingEnabled = cloneBool(form.OpenTrackingEnabled)
+	clone.ClickTrackingEnabled = cloneBool(form.ClickTrackingEnabled)
+	if form.Steps != nil {
+		clone.Steps = make([]model.FormStepModel, len(form.Steps))
+		copy(clone.Steps, form.Steps)
+	}
+	return &clone
+}
+
+func cloneBool(value *bool) *bool {
+	if value == nil {
+		return nil
+	}
+	clone := *value
+	return &clone
+}
@@ -6,11 +6,16 @@ import (
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/logger"
 	"salesforge-assignment/internal/model"
 )
 
 type CredentialsRepository interface {
 	GetCredentialsByUsername(ctx context.Context, username string) (*model.CredentialsModel, error)
+	CreateCredentials(ctx context.Context, credentials *model.CredentialsModel) error
+	// UpdateCredentials sets the given columns of the user, failing with
+	// ResourceNotFoundError when there is no such user.
+	UpdateCredentials(ctx context.Context, username string, updates map[string]interface{}) error
 }
 
 type CredentialsRepositoryImpl struct {
@@ -42,6 +47,24 @@ func (cr *CredentialsRepositoryImpl) GetCredentialsByUsername(ctx context.Contex
 		return nil, &apierrors.InvalidApplicationStateError{}
 	}
 
-	cr.log.Trace().Msg("Retrieved credentials for user: " + username)
+	zerolog.Ctx(ctx).Trace().EmbedObject(logger.Fields{"username": username}).Msg("Retrieved credentials")
 	return &credentials, nil
 }
+
+func (cr *CredentialsRepositoryImpl) CreateCredentials(ctx context.Context, credentials *model.CredentialsModel) error {
+	if err := conn(ctx, cr.db).Create(credentials).Error; err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+func (cr *CredentialsRepositoryImpl) UpdateCredentials(ctx context.Context, username string, updates map[string]interface{}) error {
+	result := conn(ctx, cr.db).Model(&model.CredentialsModel{}).Where("username = ?", username).Updates(updates)
+	if result.Error != nil {
+		return TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return &apierrors.ResourceNotFoundError{}
+	}
+	return nil
+}
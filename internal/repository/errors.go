@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"regexp"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"strings"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgNotNullViolation          = "23502"
+	pgForeignKeyViolation       = "23503"
+	pgUniqueViolation           = "23505"
+	pgCheckViolation            = "23514"
+	pgSerializationFailure      = "40001"
+	pgDeadlockDetected          = "40P01"
+	pgInvalidTextRepresentation = "22P02"
+	pgStringDataRightTruncation = "22001"
+	pgNumericValueOutOfRange    = "22003"
+)
+
+// detailKeyPattern extracts the columns from details such as
+// "Key (name)=(Welcome) already exists."
+var detailKeyPattern = regexp.MustCompile(`^Key \(([^)]+)\)=`)
+
+// apiFields maps columns to the names of the request fields they are set
+// from, where the two differ beyond snake and camel case.
+var apiFields = map[string]string{
+	"step_order": "step",
+}
+
+// TranslateError classifies Postgres errors into API errors, so that
+// constraint violations reach clients as a 4xx naming the offending field
+// instead of a 500. Other errors are returned unchanged.
+func TranslateError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return &apierrors.ConflictError{Field: offendingField(pgErr), Err: err}
+	case pgForeignKeyViolation:
+		return &apierrors.UnprocessableEntityError{Field: offendingField(pgErr), Err: err}
+	case pgNotNullViolation, pgCheckViolation, pgInvalidTextRepresentation, pgStringDataRightTruncation, pgNumericValueOutOfRange:
+		return &apierrors.InvalidInputError{Field: offendingField(pgErr), Err: err}
+	case pgSerializationFailure, pgDeadlockDetected:
+		return &apierrors.ConcurrentUpdateError{Err: err}
+	default:
+		return err
+	}
+}
+
+// offendingField returns the request field a constraint violation is about,
+// or an empty string if it cannot be told. The offending value is never
+// included, since details may contain other users' data.
+func offendingField(pgErr *pgconn.PgError) string {
+	column := pgErr.ColumnName
+	if column == "" {
+		if match := detailKeyPattern.FindStringSubmatch(pgErr.Detail); match != nil {
+			column = match[1]
+		}
+	}
+	if column == "" {
+		return ""
+	}
+
+	columns := strings.Split(column, ",")
+	fields := make([]string, 0, len(columns))
+	for _, c := range columns {
+		fields = append(fields, apiField(strings.TrimSpace(c)))
+	}
+	return strings.Join(fields, ",")
+}
+
+func apiField(column string) string {
+	if field, ok := apiFields[column]; ok {
+		return field
+	}
+
+	parts := strings.Split(column, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -2,19 +2,28 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 	"salesforge-assignment/internal/model"
+	"time"
 )
 
+// ErrVersionConflict is returned when a form or step was modified since it
+// was loaded.
+var ErrVersionConflict = errors.New("version conflict")
+
 type FormRepository interface {
 	CreateForm(ctx context.Context, form *model.FormModel) (*model.FormModel, error)
 	GetFormById(ctx context.Context, id string) (*model.FormModel, error)
+	GetFormVersion(ctx context.Context, id string) (*model.FormModel, error)
 	UpdateForm(ctx context.Context, form *model.FormModel) (*model.FormModel, error)
 	UpdateFormStep(ctx context.Context, step *model.FormStepModel) (*model.FormStepModel, error)
 	DeleteFormStepById(ctx context.Context, id string) error
 	GetFormStepById(ctx context.Context, formId string) (*model.FormStepModel, error)
 	DeleteFormStep(ctx context.Context, step *model.FormStepModel) error
+	CountFormsAndSteps(ctx context.Context) (forms int64, steps int64, err error)
+	ExistsFormWithName(ctx context.Context, name string) (bool, error)
 }
 
 type FormRepositoryImpl struct {
@@ -33,64 +42,166 @@ func NewFormRepository(
 }
 
 func (sr *FormRepositoryImpl) CreateForm(ctx context.Context, form *model.FormModel) (*model.FormModel, error) {
-	err := sr.db.WithContext(ctx).Create(&form).Error
+	err := conn(ctx, sr.db).Create(&form).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return form, nil
 }
 
 func (sr *FormRepositoryImpl) GetFormById(ctx context.Context, id string) (*model.FormModel, error) {
 	var form *model.FormModel
-	err := sr.db.WithContext(ctx).
+	err := conn(ctx, sr.db).
 		Preload("Steps", func(db *gorm.DB) *gorm.DB {
 			return db.Order("step_order ASC")
 		}).
 		First(&form, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return form, nil
 }
 
-func (sr *FormRepositoryImpl) UpdateForm(ctx context.Context, form *model.FormModel) (*model.FormModel, error) {
-	err := sr.db.WithContext(ctx).Save(form).Error
+// GetFormVersion reads only the ID, version and modification time of a form,
+// which is enough to answer conditional requests.
+func (sr *FormRepositoryImpl) GetFormVersion(ctx context.Context, id string) (*model.FormModel, error) {
+	var form *model.FormModel
+	err := conn(ctx, sr.db).
+		Select("id", "version", "updated_at").
+		First(&form, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return form, nil
 }
 
+// UpdateForm stores the form if it is still at the version it was loaded
+// with and returns it at the incremented version.
+func (sr *FormRepositoryImpl) UpdateForm(ctx context.Context, form *model.FormModel) (*model.FormModel, error) {
+	updatedAt := time.Now().UTC()
+	result := conn(ctx, sr.db).
+		Model(&model.FormModel{}).
+		Where("id = ? AND version = ?", form.ID, form.Version).
+		Updates(map[string]interface{}{
+			"name":                   form.Name,
+			"open_tracking_enabled":  form.OpenTrackingEnabled,
+			"click_tracking_enabled": form.ClickTrackingEnabled,
+			"version":                gorm.Expr("version + 1"),
+			"updated_at":             updatedAt,
+		})
+	if result.Error != nil {
+		return nil, TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrVersionConflict
+	}
+
+	// The model of the caller keeps the version it was loaded with, so that
+	// a unit of work retried after a rollback updates that version again
+	updated := *form
+	updated.Version++
+	updated.UpdatedAt = updatedAt
+	return &updated, nil
+}
+
+// UpdateFormStep stores the step if it is still at the version it was loaded
+// with, increments the version of its form and returns it at the
+// incremented version.
 func (sr *FormRepositoryImpl) UpdateFormStep(ctx context.Context, step *model.FormStepModel) (*model.FormStepModel, error) {
-	err := sr.db.WithContext(ctx).Save(step).Error
+	err := conn(ctx, sr.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&model.FormStepModel{}).
+			Where("id = ? AND version = ?", step.ID, step.Version).
+			Updates(map[string]interface{}{
+				"name":       step.Name,
+				"content":    step.Content,
+				"step_order": step.StepOrder,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return incrementFormVersion(tx, step.FormID)
+	})
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
-	return step, nil
+
+	updated := *step
+	updated.Version++
+	return &updated, nil
 }
 
 func (sr *FormRepositoryImpl) DeleteFormStepById(ctx context.Context, id string) error {
-	err := sr.db.WithContext(ctx).Where("id = ?", id).Delete(&model.FormStepModel{}).Error
+	err := conn(ctx, sr.db).Where("id = ?", id).Delete(&model.FormStepModel{}).Error
 	if err != nil {
-		return err
+		return TranslateError(err)
 	}
 	return nil
 }
 
 func (sr *FormRepositoryImpl) GetFormStepById(ctx context.Context, stepId string) (*model.FormStepModel, error) {
 	var step *model.FormStepModel
-	err := sr.db.WithContext(ctx).Where("id = ?", stepId).Find(&step).Error
+	err := conn(ctx, sr.db).Where("id = ?", stepId).Find(&step).Error
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return step, nil
 }
 
+// DeleteFormStep deletes the step if it is still at the version it was
+// loaded with and increments the version of its form.
 func (sr *FormRepositoryImpl) DeleteFormStep(ctx context.Context, step *model.FormStepModel) error {
-	err := sr.db.WithContext(ctx).Delete(step).Error
+	err := conn(ctx, sr.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND version = ?", step.ID, step.Version).Delete(&model.FormStepModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return incrementFormVersion(tx, step.FormID)
+	})
 	if err != nil {
-		sr.log.Error().Err(err).Msg("Failed to delete form step")
-		return err
+		zerolog.Ctx(ctx).Error().Err(err).Msg("Failed to delete form step")
+		return TranslateError(err)
 	}
 	return nil
 }
+
+// incrementFormVersion marks a form as changed when one of its steps
+// changes, since steps are part of the form representation.
+func incrementFormVersion(tx *gorm.DB, formId string) error {
+	return tx.Model(&model.FormModel{}).
+		Where("id = ?", formId).
+		Updates(map[string]interface{}{
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+func (sr *FormRepositoryImpl) CountFormsAndSteps(ctx context.Context) (int64, int64, error) {
+	var counts struct {
+		Forms int64
+		Steps int64
+	}
+	err := conn(ctx, sr.db).Raw(
+		"SELECT (SELECT count(*) FROM public.form) AS forms, (SELECT count(*) FROM public.form_steps) AS steps",
+	).Scan(&counts).Error
+	if err != nil {
+		return 0, 0, TranslateError(err)
+	}
+	return counts.Forms, counts.Steps, nil
+}
+
+func (sr *FormRepositoryImpl) ExistsFormWithName(ctx context.Context, name string) (bool, error) {
+	var count int64
+	err := conn(ctx, sr.db).Model(&model.FormModel{}).Where("name = ?", name).Limit(1).Count(&count).Error
+	if err != nil {
+		return false, TranslateError(err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"salesforge-assignment/internal/model"
+	"time"
+)
+
+// ErrKeyTakenOver is returned when completing or releasing a key that a
+// later request took over since it was reserved.
+var ErrKeyTakenOver = errors.New("idempotency key was taken over")
+
+type IdempotencyRepository interface {
+	ReserveKey(ctx context.Context, record *model.IdempotencyKeyModel, abandonedBefore time.Time) (bool, error)
+	GetKey(ctx context.Context, userId string, key string) (*model.IdempotencyKeyModel, error)
+	CompleteKey(ctx context.Context, record *model.IdempotencyKeyModel) error
+	ReleaseKey(ctx context.Context, record *model.IdempotencyKeyModel) error
+	DeleteExpiredKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+type IdempotencyRepositoryImpl struct {
+	log *zerolog.Logger
+	db  *gorm.DB
+}
+
+func NewIdempotencyRepository(
+	log *zerolog.Logger,
+	db *gorm.DB,
+) IdempotencyRepository {
+	return &IdempotencyRepositoryImpl{
+		log: log,
+		db:  db,
+	}
+}
+
+// ReserveKey stores the key for an incoming request. It reports false if the
+// key is held by an earlier request that has not expired. Keys of requests
+// still in flight since before abandonedBefore are taken over, since those
+// requests will not complete.
+func (ir *IdempotencyRepositoryImpl) ReserveKey(
+	ctx context.Context,
+	record *model.IdempotencyKeyModel,
+	abandonedBefore time.Time,
+) (bool, error) {
+	result := conn(ctx, ir.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"request_hash", "status_code", "content_type", "response_body", "created_at", "expires_at",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{
+				SQL: "idempotency_keys.expires_at <= excluded.created_at OR " +
+					"(idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < ?)",
+				Vars: []interface{}{abandonedBefore},
+			},
+		}},
+	}).Create(record)
+	if result.Error != nil {
+		return false, TranslateError(result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (ir *IdempotencyRepositoryImpl) GetKey(ctx context.Context, userId string, key string) (*model.IdempotencyKeyModel, error) {
+	var record model.IdempotencyKeyModel
+	err := conn(ctx, ir.db).First(&record, "user_id = ? AND key = ?", userId, key).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return &record, nil
+}
+
+// CompleteKey stores the response of the request that reserved record. It
+// returns ErrKeyTakenOver if the key no longer holds that reservation.
+func (ir *IdempotencyRepositoryImpl) CompleteKey(ctx context.Context, record *model.IdempotencyKeyModel) error {
+	result := reservation(conn(ctx, ir.db), record).
+		Model(&model.IdempotencyKeyModel{}).
+		Updates(map[string]interface{}{
+			"status_code":   record.StatusCode,
+			"content_type":  record.ContentType,
+			"response_body": record.ResponseBody,
+		})
+	return keyResult(result)
+}
+
+// ReleaseKey deletes the key reserved by record so that the request can be
+// retried. It returns ErrKeyTakenOver if the key no longer holds that
+// reservation.
+func (ir *IdempotencyRepositoryImpl) ReleaseKey(ctx context.Context, record *model.IdempotencyKeyModel) error {
+	result := reservation(conn(ctx, ir.db), record).Delete(&model.IdempotencyKeyModel{})
+	return keyResult(result)
+}
+
+// reservation scopes db to the key while it holds the in-flight reservation
+// of record, rather than one that took the key over after it was abandoned.
+func reservation(db *gorm.DB, record *model.IdempotencyKeyModel) *gorm.DB {
+	return db.Where(
+		"user_id = ? AND key = ? AND created_at = ? AND request_hash = ? AND status_code IS NULL",
+		record.UserID, record.Key, record.CreatedAt, record.RequestHash,
+	)
+}
+
+func keyResult(result *gorm.DB) error {
+	if result.Error != nil {
+		return TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrKeyTakenOver
+	}
+	return nil
+}
+
+func (ir *IdempotencyRepositoryImpl) DeleteExpiredKeys(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, ir.db).Where("expires_at < ?", before).Delete(&model.IdempotencyKeyModel{})
+	return result.RowsAffected, TranslateError(result.Error)
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// ErrNoMigration is returned by GetVersion before the first migration.
+var ErrNoMigration = errors.New("no migration has been applied")
+
+type MigrationRepository interface {
+	// GetVersion returns the applied schema version and whether a migration
+	// failed halfway.
+	GetVersion(ctx context.Context) (version uint64, dirty bool, err error)
+	// EnsureVersionTable creates the table recording the schema version, in
+	// the format of the migrate tool so that both can be used.
+	EnsureVersionTable(ctx context.Context) error
+	// Migrate runs a migration script and records the resulting version in
+	// one transaction, 0 removing the record.
+	Migrate(ctx context.Context, script string, version uint64) error
+	// WithLock runs fn holding a lock shared by all instances of the
+	// application, so that replicas starting together migrate one at a time.
+	WithLock(ctx context.Context, fn func(ctx context.Context) error) error
+	// DescribeTable returns the columns and unique constraints of a table,
+	// nil when it does not exist.
+	DescribeTable(ctx context.Context, name string) (*TableSchema, error)
+}
+
+// TableSchema is the part of a table definition the models rely on.
+type TableSchema struct {
+	Name    string
+	Columns map[string]ColumnSchema
+	// Unique lists the columns of each unique constraint and index,
+	// including the primary key
+	Unique [][]string
+}
+
+type ColumnSchema struct {
+	NotNull bool
+}
+
+// migrationLockKey identifies the advisory lock held while migrating.
+const migrationLockKey int64 = 4817305296
+
+type MigrationRepositoryImpl struct {
+	log *zerolog.Logger
+	db  *gorm.DB
+}
+
+func NewMigrationRepository(
+	log *zerolog.Logger,
+	db *gorm.DB,
+) MigrationRepository {
+	return &MigrationRepositoryImpl{
+		log: log,
+		db:  db,
+	}
+}
+
+func (mr *MigrationRepositoryImpl) GetVersion(ctx context.Context) (uint64, bool, error) {
+	var row struct {
+		Version uint64
+		Dirty   bool
+	}
+	err := conn(ctx, mr.db).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row).Error
+	if err != nil {
+		return 0, false, err
+	}
+	if row.Version == 0 && !row.Dirty {
+		return 0, false, ErrNoMigration
+	}
+	return row.Version, row.Dirty, nil
+}
+
+func (mr *MigrationRepositoryImpl) EnsureVersionTable(ctx context.Context) error {
+	return conn(ctx, mr.db).Exec(
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)",
+	).Error
+}
+
+func (mr *MigrationRepositoryImpl) Migrate(ctx context.Context, script string, version uint64) error {
+	return conn(ctx, mr.db).Transaction(func(tx *gorm.DB) error {
+		// Without arguments, the script runs through the simple protocol,
+		// which accepts several statements
+		if err := tx.Exec(script).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+			return err
+		}
+		if version == 0 {
+			return nil
+		}
+		return tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", version).Error
+	})
+}
+
+func (mr *MigrationRepositoryImpl) WithLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Advisory locks belong to a session, so lock and unlock on the same
+	// connection. Repository calls made with the context passed to fn use it
+	// too, which keeps them from waiting for a second connection of the pool.
+	return mr.db.WithContext(ctx).Connection(func(session *gorm.DB) error {
+		// The connection returns to the pool afterwards, so restore it even
+		// when ctx is done
+		cleanup := session.WithContext(context.WithoutCancel(ctx))
+
+		// Waiting for the lock and migrating may take longer than the
+		// statement timeout of the pool
+		if err := session.Exec("SET statement_timeout = 0").Error; err != nil {
+			return err
+		}
+		defer func() {
+			if err := cleanup.Exec("RESET statement_timeout").Error; err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).Msg("Failed to restore the statement timeout")
+			}
+		}()
+
+		if err := session.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+		defer func() {
+			if err := cleanup.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey).Error; err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).Msg("Failed to release the migration lock")
+			}
+		}()
+		return fn(context.WithValue(ctx, txKey{}, &txState{db: session, afterCommit: new([]func())}))
+	})
+}
+
+func (mr *MigrationRepositoryImpl) DescribeTable(ctx context.Context, name string) (*TableSchema, error) {
+	schema, table, found := strings.Cut(name, ".")
+	if !found {
+		schema, table = "", name
+	}
+
+	var columns []struct {
+		Name    string
+		NotNull bool
+	}
+	err := conn(ctx, mr.db).Raw(`
+		SELECT column_name AS name, is_nullable = 'NO' AS not_null
+		FROM information_schema.columns
+		WHERE table_schema = COALESCE(NULLIF(?, ''), current_schema()) AND table_name = ?`,
+		schema, table,
+	).Scan(&columns).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	// Partial indexes do not make a column unique, so they are left out
+	var unique []string
+	err = conn(ctx, mr.db).Raw(`
+		SELECT string_agg(a.attname, ',' ORDER BY k.n)
+		FROM pg_index i
+		CROSS JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, n)
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+		WHERE i.indrelid = to_regclass(?) AND i.indisunique AND i.indpred IS NULL
+		GROUP BY i.indexrelid`,
+		name,
+	).Scan(&unique).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	described := &TableSchema{Name: name, Columns: make(map[string]ColumnSchema, len(columns))}
+	for _, column := range columns {
+		described.Columns[column.Name] = ColumnSchema{NotNull: column.NotNull}
+	}
+	for _, columns := range unique {
+		described.Unique = append(described.Unique, strings.Split(columns, ","))
+	}
+	return described, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"salesforge-assignment/internal/model"
+	"time"
+)
+
+// outboxRelayLockKey is the transaction level advisory lock taken by the
+// relay while it claims a batch, so that replicas never claim the same
+// events.
+const outboxRelayLockKey = 5_240_113_007
+
+// Events of a form queue behind an earlier event that is waiting for a retry.
+const findPendingEventsQuery = `
+SELECT o.* FROM public.outbox_events o
+WHERE o.published_at IS NULL AND o.next_attempt_at <= @now
+AND NOT EXISTS (
+    SELECT 1 FROM public.outbox_events p
+    WHERE p.form_id = o.form_id AND p.published_at IS NULL
+    AND p.sequence < o.sequence AND p.next_attempt_at > @now
+)
+ORDER BY o.sequence
+LIMIT @limit`
+
+type OutboxRepository interface {
+	CreateEvents(ctx context.Context, events []model.OutboxEventModel) error
+	TryLockRelay(ctx context.Context) (bool, error)
+	FindPendingEvents(ctx context.Context, limit int) ([]model.OutboxEventModel, error)
+	PostponeEvents(ctx context.Context, ids []string, until time.Time) error
+	MarkPublished(ctx context.Context, ids []string, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, event *model.OutboxEventModel) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type OutboxRepositoryImpl struct {
+	log *zerolog.Logger
+	db  *gorm.DB
+}
+
+func NewOutboxRepository(
+	log *zerolog.Logger,
+	db *gorm.DB,
+) OutboxRepository {
+	return &OutboxRepositoryImpl{
+		log: log,
+		db:  db,
+	}
+}
+
+func (ob *OutboxRepositoryImpl) CreateEvents(ctx context.Context, events []model.OutboxEventModel) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	err := conn(ctx, ob.db).Create(&events).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+// TryLockRelay takes the relay lock for the rest of the transaction carried
+// by the context and reports whether it was free.
+func (ob *OutboxRepositoryImpl) TryLockRelay(ctx context.Context) (bool, error) {
+	var locked bool
+	err := conn(ctx, ob.db).Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockKey).Scan(&locked).Error
+	if err != nil {
+		return false, TranslateError(err)
+	}
+	return locked, nil
+}
+
+func (ob *OutboxRepositoryImpl) FindPendingEvents(ctx context.Context, limit int) ([]model.OutboxEventModel, error) {
+	var events []model.OutboxEventModel
+	err := conn(ctx, ob.db).
+		Raw(findPendingEventsQuery, map[string]interface{}{
+			"now":   time.Now().UTC(),
+			"limit": limit,
+		}).
+		Scan(&events).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return events, nil
+}
+
+// PostponeEvents sets when the events are due again. The relay claims the
+// events it is about to publish by postponing them past the time publishing
+// may take, which also holds back the later events of their forms.
+func (ob *OutboxRepositoryImpl) PostponeEvents(ctx context.Context, ids []string, until time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := conn(ctx, ob.db).
+		Model(&model.OutboxEventModel{}).
+		Where("id IN ?", ids).
+		Update("next_attempt_at", until).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+func (ob *OutboxRepositoryImpl) MarkPublished(ctx context.Context, ids []string, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := conn(ctx, ob.db).
+		Model(&model.OutboxEventModel{}).
+		Where("id IN ?", ids).
+		Update("published_at", publishedAt).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+func (ob *OutboxRepositoryImpl) MarkFailed(ctx context.Context, event *model.OutboxEventModel) error {
+	err := conn(ctx, ob.db).
+		Model(&model.OutboxEventModel{ID: event.ID}).
+		Updates(map[string]interface{}{
+			"attempts":        event.Attempts,
+			"next_attempt_at": event.NextAttemptAt,
+			"last_error":      event.LastError,
+		}).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+func (ob *OutboxRepositoryImpl) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, ob.db).
+		Where("published_at < ?", before).
+		Delete(&model.OutboxEventModel{})
+	if result.Error != nil {
+		return 0, TranslateError(result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,440 @@
+package repository
+
+import (
+	"context"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/tracing"
+	"time"
+)
+
+// TracedFormRepository wraps a FormRepository in a span per method call.
+type TracedFormRepository struct {
+	FormRepository
+}
+
+func NewTracedFormRepository(repository FormRepository) FormRepository {
+	return &TracedFormRepository{FormRepository: repository}
+}
+
+func (tr *TracedFormRepository) CreateForm(
+	ctx context.Context,
+	form *model.FormModel,
+) (created *model.FormModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.CreateForm")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.CreateForm(ctx, form)
+}
+
+func (tr *TracedFormRepository) GetFormById(ctx context.Context, id string) (form *model.FormModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.GetFormById")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.GetFormById(ctx, id)
+}
+
+func (tr *TracedFormRepository) GetFormVersion(ctx context.Context, id string) (form *model.FormModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.GetFormVersion")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.GetFormVersion(ctx, id)
+}
+
+func (tr *TracedFormRepository) UpdateForm(
+	ctx context.Context,
+	form *model.FormModel,
+) (updated *model.FormModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.UpdateForm")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.UpdateForm(ctx, form)
+}
+
+func (tr *TracedFormRepository) UpdateFormStep(
+	ctx context.Context,
+	step *model.FormStepModel,
+) (updated *model.FormStepModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.UpdateFormStep")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.UpdateFormStep(ctx, step)
+}
+
+func (tr *TracedFormRepository) DeleteFormStepById(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.DeleteFormStepById")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.DeleteFormStepById(ctx, id)
+}
+
+func (tr *TracedFormRepository) GetFormStepById(
+	ctx context.Context,
+	formId string,
+) (step *model.FormStepModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.GetFormStepById")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.GetFormStepById(ctx, formId)
+}
+
+func (tr *TracedFormRepository) DeleteFormStep(ctx context.Context, step *model.FormStepModel) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.DeleteFormStep")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.DeleteFormStep(ctx, step)
+}
+
+func (tr *TracedFormRepository) CountFormsAndSteps(ctx context.Context) (forms int64, steps int64, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.CountFormsAndSteps")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.CountFormsAndSteps(ctx)
+}
+
+func (tr *TracedFormRepository) ExistsFormWithName(ctx context.Context, name string) (exists bool, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormRepository.ExistsFormWithName")
+	defer func() { tracing.End(span, err) }()
+	return tr.FormRepository.ExistsFormWithName(ctx, name)
+}
+
+// TracedCredentialsRepository wraps a CredentialsRepository in a span per method call.
+type TracedCredentialsRepository struct {
+	CredentialsRepository
+}
+
+func NewTracedCredentialsRepository(repository CredentialsRepository) CredentialsRepository {
+	return &TracedCredentialsRepository{CredentialsRepository: repository}
+}
+
+func (tr *TracedCredentialsRepository) GetCredentialsByUsername(
+	ctx context.Context,
+	username string,
+) (credentials *model.CredentialsModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "CredentialsRepository.GetCredentialsByUsername")
+	defer func() { tracing.End(span, err) }()
+	return tr.CredentialsRepository.GetCredentialsByUsername(ctx, username)
+}
+
+func (tr *TracedCredentialsRepository) CreateCredentials(
+	ctx context.Context,
+	credentials *model.CredentialsModel,
+) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "CredentialsRepository.CreateCredentials")
+	defer func() { tracing.End(span, err) }()
+	return tr.CredentialsRepository.CreateCredentials(ctx, credentials)
+}
+
+func (tr *TracedCredentialsRepository) UpdateCredentials(
+	ctx context.Context,
+	username string,
+	updates map[string]interface{},
+) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "CredentialsRepository.UpdateCredentials")
+	defer func() { tracing.End(span, err) }()
+	return tr.CredentialsRepository.UpdateCredentials(ctx, username, updates)
+}
+
+// TracedTrackingRepository wraps a TrackingRepository in a span per method call.
+type TracedTrackingRepository struct {
+	TrackingRepository
+}
+
+func NewTracedTrackingRepository(repository TrackingRepository) TrackingRepository {
+	return &TracedTrackingRepository{TrackingRepository: repository}
+}
+
+func (tr *TracedTrackingRepository) CreateEvent(ctx context.Context, event *model.TrackingEventModel) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "TrackingRepository.CreateEvent")
+	defer func() { tracing.End(span, err) }()
+	return tr.TrackingRepository.CreateEvent(ctx, event)
+}
+
+func (tr *TracedTrackingRepository) CreateEvents(ctx context.Context, events []model.TrackingEventModel) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "TrackingRepository.CreateEvents")
+	defer func() { tracing.End(span, err) }()
+	return tr.TrackingRepository.CreateEvents(ctx, events)
+}
+
+func (tr *TracedTrackingRepository) CreateEventUnlessSeen(
+	ctx context.Context,
+	event *model.TrackingEventModel,
+	since time.Time,
+) (created bool, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "TrackingRepository.CreateEventUnlessSeen")
+	defer func() { tracing.End(span, err) }()
+	return tr.TrackingRepository.CreateEventUnlessSeen(ctx, event, since)
+}
+
+// TracedAnalyticsRepository wraps a AnalyticsRepository in a span per method call.
+type TracedAnalyticsRepository struct {
+	AnalyticsRepository
+}
+
+func NewTracedAnalyticsRepository(repository AnalyticsRepository) AnalyticsRepository {
+	return &TracedAnalyticsRepository{AnalyticsRepository: repository}
+}
+
+func (tr *TracedAnalyticsRepository) RollupEvents(
+	ctx context.Context,
+	until time.Time,
+) (rolledUpTo time.Time, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "AnalyticsRepository.RollupEvents")
+	defer func() { tracing.End(span, err) }()
+	return tr.AnalyticsRepository.RollupEvents(ctx, until)
+}
+
+func (tr *TracedAnalyticsRepository) CountEvents(
+	ctx context.Context,
+	formId string,
+	bucket string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) (counts []model.EventCount, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "AnalyticsRepository.CountEvents")
+	defer func() { tracing.End(span, err) }()
+	return tr.AnalyticsRepository.CountEvents(ctx, formId, bucket, types, from, to)
+}
+
+func (tr *TracedAnalyticsRepository) CountRecipientsPerBucket(
+	ctx context.Context,
+	formId string,
+	bucket string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) (counts []model.EventCount, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "AnalyticsRepository.CountRecipientsPerBucket")
+	defer func() { tracing.End(span, err) }()
+	return tr.AnalyticsRepository.CountRecipientsPerBucket(ctx, formId, bucket, types, from, to)
+}
+
+func (tr *TracedAnalyticsRepository) CountRecipientsPerStep(
+	ctx context.Context,
+	formId string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) (counts []model.EventCount, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "AnalyticsRepository.CountRecipientsPerStep")
+	defer func() { tracing.End(span, err) }()
+	return tr.AnalyticsRepository.CountRecipientsPerStep(ctx, formId, types, from, to)
+}
+
+func (tr *TracedAnalyticsRepository) CountRecipients(
+	ctx context.Context,
+	formId string,
+	types []string,
+	from time.Time,
+	to time.Time,
+) (counts []model.EventCount, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "AnalyticsRepository.CountRecipients")
+	defer func() { tracing.End(span, err) }()
+	return tr.AnalyticsRepository.CountRecipients(ctx, formId, types, from, to)
+}
+
+// TracedWebhookRepository wraps a WebhookRepository in a span per method call.
+type TracedWebhookRepository struct {
+	WebhookRepository
+}
+
+func NewTracedWebhookRepository(repository WebhookRepository) WebhookRepository {
+	return &TracedWebhookRepository{WebhookRepository: repository}
+}
+
+func (tr *TracedWebhookRepository) CreateWebhook(
+	ctx context.Context,
+	webhook *model.WebhookModel,
+) (created *model.WebhookModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.CreateWebhook")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.CreateWebhook(ctx, webhook)
+}
+
+func (tr *TracedWebhookRepository) GetWebhookById(
+	ctx context.Context,
+	id string,
+) (webhook *model.WebhookModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.GetWebhookById")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.GetWebhookById(ctx, id)
+}
+
+func (tr *TracedWebhookRepository) ListWebhooks(ctx context.Context) (webhooks []model.WebhookModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.ListWebhooks")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.ListWebhooks(ctx)
+}
+
+func (tr *TracedWebhookRepository) DeleteWebhookById(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.DeleteWebhookById")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.DeleteWebhookById(ctx, id)
+}
+
+func (tr *TracedWebhookRepository) FindSubscribedWebhooks(
+	ctx context.Context,
+	formId string,
+	eventType string,
+) (webhooks []model.WebhookModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.FindSubscribedWebhooks")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.FindSubscribedWebhooks(ctx, formId, eventType)
+}
+
+func (tr *TracedWebhookRepository) CreateDeliveries(
+	ctx context.Context,
+	deliveries []model.WebhookDeliveryModel,
+) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.CreateDeliveries")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.CreateDeliveries(ctx, deliveries)
+}
+
+func (tr *TracedWebhookRepository) ListDeliveries(
+	ctx context.Context,
+	webhookId string,
+	offset int,
+	limit int,
+) (deliveries []model.WebhookDeliveryModel, total int64, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.ListDeliveries")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.ListDeliveries(ctx, webhookId, offset, limit)
+}
+
+func (tr *TracedWebhookRepository) GetDeliveryById(
+	ctx context.Context,
+	webhookId string,
+	deliveryId string,
+) (delivery *model.WebhookDeliveryModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.GetDeliveryById")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.GetDeliveryById(ctx, webhookId, deliveryId)
+}
+
+func (tr *TracedWebhookRepository) ScheduleRedelivery(
+	ctx context.Context,
+	delivery *model.WebhookDeliveryModel,
+) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.ScheduleRedelivery")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.ScheduleRedelivery(ctx, delivery)
+}
+
+func (tr *TracedWebhookRepository) ClaimDueDeliveries(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) (deliveries []model.WebhookDeliveryModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.ClaimDueDeliveries")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.ClaimDueDeliveries(ctx, limit, lease)
+}
+
+func (tr *TracedWebhookRepository) RecordAttempt(
+	ctx context.Context,
+	delivery *model.WebhookDeliveryModel,
+	attempt *model.WebhookDeliveryAttemptModel,
+) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WebhookRepository.RecordAttempt")
+	defer func() { tracing.End(span, err) }()
+	return tr.WebhookRepository.RecordAttempt(ctx, delivery, attempt)
+}
+
+// TracedOutboxRepository wraps a OutboxRepository in a span per method call.
+type TracedOutboxRepository struct {
+	OutboxRepository
+}
+
+func NewTracedOutboxRepository(repository OutboxRepository) OutboxRepository {
+	return &TracedOutboxRepository{OutboxRepository: repository}
+}
+
+func (tr *TracedOutboxRepository) CreateEvents(ctx context.Context, events []model.OutboxEventModel) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OutboxRepository.CreateEvents")
+	defer func() { tracing.End(span, err) }()
+	return tr.OutboxRepository.CreateEvents(ctx, events)
+}
+
+func (tr *TracedOutboxRepository) TryLockRelay(ctx context.Context) (locked bool, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OutboxRepository.TryLockRelay")
+	defer func() { tracing.End(span, err) }()
+	return tr.OutboxRepository.TryLockRelay(ctx)
+}
+
+func (tr *TracedOutboxRepository) FindPendingEvents(
+	ctx context.Context,
+	limit int,
+) (events []model.OutboxEventModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OutboxRepository.FindPendingEvents")
+	defer func() { tracing.End(span, err) }()
+	return tr.OutboxRepository.FindPendingEvents(ctx, limit)
+}
+
+func (tr *TracedOutboxRepository) PostponeEvents(ctx context.Context, ids []string, until time.Time) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OutboxRepository.PostponeEvents")
+	defer func() { tracing.End(span, err) }()
+	return tr.OutboxRepository.PostponeEvents(ctx, ids, until)
+}
+
+func (tr *TracedOutboxRepository) MarkPublished(ctx context.Context, ids []string, publishedAt time.Time) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OutboxRepository.MarkPublished")
+	defer func() { tracing.End(span, err) }()
+	return tr.OutboxRepository.MarkPublished(ctx, ids, publishedAt)
+}
+
+func (tr *TracedOutboxRepository) MarkFailed(ctx context.Context, event *model.OutboxEventModel) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OutboxRepository.MarkFailed")
+	defer func() { tracing.End(span, err) }()
+	return tr.OutboxRepository.MarkFailed(ctx, event)
+}
+
+func (tr *TracedOutboxRepository) DeletePublishedBefore(
+	ctx context.Context,
+	before time.Time,
+) (deleted int64, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OutboxRepository.DeletePublishedBefore")
+	defer func() { tracing.End(span, err) }()
+	return tr.OutboxRepository.DeletePublishedBefore(ctx, before)
+}
+
+// TracedIdempotencyRepository wraps a IdempotencyRepository in a span per method call.
+type TracedIdempotencyRepository struct {
+	IdempotencyRepository
+}
+
+func NewTracedIdempotencyRepository(repository IdempotencyRepository) IdempotencyRepository {
+	return &TracedIdempotencyRepository{IdempotencyRepository: repository}
+}
+
+func (tr *TracedIdempotencyRepository) ReserveKey(
+	ctx context.Context,
+	record *model.IdempotencyKeyModel,
+	abandonedBefore time.Time,
+) (reserved bool, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "IdempotencyRepository.ReserveKey")
+	defer func() { tracing.End(span, err) }()
+	return tr.IdempotencyRepository.ReserveKey(ctx, record, abandonedBefore)
+}
+
+func (tr *TracedIdempotencyRepository) GetKey(
+	ctx context.Context,
+	userId string,
+	key string,
+) (record *model.IdempotencyKeyModel, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "IdempotencyRepository.GetKey")
+	defer func() { tracing.End(span, err) }()
+	return tr.IdempotencyRepository.GetKey(ctx, userId, key)
+}
+
+func (tr *TracedIdempotencyRepository) CompleteKey(ctx context.Context, record *model.IdempotencyKeyModel) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "IdempotencyRepository.CompleteKey")
+	defer func() { tracing.End(span, err) }()
+	return tr.IdempotencyRepository.CompleteKey(ctx, record)
+}
+
+func (tr *TracedIdempotencyRepository) ReleaseKey(ctx context.Context, record *model.IdempotencyKeyModel) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "IdempotencyRepository.ReleaseKey")
+	defer func() { tracing.End(span, err) }()
+	return tr.IdempotencyRepository.ReleaseKey(ctx, record)
+}
+
+func (tr *TracedIdempotencyRepository) DeleteExpiredKeys(
+	ctx context.Context,
+	before time.Time,
+) (deleted int64, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "IdempotencyRepository.DeleteExpiredKeys")
+	defer func() { tracing.End(span, err) }()
+	return tr.IdempotencyRepository.DeleteExpiredKeys(ctx, before)
+}
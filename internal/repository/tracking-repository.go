@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"salesforge-assignment/internal/model"
+	"time"
+)
+
+type TrackingRepository interface {
+	CreateEvent(ctx context.Context, event *model.TrackingEventModel) error
+	CreateEvents(ctx context.Context, events []model.TrackingEventModel) error
+	CreateEventUnlessSeen(ctx context.Context, event *model.TrackingEventModel, since time.Time) (bool, error)
+}
+
+type TrackingRepositoryImpl struct {
+	log *zerolog.Logger
+	db  *gorm.DB
+}
+
+func NewTrackingRepository(
+	log *zerolog.Logger,
+	db *gorm.DB,
+) TrackingRepository {
+	return &TrackingRepositoryImpl{
+		log: log,
+		db:  db,
+	}
+}
+
+func (tr *TrackingRepositoryImpl) CreateEvent(ctx context.Context, event *model.TrackingEventModel) error {
+	err := conn(ctx, tr.db).Create(event).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+// CreateEvents stores a batch of events. Events may be reported late, so the
+// rollup watermark is moved back to the earliest affected hour to have the
+// aggregator recompute it.
+func (tr *TrackingRepositoryImpl) CreateEvents(ctx context.Context, events []model.TrackingEventModel) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	earliest := events[0].OccurredAt
+	for _, event := range events[1:] {
+		if event.OccurredAt.Before(earliest) {
+			earliest = event.OccurredAt
+		}
+	}
+
+	err := conn(ctx, tr.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&events).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.TrackingEventRollupStateModel{}).
+			Where("id = 1 AND rolled_up_until > ?", earliest.UTC().Truncate(time.Hour)).
+			Update("rolled_up_until", earliest.UTC().Truncate(time.Hour)).
+			Error
+	})
+	return TranslateError(err)
+}
+
+// CreateEventUnlessSeen stores the event unless the same recipient already
+// produced an event of the same type for the same form step after the given
+// point in time, and reports whether it was stored. A transaction-level
+// advisory lock on the recipient's events serializes concurrent calls, so two
+// simultaneous hits cannot both pass the check.
+func (tr *TrackingRepositoryImpl) CreateEventUnlessSeen(ctx context.Context, event *model.TrackingEventModel, since time.Time) (bool, error) {
+	created := false
+	err := conn(ctx, tr.db).Transaction(func(tx *gorm.DB) error {
+		key := event.FormID + "/" + event.StepID + "/" + event.Recipient + "/" + string(event.EventType)
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		err := tx.Model(&model.TrackingEventModel{}).
+			Where("form_id = ? AND step_id = ? AND recipient = ? AND event_type = ? AND occurred_at >= ?",
+				event.FormID, event.StepID, event.Recipient, event.EventType, since).
+			Limit(1).
+			Count(&count).Error
+		if err != nil || count > 0 {
+			return err
+		}
+
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, TranslateError(err)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/retry"
+	"time"
+)
+
+type txKey struct{}
+
+type txState struct {
+	db          *gorm.DB
+	afterCommit *[]func()
+}
+
+// TxManager runs a unit of work in a single database transaction. Repository
+// calls made with the context passed to the unit of work join the
+// transaction.
+type TxManager interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type TxManagerImpl struct {
+	log *zerolog.Logger
+	db  *gorm.DB
+}
+
+func NewTxManager(
+	log *zerolog.Logger,
+	db *gorm.DB,
+) TxManager {
+	return &TxManagerImpl{
+		log: log,
+		db:  db,
+	}
+}
+
+func (tm *TxManagerImpl) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested units of work run in a savepoint and share the hooks of the
+	// outermost transaction
+	outer, nested := ctx.Value(txKey{}).(*txState)
+	hooks := new([]func())
+	if nested {
+		hooks = outer.afterCommit
+	}
+
+	err := conn(ctx, tm.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, &txState{db: tx, afterCommit: hooks}))
+	})
+	if err == nil && !nested {
+		for _, hook := range *hooks {
+			hook()
+		}
+	}
+	return err
+}
+
+// AfterCommit runs fn once the transaction carried by the context has
+// committed, or right away if there is none. It is not run on rollback.
+func AfterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		*state.afterCommit = append(*state.afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+// conn returns the transaction carried by the context, or the connection
+// pool if there is none.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.db.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*txState)
+	return ok
+}
+
+const (
+	conflictInitialBackoff = 10 * time.Millisecond
+	conflictMaxBackoff     = 200 * time.Millisecond
+)
+
+// RetryingTxManager reruns units of work that lost a serialization conflict
+// or a deadlock to a concurrent transaction, which Postgres expects clients
+// to retry. Units of work nested in a transaction are not retried: the outer
+// transaction is aborted as well, and is retried as a whole if it can be.
+type RetryingTxManager struct {
+	log      *zerolog.Logger
+	inner    TxManager
+	attempts int
+}
+
+// NewRetryingTxManager runs units of work with inner up to attempts times.
+func NewRetryingTxManager(
+	log *zerolog.Logger,
+	inner TxManager,
+	attempts int,
+) TxManager {
+	return &RetryingTxManager{
+		log:      log,
+		inner:    inner,
+		attempts: attempts,
+	}
+}
+
+func (tm *RetryingTxManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := tm.inner.RunInTransaction(ctx, fn)
+		var conflict *apierrors.ConcurrentUpdateError
+		if !errors.As(TranslateError(err), &conflict) {
+			return err
+		}
+		// Conflicts failing the commit have not been classified yet
+		if attempt >= tm.attempts || inTransaction(ctx) {
+			return conflict
+		}
+
+		delay := retry.Backoff(attempt, conflictInitialBackoff, conflictMaxBackoff)
+		zerolog.Ctx(ctx).Debug().Err(err).Int("attempt", attempt).Dur("retryIn", delay).Msg("Retrying transaction after a conflict")
+		select {
+		case <-ctx.Done():
+			return conflict
+		case <-time.After(delay):
+		}
+	}
+}
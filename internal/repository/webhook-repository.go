@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"salesforge-assignment/internal/model"
+	"time"
+)
+
+// Due deliveries are leased by pushing their next attempt into the future,
+// so a dispatcher that dies mid-delivery only delays them until the lease
+// runs out, and dispatchers on other replicas skip them meanwhile.
+const claimDueDeliveriesQuery = `
+UPDATE public.webhook_deliveries SET next_attempt_at = @leaseUntil
+WHERE id IN (
+    SELECT id FROM public.webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= @now
+    ORDER BY next_attempt_at
+    LIMIT @limit
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id`
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *model.WebhookModel) (*model.WebhookModel, error)
+	GetWebhookById(ctx context.Context, id string) (*model.WebhookModel, error)
+	ListWebhooks(ctx context.Context) ([]model.WebhookModel, error)
+	DeleteWebhookById(ctx context.Context, id string) error
+	FindSubscribedWebhooks(ctx context.Context, formId string, eventType string) ([]model.WebhookModel, error)
+	CreateDeliveries(ctx context.Context, deliveries []model.WebhookDeliveryModel) error
+	ListDeliveries(ctx context.Context, webhookId string, offset int, limit int) ([]model.WebhookDeliveryModel, int64, error)
+	GetDeliveryById(ctx context.Context, webhookId string, deliveryId string) (*model.WebhookDeliveryModel, error)
+	ScheduleRedelivery(ctx context.Context, delivery *model.WebhookDeliveryModel) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDeliveryModel, error)
+	RecordAttempt(ctx context.Context, delivery *model.WebhookDeliveryModel, attempt *model.WebhookDeliveryAttemptModel) error
+}
+
+type WebhookRepositoryImpl struct {
+	log *zerolog.Logger
+	db  *gorm.DB
+}
+
+func NewWebhookRepository(
+	log *zerolog.Logger,
+	db *gorm.DB,
+) WebhookRepository {
+	return &WebhookRepositoryImpl{
+		log: log,
+		db:  db,
+	}
+}
+
+func (wr *WebhookRepositoryImpl) CreateWebhook(ctx context.Context, webhook *model.WebhookModel) (*model.WebhookModel, error) {
+	err := conn(ctx, wr.db).Create(webhook).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return webhook, nil
+}
+
+func (wr *WebhookRepositoryImpl) GetWebhookById(ctx context.Context, id string) (*model.WebhookModel, error) {
+	var webhook *model.WebhookModel
+	err := conn(ctx, wr.db).First(&webhook, "id = ?", id).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return webhook, nil
+}
+
+func (wr *WebhookRepositoryImpl) ListWebhooks(ctx context.Context) ([]model.WebhookModel, error) {
+	var webhooks []model.WebhookModel
+	err := conn(ctx, wr.db).Order("created_at ASC").Find(&webhooks).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return webhooks, nil
+}
+
+func (wr *WebhookRepositoryImpl) DeleteWebhookById(ctx context.Context, id string) error {
+	err := conn(ctx, wr.db).Where("id = ?", id).Delete(&model.WebhookModel{}).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+// FindSubscribedWebhooks returns the webhooks of the form and the workspace
+// wide webhooks that subscribed to the event type.
+func (wr *WebhookRepositoryImpl) FindSubscribedWebhooks(ctx context.Context, formId string, eventType string) ([]model.WebhookModel, error) {
+	eventTypes, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	var webhooks []model.WebhookModel
+	err = conn(ctx, wr.db).
+		Where("(form_id = ? OR form_id IS NULL) AND event_types @> ?::jsonb", formId, string(eventTypes)).
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return webhooks, nil
+}
+
+// CreateDeliveries skips deliveries of events already queued for the webhook.
+func (wr *WebhookRepositoryImpl) CreateDeliveries(ctx context.Context, deliveries []model.WebhookDeliveryModel) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err := conn(ctx, wr.db).
+		Omit("Webhook", "AttemptLog").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+func (wr *WebhookRepositoryImpl) ListDeliveries(
+	ctx context.Context,
+	webhookId string,
+	offset int,
+	limit int,
+) ([]model.WebhookDeliveryModel, int64, error) {
+	var total int64
+	err := conn(ctx, wr.db).
+		Model(&model.WebhookDeliveryModel{}).
+		Where("webhook_id = ?", webhookId).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, TranslateError(err)
+	}
+
+	var deliveries []model.WebhookDeliveryModel
+	err = conn(ctx, wr.db).
+		Where("webhook_id = ?", webhookId).
+		Order("created_at DESC, id").
+		Offset(offset).
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, TranslateError(err)
+	}
+	return deliveries, total, nil
+}
+
+func (wr *WebhookRepositoryImpl) GetDeliveryById(ctx context.Context, webhookId string, deliveryId string) (*model.WebhookDeliveryModel, error) {
+	var delivery *model.WebhookDeliveryModel
+	err := conn(ctx, wr.db).
+		Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+			return db.Order("attempted_at ASC")
+		}).
+		First(&delivery, "id = ? AND webhook_id = ?", deliveryId, webhookId).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return delivery, nil
+}
+
+// ScheduleRedelivery starts a new delivery cycle with a fresh retry budget.
+func (wr *WebhookRepositoryImpl) ScheduleRedelivery(ctx context.Context, delivery *model.WebhookDeliveryModel) error {
+	delivery.Status = model.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now().UTC()
+
+	err := conn(ctx, wr.db).
+		Model(&model.WebhookDeliveryModel{ID: delivery.ID}).
+		Updates(deliverySchedule(delivery)).Error
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+func (wr *WebhookRepositoryImpl) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDeliveryModel, error) {
+	now := time.Now().UTC()
+
+	var ids []string
+	err := conn(ctx, wr.db).
+		Raw(claimDueDeliveriesQuery, map[string]interface{}{
+			"leaseUntil": now.Add(lease),
+			"now":        now,
+			"limit":      limit,
+		}).
+		Scan(&ids).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var deliveries []model.WebhookDeliveryModel
+	err = conn(ctx, wr.db).
+		Preload("Webhook").
+		Where("id IN ?", ids).
+		Order("next_attempt_at").
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return deliveries, nil
+}
+
+// RecordAttempt appends the attempt to the delivery log and stores the
+// delivery's resulting status and schedule.
+func (wr *WebhookRepositoryImpl) RecordAttempt(
+	ctx context.Context,
+	delivery *model.WebhookDeliveryModel,
+	attempt *model.WebhookDeliveryAttemptModel,
+) error {
+	err := conn(ctx, wr.db).Transaction(func(tx *gorm.DB) error {
+		attempt.DeliveryID = delivery.ID
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.WebhookDeliveryModel{ID: delivery.ID}).
+			Updates(deliverySchedule(delivery)).Error
+	})
+	return TranslateError(err)
+}
+
+func deliverySchedule(delivery *model.WebhookDeliveryModel) map[string]interface{} {
+	return map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"next_attempt_at": delivery.NextAttemptAt,
+	}
+}
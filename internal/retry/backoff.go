@@ -0,0 +1,22 @@
+package retry
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff returns the delay before the given retry: the initial delay
+// doubled for every previous failure, capped at max, with up to 20% jitter
+// so that peers recovering from an outage are not hit all at once.
+func Backoff(attempt int, initial time.Duration, max time.Duration) time.Duration {
+	delay := initial
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(delay)/5 + 1))
+	return delay - jitter
+}
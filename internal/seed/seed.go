@@ -0,0 +1,161 @@
+// Package seed loads development data from a YAML fixture.
+package seed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+	"os"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/service"
+	"strings"
+)
+
+type Fixture struct {
+	Users []User `yaml:"users"`
+	Forms []Form `yaml:"forms"`
+}
+
+// User is created with the password hashed like any other. Fixtures that
+// are shared name the environment variable holding the password, which may
+// also be read from a file named by its _FILE variant, rather than holding
+// it in plain text.
+type User struct {
+	Username    string `yaml:"username"`
+	Password    string `yaml:"password"`
+	PasswordEnv string `yaml:"passwordEnv"`
+}
+
+// fileSuffix marks environment variables naming a file to read a password
+// from, like the settings of the configuration
+const fileSuffix = "_FILE"
+
+type Form struct {
+	Name                 string `yaml:"name"`
+	OpenTrackingEnabled  bool   `yaml:"openTrackingEnabled"`
+	ClickTrackingEnabled bool   `yaml:"clickTrackingEnabled"`
+	Steps                []Step `yaml:"steps"`
+}
+
+type Step struct {
+	Name    string `yaml:"name"`
+	Content string `yaml:"content"`
+	Step    int    `yaml:"step"`
+}
+
+// LoadFixture reads a fixture, rejecting unknown keys so that typos do not
+// go unnoticed, and resolves the passwords of users through lookupEnv.
+func LoadFixture(path string, lookupEnv func(string) (string, bool)) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture Fixture
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&fixture); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i := range fixture.Users {
+		if err := fixture.Users[i].resolvePassword(lookupEnv); err != nil {
+			return nil, fmt.Errorf("%s: user %s: %w", path, fixture.Users[i].Username, err)
+		}
+	}
+	return &fixture, nil
+}
+
+func (u *User) resolvePassword(lookupEnv func(string) (string, bool)) error {
+	if u.PasswordEnv == "" {
+		if u.Password == "" {
+			return errors.New("neither password nor passwordEnv is set")
+		}
+		return nil
+	}
+	if u.Password != "" {
+		return errors.New("only one of password and passwordEnv may be set")
+	}
+
+	if file, ok := lookupEnv(u.PasswordEnv + fileSuffix); ok {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", u.PasswordEnv+fileSuffix, err)
+		}
+		u.Password = strings.TrimRight(string(data), "\r\n")
+	} else {
+		u.Password, _ = lookupEnv(u.PasswordEnv)
+	}
+	if u.Password == "" {
+		return fmt.Errorf("%s is not set", u.PasswordEnv)
+	}
+	return nil
+}
+
+type Seeder struct {
+	log            *zerolog.Logger
+	userService    service.UserService
+	formService    service.FormService
+	formRepository repository.FormRepository
+}
+
+func NewSeeder(
+	log *zerolog.Logger,
+	userService service.UserService,
+	formService service.FormService,
+	formRepository repository.FormRepository,
+) *Seeder {
+	return &Seeder{
+		log:            log,
+		userService:    userService,
+		formService:    formService,
+		formRepository: formRepository,
+	}
+}
+
+// Seed creates the users and forms of the fixture that do not exist yet,
+// by username and form name, so that it can run on every start.
+func (s *Seeder) Seed(ctx context.Context, fixture *Fixture) error {
+	for _, user := range fixture.Users {
+		err := s.userService.CreateUser(ctx, user.Username, user.Password)
+		var conflict *apierrors.ConflictError
+		if errors.As(err, &conflict) {
+			s.log.Info().EmbedObject(logger.Fields{"username": user.Username}).Msg("User exists, skipping")
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("user %s: %w", user.Username, err)
+		}
+	}
+
+	for _, form := range fixture.Forms {
+		exists, err := s.formRepository.ExistsFormWithName(ctx, form.Name)
+		if err != nil {
+			return fmt.Errorf("form %s: %w", form.Name, err)
+		}
+		if exists {
+			s.log.Info().Str("form", form.Name).Msg("Form exists, skipping")
+			continue
+		}
+
+		req := api.FormCreate{
+			Name:                 form.Name,
+			OpenTrackingEnabled:  &form.OpenTrackingEnabled,
+			ClickTrackingEnabled: &form.ClickTrackingEnabled,
+			Steps:                make(api.FormStepCreateArray, len(form.Steps)),
+		}
+		for i, step := range form.Steps {
+			req.Steps[i] = api.FormStepCreate{Name: step.Name, Content: step.Content, Step: step.Step}
+		}
+		if _, err := s.formService.CreateForm(ctx, req); err != nil {
+			return fmt.Errorf("form %s: %w", form.Name, err)
+		}
+		s.log.Info().Str("form", form.Name).Msg("Form created")
+	}
+	return nil
+}
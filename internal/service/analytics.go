@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"salesforge-assignment/internal/analytics"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"time"
+)
+
+const (
+	defaultAnalyticsRange = 7 * 24 * time.Hour
+	maxAnalyticsBuckets   = 2000
+)
+
+var (
+	engagementEventTypes = []string{model.TrackingEventOpen, model.TrackingEventClick}
+	funnelEventTypes     = []string{model.TrackingEventView, model.TrackingEventStepCompleted}
+)
+
+type AnalyticsService interface {
+	GetFormAnalytics(ctx context.Context, formId string, params api.GetFormAnalyticsParams) (*api.FormAnalytics, error)
+}
+
+type AnalyticsServiceImpl struct {
+	formRepository      repository.FormRepository
+	analyticsRepository repository.AnalyticsRepository
+	config              *config.Config
+}
+
+func NewAnalyticsService(
+	formRepository repository.FormRepository,
+	analyticsRepository repository.AnalyticsRepository,
+	config *config.Config,
+) AnalyticsService {
+	return &AnalyticsServiceImpl{
+		formRepository:      formRepository,
+		analyticsRepository: analyticsRepository,
+		config:              config,
+	}
+}
+
+func (s *AnalyticsServiceImpl) GetFormAnalytics(
+	ctx context.Context,
+	formId string,
+	params api.GetFormAnalyticsParams,
+) (*api.FormAnalytics, error) {
+	bucket, from, to, err := analyticsRange(params)
+	if err != nil {
+		zerolog.Ctx(ctx).Debug().Err(err).Str("formId", formId).Msg("Invalid analytics range")
+		return nil, &apierrors.InvalidInputError{Err: err}
+	}
+
+	form, err := s.formRepository.GetFormById(ctx, formId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			zerolog.Ctx(ctx).Debug().Str("formId", formId).Msg("Form not found")
+			return nil, &apierrors.ResourceNotFoundError{}
+		}
+		zerolog.Ctx(ctx).Error().Err(err).Str("formId", formId).Msg("Failed to retrieve form")
+		return nil, &apierrors.InvalidApplicationStateError{}
+	}
+
+	events, err := s.analyticsRepository.CountEvents(ctx, formId, bucket, engagementEventTypes, from, to)
+	if err != nil {
+		return nil, s.queryFailed(ctx, err, formId)
+	}
+	bucketRecipients, err := s.analyticsRepository.CountRecipientsPerBucket(ctx, formId, bucket, engagementEventTypes, from, to)
+	if err != nil {
+		return nil, s.queryFailed(ctx, err, formId)
+	}
+	recipients, err := s.analyticsRepository.CountRecipients(ctx, formId, engagementEventTypes, from, to)
+	if err != nil {
+		return nil, s.queryFailed(ctx, err, formId)
+	}
+	stepRecipients, err := s.analyticsRepository.CountRecipientsPerStep(ctx, formId, funnelEventTypes, from, to)
+	if err != nil {
+		return nil, s.queryFailed(ctx, err, formId)
+	}
+
+	response := &api.FormAnalytics{
+		Self: api.SelfId{
+			Id:   form.ID,
+			Href: model.GetFormHref(form.ID, s.config.Server.PublicUrl, s.config.Server.BaseURL),
+		},
+		From:   from,
+		To:     to,
+		Bucket: bucket,
+		Series: buildSeries(from, to, bucket, events, bucketRecipients),
+		Funnel: buildFunnel(form, stepRecipients, s.config.Server.PublicUrl, s.config.Server.BaseURL),
+	}
+
+	for _, bucket := range response.Series {
+		response.Totals.Opens += bucket.Opens
+		response.Totals.Clicks += bucket.Clicks
+	}
+	for _, count := range recipients {
+		switch count.EventType {
+		case model.TrackingEventOpen:
+			response.Totals.UniqueOpens = count.Count
+		case model.TrackingEventClick:
+			response.Totals.UniqueClicks = count.Count
+		}
+	}
+	response.Totals.ClickThroughRate = analytics.Ratio(response.Totals.UniqueClicks, response.Totals.UniqueOpens)
+
+	zerolog.Ctx(ctx).Debug().Str("formId", formId).Msg("Form analytics retrieved successfully")
+	return response, nil
+}
+
+func (s *AnalyticsServiceImpl) queryFailed(ctx context.Context, err error, formId string) error {
+	zerolog.Ctx(ctx).Error().Err(err).Str("formId", formId).Msg("Failed to query form analytics")
+	return &apierrors.InvalidApplicationStateError{}
+}
+
+// analyticsRange applies the defaults and aligns the requested range to
+// whole buckets.
+func analyticsRange(params api.GetFormAnalyticsParams) (string, time.Time, time.Time, error) {
+	bucket := string(api.Day)
+	if params.Bucket != nil {
+		bucket = string(*params.Bucket)
+	}
+
+	to := time.Now()
+	if params.To != nil {
+		to = *params.To
+	}
+	from := to.Add(-defaultAnalyticsRange)
+	if params.From != nil {
+		from = *params.From
+	}
+	if !from.Before(to) {
+		return "", time.Time{}, time.Time{}, errors.New("from must be before to")
+	}
+
+	alignedFrom, err := analytics.Truncate(from, bucket)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, err
+	}
+	alignedTo, _ := analytics.Truncate(to, bucket)
+	if alignedTo.Before(to) {
+		alignedTo = analytics.Next(alignedTo, bucket)
+	}
+
+	if analytics.Count(alignedFrom, alignedTo, bucket) > maxAnalyticsBuckets {
+		return "", time.Time{}, time.Time{}, errors.New("range spans too many buckets")
+	}
+
+	return bucket, alignedFrom, alignedTo, nil
+}
+
+func buildSeries(
+	from time.Time,
+	to time.Time,
+	bucket string,
+	events []model.EventCount,
+	recipients []model.EventCount,
+) []api.EngagementBucket {
+	buckets := analytics.Buckets(from, to, bucket)
+	series := make([]api.EngagementBucket, len(buckets))
+	index := make(map[time.Time]*api.EngagementBucket, len(buckets))
+	for i, start := range buckets {
+		series[i] = api.EngagementBucket{BucketStart: start}
+		index[start] = &series[i]
+	}
+
+	for _, count := range events {
+		if b, ok := index[count.Bucket.UTC()]; ok {
+			switch count.EventType {
+			case model.TrackingEventOpen:
+				b.Opens = count.Count
+			case model.TrackingEventClick:
+				b.Clicks = count.Count
+			}
+		}
+	}
+	for _, count := range recipients {
+		if b, ok := index[count.Bucket.UTC()]; ok {
+			switch count.EventType {
+			case model.TrackingEventOpen:
+				b.UniqueOpens = count.Count
+			case model.TrackingEventClick:
+				b.UniqueClicks = count.Count
+			}
+		}
+	}
+	for i := range series {
+		series[i].ClickThroughRate = analytics.Ratio(series[i].UniqueClicks, series[i].UniqueOpens)
+	}
+
+	return series
+}
+
+func buildFunnel(form *model.FormModel, counts []model.EventCount, publicUrl string, baseUrl string) []api.FunnelStep {
+	viewed := make(map[string]int64)
+	completed := make(map[string]int64)
+	for _, count := range counts {
+		switch count.EventType {
+		case model.TrackingEventView:
+			viewed[count.StepID] = count.Count
+		case model.TrackingEventStepCompleted:
+			completed[count.StepID] = count.Count
+		}
+	}
+
+	funnel := make([]api.FunnelStep, 0, len(form.Steps))
+	for _, step := range form.Steps {
+		dropOff := max(viewed[step.ID]-completed[step.ID], 0)
+		funnel = append(funnel, api.FunnelStep{
+			Self: api.SelfId{
+				Id:   step.ID,
+				Href: model.GetFormStepHref(form.ID, step.ID, publicUrl, baseUrl),
+			},
+			Name:        step.Name,
+			Step:        step.StepOrder,
+			Viewed:      viewed[step.ID],
+			Completed:   completed[step.ID],
+			DropOff:     dropOff,
+			DropOffRate: analytics.Ratio(dropOff, viewed[step.ID]),
+		})
+	}
+
+	return funnel
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/logger"
+)
+
+// toAPIError passes on the API error a repository classified err as, such as
+// a conflict on a unique field. Any other error is logged with the failure
+// message and the given key/value fields, redacted like logger.Fields, and reported as an internal error.
+func toAPIError(ctx context.Context, err error, failure string, fields ...interface{}) error {
+	log := zerolog.Ctx(ctx)
+	redacted := logger.Fields{}
+	for i := 0; i+1 < len(fields); i += 2 {
+		if key, ok := fields[i].(string); ok {
+			redacted[key] = fields[i+1]
+		}
+	}
+	var httpErr apierrors.HTTPError
+	if errors.As(err, &httpErr) {
+		log.Debug().Err(err).EmbedObject(redacted).Msg("Request rejected by the database")
+		return httpErr
+	}
+	log.Error().Err(err).EmbedObject(redacted).Msg(failure)
+	return &apierrors.InvalidApplicationStateError{}
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"encoding/json"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/model"
+	"time"
+)
+
+// newOutboxEvent builds a domain event to be stored in the outbox within
+// the transaction making the change it describes.
+func newOutboxEvent(formId string, eventType api.WebhookEventType, data interface{}) (model.OutboxEventModel, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return model.OutboxEventModel{}, err
+	}
+
+	now := time.Now().UTC()
+	return model.OutboxEventModel{
+		FormID:        formId,
+		EventType:     string(eventType),
+		Payload:       string(payload),
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	apierrors "salesforge-assignment/internal/api-errors"
+)
+
+// hashPassword hashes a password to store with the credentials of a user,
+// in the form checkPassword verifies on login.
+func hashPassword(password string) (string, error) {
+	if password == "" {
+		return "", &apierrors.InvalidInputError{Field: "password", Err: errors.New("password is empty")}
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", &apierrors.InvalidInputError{Field: "password", Err: err}
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func checkPassword(hash string, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
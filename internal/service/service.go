@@ -4,32 +4,38 @@ import (
 	"context"
 	"errors"
 	"github.com/rs/zerolog"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
-	"os"
 	"salesforge-assignment/internal/api"
 	apierrors "salesforge-assignment/internal/api-errors"
 	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/etag"
+	"salesforge-assignment/internal/logger"
 	"salesforge-assignment/internal/middleware/auth"
 	"salesforge-assignment/internal/model"
 	"salesforge-assignment/internal/repository"
+	"time"
 )
 
+// transactionAttempts is how many times a form operation is run before a
+// conflict with concurrent transactions is reported to the client.
+const transactionAttempts = 3
+
 type FormService interface {
 	LoginUser(ctx context.Context, req api.Authentication) (string, error)
 	CreateForm(ctx context.Context, req api.FormCreate) (*api.SelfId, error)
 	GetFormById(ctx context.Context, id string) (*api.FormResponseGet, error)
-	UpdateFormById(ctx context.Context, id string, update api.FormUpdate) (*api.FormResponseGet, error)
-	UpdateFormStepById(ctx context.Context, formId string, stepId string, req api.FormStepUpdate) (*api.FormStepResponseGet, error)
+	GetFormVersion(ctx context.Context, id string) (version int, updatedAt time.Time, err error)
+	UpdateFormById(ctx context.Context, id string, update api.FormUpdate, ifMatch string) (*api.FormResponseGet, error)
+	UpdateFormStepById(ctx context.Context, formId string, stepId string, req api.FormStepUpdate, ifMatch string) (*api.FormStepResponseGet, error)
 	GetFormStepById(ctx context.Context, formId string, stepId string) (*api.FormStepResponseGet, error)
-	DeleteFormStepById(ctx context.Context, formId string, stepId string) error
+	DeleteFormStepById(ctx context.Context, formId string, stepId string, ifMatch string) error
 }
 
 type FormServiceImpl struct {
-	log                   *zerolog.Logger
 	credentialsRepository repository.CredentialsRepository
 	formRepository        repository.FormRepository
+	outboxRepository      repository.OutboxRepository
+	txManager             repository.TxManager
 	config                *config.Config
 	jwtKey                []byte
 }
@@ -38,38 +44,45 @@ func NewFormService(
 	log *zerolog.Logger,
 	credentialsRepository repository.CredentialsRepository,
 	formRepository repository.FormRepository,
+	outboxRepository repository.OutboxRepository,
+	txManager repository.TxManager,
 	config *config.Config,
 ) FormService {
-	jwtSecret := os.Getenv("JWT_SECRET_KEY")
+	jwtSecret := config.Auth.JWTSecret
 
 	if jwtSecret == "" {
-		log.Fatal().Msg("Environment variable JWT_SECRET_KEY is not set")
+		log.Fatal().Msg("auth.jwtSecret is not configured")
 	}
 
 	return &FormServiceImpl{
-		log:                   log,
 		credentialsRepository: credentialsRepository,
 		formRepository:        formRepository,
+		outboxRepository:      outboxRepository,
+		txManager:             repository.NewRetryingTxManager(log, txManager, transactionAttempts),
 		config:                config,
 		jwtKey:                []byte(jwtSecret),
 	}
 }
 
 func (s *FormServiceImpl) LoginUser(ctx context.Context, req api.Authentication) (string, error) {
-	log := s.log.With().Str("username", req.Username).Logger()
+	log := zerolog.Ctx(ctx).With().EmbedObject(logger.Fields{"username": req.Username}).Logger()
 
 	user, err := s.credentialsRepository.GetCredentialsByUsername(ctx, req.Username)
 	if err != nil {
 		return "", err
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	err = checkPassword(user.Password, req.Password)
 	if err != nil {
 		log.Debug().Msg("Password mismatch attempt")
 		return "", &apierrors.InvalidCredentialsError{}
 	}
+	if user.Disabled {
+		log.Debug().Msg("Login attempt of a disabled user")
+		return "", &apierrors.InvalidCredentialsError{}
+	}
 
-	token, err := auth.GenerateToken(user.ID, user.Username, s.jwtKey)
+	token, err := auth.GenerateToken(user.ID, user.Username, user.Roles, s.jwtKey)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to generate JWT token")
 		return "", &apierrors.InvalidApplicationStateError{}
@@ -96,13 +109,21 @@ func (s *FormServiceImpl) CreateForm(ctx context.Context, req api.FormCreate) (*
 		}
 	}
 
-	createdForm, err := s.formRepository.CreateForm(ctx, newForm)
+	var createdForm *model.FormModel
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdForm, err = s.formRepository.CreateForm(ctx, newForm)
+		if err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, createdForm.ID, api.FormCreated,
+			createdForm.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL))
+	})
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to create form")
-		return nil, &apierrors.InvalidApplicationStateError{}
+		return nil, toAPIError(ctx, err, "Failed to create form")
 	}
 
-	log.Debug().Msg("Form created successfully")
+	zerolog.Ctx(ctx).Debug().Msg("Form created successfully")
 	return &api.SelfId{
 		Id:   createdForm.ID,
 		Href: model.GetFormHref(createdForm.ID, s.config.Server.PublicUrl, s.config.Server.BaseURL),
@@ -113,26 +134,49 @@ func (s *FormServiceImpl) GetFormById(ctx context.Context, id string) (*api.Form
 	form, err := s.formRepository.GetFormById(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			log.Debug().Str("formId", id).Msg("Form not found")
+			zerolog.Ctx(ctx).Debug().Str("formId", id).Msg("Form not found")
 			return nil, &apierrors.ResourceNotFoundError{}
-		} else {
-			log.Error().Err(err).Str("formId", id).Msg("Failed to retrieve form")
-			return nil, &apierrors.InvalidApplicationStateError{}
 		}
+		return nil, toAPIError(ctx, err, "Failed to retrieve form", "formId", id)
 	}
 
-	log.Debug().Msg("Form retrieved successfully")
+	zerolog.Ctx(ctx).Debug().Msg("Form retrieved successfully")
 	return form.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL), nil
 }
 
-func (s *FormServiceImpl) UpdateFormById(ctx context.Context, id string, req api.FormUpdate) (*api.FormResponseGet, error) {
+// GetFormVersion returns the version and modification time of a form without
+// reading its steps, so that conditional requests can be answered cheaply.
+func (s *FormServiceImpl) GetFormVersion(ctx context.Context, id string) (int, time.Time, error) {
+	form, err := s.formRepository.GetFormVersion(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			zerolog.Ctx(ctx).Debug().Str("formId", id).Msg("Form not found")
+			return 0, time.Time{}, &apierrors.ResourceNotFoundError{}
+		}
+		return 0, time.Time{}, toAPIError(ctx, err, "Failed to retrieve form version", "formId", id)
+	}
+
+	return form.Version, form.UpdatedAt, nil
+}
+
+func (s *FormServiceImpl) UpdateFormById(
+	ctx context.Context,
+	id string,
+	req api.FormUpdate,
+	ifMatch string,
+) (*api.FormResponseGet, error) {
 	form, err := s.getFormById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if !etag.Matches(ifMatch, form.Version) {
+		zerolog.Ctx(ctx).Debug().Str("formId", id).Msg("Form version does not match If-Match")
+		return nil, &apierrors.PreconditionFailedError{}
+	}
+
 	if req.ClickTrackingEnabled == nil && req.OpenTrackingEnabled == nil {
-		log.Debug().Msg("No fields to update in form")
+		zerolog.Ctx(ctx).Debug().Msg("No fields to update in form")
 		return nil, &apierrors.InvalidInputError{}
 	}
 
@@ -144,13 +188,24 @@ func (s *FormServiceImpl) UpdateFormById(ctx context.Context, id string, req api
 		form.OpenTrackingEnabled = req.OpenTrackingEnabled
 	}
 
-	updatedForm, err := s.formRepository.UpdateForm(ctx, form)
+	var response *api.FormResponseGet
+	err = s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		updatedForm, err := s.formRepository.UpdateForm(ctx, form)
+		if err != nil {
+			return err
+		}
+		response = updatedForm.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL)
+		return s.recordEvent(ctx, id, api.FormUpdated, response)
+	})
 	if err != nil {
-		log.Error().Err(err).Str("formId", id).Msg("Failed to update form")
-		return nil, &apierrors.InvalidApplicationStateError{}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			zerolog.Ctx(ctx).Debug().Str("formId", id).Msg("Form was modified concurrently")
+			return nil, &apierrors.PreconditionFailedError{}
+		}
+		return nil, toAPIError(ctx, err, "Failed to update form", "formId", id)
 	}
 
-	return updatedForm.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL), nil
+	return response, nil
 }
 
 func (s *FormServiceImpl) GetFormStepById(
@@ -163,7 +218,7 @@ func (s *FormServiceImpl) GetFormStepById(
 		return nil, err
 	}
 
-	log.Debug().Msg("Form step retrieved successfully")
+	zerolog.Ctx(ctx).Debug().Msg("Form step retrieved successfully")
 	return step.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL), nil
 }
 
@@ -172,14 +227,20 @@ func (s *FormServiceImpl) UpdateFormStepById(
 	formId string,
 	stepId string,
 	req api.FormStepUpdate,
+	ifMatch string,
 ) (*api.FormStepResponseGet, error) {
 	step, err := s.getFormStepById(ctx, formId, stepId)
 	if err != nil {
 		return nil, err
 	}
 
+	if !etag.Matches(ifMatch, step.Version) {
+		zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Form step version does not match If-Match")
+		return nil, &apierrors.PreconditionFailedError{}
+	}
+
 	if req.Name == nil && req.Content == nil {
-		log.Debug().Msg("No fields to update in form step")
+		zerolog.Ctx(ctx).Debug().Msg("No fields to update in form step")
 		return nil, &apierrors.InvalidInputError{}
 	}
 
@@ -190,20 +251,32 @@ func (s *FormServiceImpl) UpdateFormStepById(
 		step.Content = *req.Content
 	}
 
-	updatedStep, err := s.formRepository.UpdateFormStep(ctx, step)
+	var response *api.FormStepResponseGet
+	err = s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		updatedStep, err := s.formRepository.UpdateFormStep(ctx, step)
+		if err != nil {
+			return err
+		}
+		response = updatedStep.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL)
+		return s.recordEvent(ctx, formId, api.StepUpdated, response)
+	})
 	if err != nil {
-		log.Error().Err(err).Str("stepId", stepId).Msg("Failed to update form step")
-		return nil, &apierrors.InvalidApplicationStateError{}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Form step was modified concurrently")
+			return nil, &apierrors.PreconditionFailedError{}
+		}
+		return nil, toAPIError(ctx, err, "Failed to update form step", "stepId", stepId)
 	}
 
-	log.Debug().Msg("Form step updated successfully")
-	return updatedStep.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL), nil
+	zerolog.Ctx(ctx).Debug().Msg("Form step updated successfully")
+	return response, nil
 }
 
 func (s *FormServiceImpl) DeleteFormStepById(
 	ctx context.Context,
 	formId string,
 	stepId string,
+	ifMatch string,
 ) error {
 	step, err := s.getFormStepById(ctx, formId, stepId)
 	if err != nil {
@@ -211,20 +284,44 @@ func (s *FormServiceImpl) DeleteFormStepById(
 	}
 
 	if step.FormID != formId {
-		log.Debug().Str("stepId", stepId).Msg("Step does not belong to the specified form")
+		zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Step does not belong to the specified form")
 		return &apierrors.ResourceNotFoundError{}
 	}
 
-	err = s.formRepository.DeleteFormStepById(ctx, step.ID)
+	if !etag.Matches(ifMatch, step.Version) {
+		zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Form step version does not match If-Match")
+		return &apierrors.PreconditionFailedError{}
+	}
+
+	err = s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.formRepository.DeleteFormStep(ctx, step); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, formId, api.StepDeleted, api.SelfId{
+			Id:   step.ID,
+			Href: model.GetFormStepHref(formId, step.ID, s.config.Server.PublicUrl, s.config.Server.BaseURL),
+		})
+	})
 	if err != nil {
-		log.Error().Err(err).Str("stepId", stepId).Msg("Failed to delete form step")
-		return &apierrors.InvalidApplicationStateError{}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Form step was modified concurrently")
+			return &apierrors.PreconditionFailedError{}
+		}
+		return toAPIError(ctx, err, "Failed to delete form step", "stepId", stepId)
 	}
 
-	log.Debug().Msg("Form step deleted successfully")
+	zerolog.Ctx(ctx).Debug().Msg("Form step deleted successfully")
 	return nil
 }
 
+func (s *FormServiceImpl) recordEvent(ctx context.Context, formId string, eventType api.WebhookEventType, data interface{}) error {
+	event, err := newOutboxEvent(formId, eventType, data)
+	if err != nil {
+		return err
+	}
+	return s.outboxRepository.CreateEvents(ctx, []model.OutboxEventModel{event})
+}
+
 func (s *FormServiceImpl) getFormStepById(
 	ctx context.Context,
 	formId string,
@@ -233,19 +330,18 @@ func (s *FormServiceImpl) getFormStepById(
 	step, err := s.formRepository.GetFormStepById(ctx, stepId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			log.Debug().Str("stepId", stepId).Msg("Step not found")
+			zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Step not found")
 			return nil, &apierrors.ResourceNotFoundError{}
 		}
-		log.Error().Err(err).Str("stepId", stepId).Msg("Failed to retrieve step")
-		return nil, &apierrors.InvalidApplicationStateError{}
+		return nil, toAPIError(ctx, err, "Failed to retrieve step", "stepId", stepId)
 	}
 
 	if step.FormID != formId {
-		log.Debug().Str("stepId", stepId).Msg("Step does not belong to the specified form")
+		zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Step does not belong to the specified form")
 		return nil, &apierrors.ResourceNotFoundError{}
 	}
 
-	log.Debug().Msg("Step retrieved successfully")
+	zerolog.Ctx(ctx).Debug().Msg("Step retrieved successfully")
 	return step, nil
 }
 
@@ -253,11 +349,10 @@ func (s *FormServiceImpl) getFormById(ctx context.Context, id string) (*model.Fo
 	form, err := s.formRepository.GetFormById(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			log.Debug().Str("formId", id).Msg("Form not found")
+			zerolog.Ctx(ctx).Debug().Str("formId", id).Msg("Form not found")
 			return nil, &apierrors.ResourceNotFoundError{}
 		}
-		log.Error().Err(err).Str("formId", id).Msg("Failed to retrieve form")
-		return nil, &apierrors.InvalidApplicationStateError{}
+		return nil, toAPIError(ctx, err, "Failed to retrieve form", "formId", id)
 	}
 	return form, nil
 }
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/tracing"
+)
+
+// TracedFormService wraps a FormService in a span per method call.
+type TracedFormService struct {
+	FormService
+}
+
+func NewTracedFormService(svc FormService) FormService {
+	return &TracedFormService{FormService: svc}
+}
+
+func (ts *TracedFormService) LoginUser(ctx context.Context, req api.Authentication) (token string, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormService.LoginUser")
+	defer func() { tracing.End(span, err) }()
+	return ts.FormService.LoginUser(ctx, req)
+}
+
+func (ts *TracedFormService) CreateForm(ctx context.Context, req api.FormCreate) (id *api.SelfId, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormService.CreateForm")
+	defer func() { tracing.End(span, err) }()
+	return ts.FormService.CreateForm(ctx, req)
+}
+
+func (ts *TracedFormService) GetFormById(ctx context.Context, id string) (form *api.FormResponseGet, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormService.GetFormById")
+	defer func() { tracing.End(span, err) }()
+	return ts.FormService.GetFormById(ctx, id)
+}
+
+func (ts *TracedFormService) UpdateFormById(
+	ctx context.Context,
+	id string,
+	update api.FormUpdate,
+	ifMatch string,
+) (form *api.FormResponseGet, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormService.UpdateFormById")
+	defer func() { tracing.End(span, err) }()
+	return ts.FormService.UpdateFormById(ctx, id, update, ifMatch)
+}
+
+func (ts *TracedFormService) UpdateFormStepById(
+	ctx context.Context,
+	formId string,
+	stepId string,
+	req api.FormStepUpdate,
+	ifMatch string,
+) (step *api.FormStepResponseGet, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormService.UpdateFormStepById")
+	defer func() { tracing.End(span, err) }()
+	return ts.FormService.UpdateFormStepById(ctx, formId, stepId, req, ifMatch)
+}
+
+func (ts *TracedFormService) GetFormStepById(
+	ctx context.Context,
+	formId string,
+	stepId string,
+) (step *api.FormStepResponseGet, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormService.GetFormStepById")
+	defer func() { tracing.End(span, err) }()
+	return ts.FormService.GetFormStepById(ctx, formId, stepId)
+}
+
+func (ts *TracedFormService) DeleteFormStepById(ctx context.Context, formId string, stepId string, ifMatch string) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "FormService.DeleteFormStepById")
+	defer func() { tracing.End(span, err) }()
+	return ts.FormService.DeleteFormStepById(ctx, formId, stepId, ifMatch)
+}
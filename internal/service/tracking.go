@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/tracking"
+	"salesforge-assignment/internal/webhook"
+	"time"
+)
+
+type TrackingService interface {
+	RenderFormStepById(ctx context.Context, formId string, stepId string, recipient string) (*api.FormStepRenderResponse, error)
+	RecordOpen(ctx context.Context, token string, userAgent string, ip string) error
+	RecordClick(ctx context.Context, token string, userAgent string, ip string) (string, error)
+	IngestEvents(ctx context.Context, batch api.EventBatch, userAgent string, ip string) (*api.EventBatchResponse, error)
+}
+
+type TrackingServiceImpl struct {
+	formRepository     repository.FormRepository
+	trackingRepository repository.TrackingRepository
+	outboxRepository   repository.OutboxRepository
+	txManager          repository.TxManager
+	config             *config.Config
+	signer             *tracking.Signer
+}
+
+func NewTrackingService(
+	log *zerolog.Logger,
+	formRepository repository.FormRepository,
+	trackingRepository repository.TrackingRepository,
+	outboxRepository repository.OutboxRepository,
+	txManager repository.TxManager,
+	config *config.Config,
+) TrackingService {
+	trackingSecret := config.Tracking.Secret
+
+	if trackingSecret == "" {
+		log.Fatal().Msg("tracking.secret is not configured")
+	}
+
+	return &TrackingServiceImpl{
+		formRepository:     formRepository,
+		trackingRepository: trackingRepository,
+		outboxRepository:   outboxRepository,
+		txManager:          txManager,
+		config:             config,
+		signer:             tracking.NewSigner([]byte(trackingSecret)),
+	}
+}
+
+func (s *TrackingServiceImpl) RenderFormStepById(
+	ctx context.Context,
+	formId string,
+	stepId string,
+	recipient string,
+) (*api.FormStepRenderResponse, error) {
+	if recipient == "" {
+		return nil, &apierrors.InvalidInputError{}
+	}
+
+	form, step, err := s.getFormAndStep(ctx, formId, stepId)
+	if err != nil {
+		return nil, err
+	}
+
+	content := step.Content
+	if *form.ClickTrackingEnabled {
+		content, err = tracking.RewriteLinks(content, func(link string) (string, error) {
+			token, err := s.signer.Sign(tracking.KindClick, tracking.Claims{FormID: form.ID, StepID: step.ID, Recipient: recipient, URL: link})
+			if err != nil {
+				return "", err
+			}
+			return tracking.GetClickHref(token, s.config.Server.PublicUrl, s.config.Server.BaseURL), nil
+		})
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("stepId", stepId).Msg("Failed to rewrite links for click tracking")
+			return nil, &apierrors.InvalidApplicationStateError{}
+		}
+	}
+
+	if *form.OpenTrackingEnabled {
+		token, err := s.signer.Sign(tracking.KindOpen, tracking.Claims{FormID: form.ID, StepID: step.ID, Recipient: recipient})
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("stepId", stepId).Msg("Failed to sign tracking token")
+			return nil, &apierrors.InvalidApplicationStateError{}
+		}
+		pixelHref := tracking.GetOpenPixelHref(token, s.config.Server.PublicUrl, s.config.Server.BaseURL)
+		content = tracking.AppendOpenPixel(content, pixelHref)
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Form step rendered successfully")
+	return &api.FormStepRenderResponse{
+		Name:    step.Name,
+		Content: content,
+		Step:    step.StepOrder,
+		Self: api.SelfId{
+			Id:   step.ID,
+			Href: model.GetFormStepHref(step.FormID, step.ID, s.config.Server.PublicUrl, s.config.Server.BaseURL),
+		},
+	}, nil
+}
+
+// RecordOpen stores an open event for the recipient encoded in the token.
+// Opens for forms without open tracking and repeated opens within the
+// configured de-duplication window are dropped silently.
+func (s *TrackingServiceImpl) RecordOpen(ctx context.Context, token string, userAgent string, ip string) error {
+	claims, err := s.signer.Verify(tracking.KindOpen, token)
+	if err != nil {
+		return &apierrors.InvalidInputError{Err: err}
+	}
+
+	form, step, err := s.getFormAndStep(ctx, claims.FormID, claims.StepID)
+	if err != nil {
+		return err
+	}
+
+	if !*form.OpenTrackingEnabled {
+		zerolog.Ctx(ctx).Trace().Str("formId", form.ID).Msg("Open tracking disabled, event dropped")
+		return nil
+	}
+
+	now := time.Now().UTC()
+	event := &model.TrackingEventModel{
+		EventType:  model.TrackingEventOpen,
+		FormID:     form.ID,
+		StepID:     step.ID,
+		Recipient:  claims.Recipient,
+		UserAgent:  userAgent,
+		IPHash:     s.signer.HashIP(ip),
+		OccurredAt: now,
+	}
+
+	if window := s.config.Tracking.OpenDedupWindow; window > 0 {
+		created, err := s.trackingRepository.CreateEventUnlessSeen(ctx, event, now.Add(-window))
+		if err != nil {
+			return toAPIError(ctx, err, "Failed to record open event", "formId", form.ID)
+		}
+		if !created {
+			zerolog.Ctx(ctx).Trace().Str("formId", form.ID).Msg("Repeated open within de-duplication window, event dropped")
+			return nil
+		}
+	} else if err := s.trackingRepository.CreateEvent(ctx, event); err != nil {
+		return toAPIError(ctx, err, "Failed to record open event", "formId", form.ID)
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("formId", form.ID).Str("stepId", step.ID).Msg("Open event recorded")
+	return nil
+}
+
+// RecordClick verifies the click token and returns the original link. Only
+// links that were signed by this service are ever returned, so the redirect
+// endpoint cannot be abused as an open redirect. A click event is stored when
+// the form still has click tracking enabled; failing to store it does not
+// prevent the redirect.
+func (s *TrackingServiceImpl) RecordClick(ctx context.Context, token string, userAgent string, ip string) (string, error) {
+	claims, err := s.signer.Verify(tracking.KindClick, token)
+	if err != nil || !tracking.IsRedirectable(claims.URL) {
+		zerolog.Ctx(ctx).Debug().Msg("Rejected tampered click tracking token")
+		return "", &apierrors.ResourceNotFoundError{Err: err}
+	}
+
+	form, step, err := s.getFormAndStep(ctx, claims.FormID, claims.StepID)
+	if err != nil {
+		return claims.URL, nil
+	}
+
+	if !*form.ClickTrackingEnabled {
+		zerolog.Ctx(ctx).Trace().Str("formId", form.ID).Msg("Click tracking disabled, event dropped")
+		return claims.URL, nil
+	}
+
+	event := &model.TrackingEventModel{
+		EventType:  model.TrackingEventClick,
+		FormID:     form.ID,
+		StepID:     step.ID,
+		Recipient:  claims.Recipient,
+		URL:        claims.URL,
+		UserAgent:  userAgent,
+		IPHash:     s.signer.HashIP(ip),
+		OccurredAt: time.Now().UTC(),
+	}
+
+	if err := s.trackingRepository.CreateEvent(ctx, event); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("formId", form.ID).Msg("Failed to record click event")
+		return claims.URL, nil
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("formId", form.ID).Str("stepId", step.ID).Msg("Click event recorded")
+	return claims.URL, nil
+}
+
+// IngestEvents stores events reported by client-side trackers. The whole
+// batch is rejected if any event references an unknown form or a step of
+// another form, or occurred longer than tracking.maxEventAge ago; open and click events of forms with the corresponding
+// tracking disabled are dropped and counted as such.
+func (s *TrackingServiceImpl) IngestEvents(
+	ctx context.Context,
+	batch api.EventBatch,
+	userAgent string,
+	ip string,
+) (*api.EventBatchResponse, error) {
+	now := time.Now().UTC()
+	oldest := now.Add(-s.config.Tracking.MaxEventAge)
+	ipHash := s.signer.HashIP(ip)
+	forms := make(map[string]*model.FormModel)
+	trackingEvents := make([]model.TrackingEventModel, 0, len(batch.Events))
+
+	for _, event := range batch.Events {
+		form, ok := forms[event.FormId]
+		if !ok {
+			var err error
+			form, _, err = s.getFormAndStep(ctx, event.FormId, event.StepId)
+			if err != nil {
+				return nil, toInvalidInput(err)
+			}
+			forms[event.FormId] = form
+		}
+
+		if !form.HasStep(event.StepId) {
+			zerolog.Ctx(ctx).Debug().Str("stepId", event.StepId).Msg("Step does not belong to the specified form")
+			return nil, &apierrors.InvalidInputError{}
+		}
+
+		if event.Type == api.Open && !*form.OpenTrackingEnabled ||
+			event.Type == api.Click && !*form.ClickTrackingEnabled {
+			continue
+		}
+
+		if event.OccurredAt != nil && event.OccurredAt.Before(oldest) {
+			zerolog.Ctx(ctx).Debug().Time("occurredAt", *event.OccurredAt).Msg("Event is older than the accepted age")
+			return nil, &apierrors.InvalidInputError{Field: "occurredAt", Err: errors.New("event is too old")}
+		}
+
+		occurredAt := now
+		if event.OccurredAt != nil && event.OccurredAt.Before(now) {
+			occurredAt = event.OccurredAt.UTC()
+		}
+
+		trackingEvent := model.TrackingEventModel{
+			EventType:  string(event.Type),
+			FormID:     event.FormId,
+			StepID:     event.StepId,
+			Recipient:  event.Recipient,
+			UserAgent:  userAgent,
+			IPHash:     ipHash,
+			OccurredAt: occurredAt,
+		}
+		if event.Url != nil {
+			trackingEvent.URL = *event.Url
+		}
+		trackingEvents = append(trackingEvents, trackingEvent)
+	}
+
+	var completions []model.OutboxEventModel
+	for i := range trackingEvents {
+		event := &trackingEvents[i]
+		if event.EventType == model.TrackingEventStepCompleted && forms[event.FormID].IsLastStep(event.StepID) {
+			completion, err := s.newSubmissionCompletedEvent(event)
+			if err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).Msg("Failed to build submission completed event")
+				return nil, &apierrors.InvalidApplicationStateError{}
+			}
+			completions = append(completions, completion)
+		}
+	}
+
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.trackingRepository.CreateEvents(ctx, trackingEvents); err != nil {
+			return err
+		}
+		return s.outboxRepository.CreateEvents(ctx, completions)
+	})
+	if err != nil {
+		return nil, toAPIError(ctx, err, "Failed to store ingested events")
+	}
+
+	zerolog.Ctx(ctx).Debug().Int("accepted", len(trackingEvents)).Msg("Events ingested")
+
+	return &api.EventBatchResponse{
+		Accepted: len(trackingEvents),
+		Dropped:  len(batch.Events) - len(trackingEvents),
+	}, nil
+}
+
+func (s *TrackingServiceImpl) newSubmissionCompletedEvent(event *model.TrackingEventModel) (model.OutboxEventModel, error) {
+	publicUrl, baseUrl := s.config.Server.PublicUrl, s.config.Server.BaseURL
+	return newOutboxEvent(event.FormID, api.SubmissionCompleted, webhook.SubmissionCompleted{
+		Form:        api.SelfId{Id: event.FormID, Href: model.GetFormHref(event.FormID, publicUrl, baseUrl)},
+		Step:        api.SelfId{Id: event.StepID, Href: model.GetFormStepHref(event.FormID, event.StepID, publicUrl, baseUrl)},
+		Recipient:   event.Recipient,
+		CompletedAt: event.OccurredAt,
+	})
+}
+
+func (s *TrackingServiceImpl) getFormAndStep(
+	ctx context.Context,
+	formId string,
+	stepId string,
+) (*model.FormModel, *model.FormStepModel, error) {
+	form, err := s.formRepository.GetFormById(ctx, formId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			zerolog.Ctx(ctx).Debug().Str("formId", formId).Msg("Form not found")
+			return nil, nil, &apierrors.ResourceNotFoundError{}
+		}
+		return nil, nil, toAPIError(ctx, err, "Failed to retrieve form", "formId", formId)
+	}
+
+	for i := range form.Steps {
+		if form.Steps[i].ID == stepId {
+			return form, &form.Steps[i], nil
+		}
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("stepId", stepId).Msg("Step does not belong to the specified form")
+	return nil, nil, &apierrors.ResourceNotFoundError{}
+}
+
+// toInvalidInput reports references to unknown resources inside a request
+// body as invalid input rather than as a missing resource.
+func toInvalidInput(err error) error {
+	var notFound *apierrors.ResourceNotFoundError
+	if errors.As(err, &notFound) {
+		return &apierrors.InvalidInputError{Err: err}
+	}
+	return err
+}
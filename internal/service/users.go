@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"strings"
+)
+
+// UserService manages the users that can log in, for operators rather than
+// through the API.
+type UserService interface {
+	CreateUser(ctx context.Context, username string, password string) error
+	ResetPassword(ctx context.Context, username string, password string) error
+	DisableUser(ctx context.Context, username string) error
+}
+
+type UserServiceImpl struct {
+	credentialsRepository repository.CredentialsRepository
+}
+
+func NewUserService(
+	credentialsRepository repository.CredentialsRepository,
+) UserService {
+	return &UserServiceImpl{
+		credentialsRepository: credentialsRepository,
+	}
+}
+
+func (s *UserServiceImpl) CreateUser(ctx context.Context, username string, password string) error {
+	if strings.TrimSpace(username) == "" {
+		return &apierrors.InvalidInputError{Field: "username", Err: errors.New("username is empty")}
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	err = s.credentialsRepository.CreateCredentials(ctx, &model.CredentialsModel{Username: username, Password: hash})
+	if err != nil {
+		return err
+	}
+	zerolog.Ctx(ctx).Info().EmbedObject(logger.Fields{"username": username}).Msg("User created")
+	return nil
+}
+
+func (s *UserServiceImpl) ResetPassword(ctx context.Context, username string, password string) error {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	if err := s.credentialsRepository.UpdateCredentials(ctx, username, map[string]interface{}{"password": hash}); err != nil {
+		return err
+	}
+	zerolog.Ctx(ctx).Info().EmbedObject(logger.Fields{"username": username}).Msg("Password reset")
+	return nil
+}
+
+// DisableUser keeps the user from logging in again. Tokens issued before
+// stay valid until they expire.
+func (s *UserServiceImpl) DisableUser(ctx context.Context, username string) error {
+	if err := s.credentialsRepository.UpdateCredentials(ctx, username, map[string]interface{}{"disabled": true}); err != nil {
+		return err
+	}
+	zerolog.Ctx(ctx).Info().EmbedObject(logger.Fields{"username": username}).Msg("User disabled")
+	return nil
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/events"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/webhook"
+	"time"
+)
+
+const (
+	defaultDeliveriesPageSize = 20
+	maxDeliveriesPageSize     = 100
+	generatedSecretBytes      = 32
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req api.WebhookCreate) (*api.WebhookResponseGet, error)
+	ListWebhooks(ctx context.Context) (api.WebhookGetArray, error)
+	GetWebhookById(ctx context.Context, id string) (*api.WebhookResponseGet, error)
+	DeleteWebhookById(ctx context.Context, id string) error
+	ListWebhookDeliveries(ctx context.Context, webhookId string, params api.ListWebhookDeliveriesParams) (*api.WebhookDeliveryPage, error)
+	GetWebhookDeliveryById(ctx context.Context, webhookId string, deliveryId string) (*api.WebhookDeliveryResponseGet, error)
+	RedeliverWebhookDelivery(ctx context.Context, webhookId string, deliveryId string) (*api.WebhookDeliveryResponseGet, error)
+	HandleEvent(ctx context.Context, event events.Event) error
+}
+
+type WebhookServiceImpl struct {
+	webhookRepository repository.WebhookRepository
+	formRepository    repository.FormRepository
+	config            *config.Config
+}
+
+func NewWebhookService(
+	webhookRepository repository.WebhookRepository,
+	formRepository repository.FormRepository,
+	config *config.Config,
+) WebhookService {
+	return &WebhookServiceImpl{
+		webhookRepository: webhookRepository,
+		formRepository:    formRepository,
+		config:            config,
+	}
+}
+
+func (s *WebhookServiceImpl) CreateWebhook(ctx context.Context, req api.WebhookCreate) (*api.WebhookResponseGet, error) {
+	if req.FormId != nil {
+		if _, err := s.formRepository.GetFormById(ctx, *req.FormId); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				zerolog.Ctx(ctx).Debug().Str("formId", *req.FormId).Msg("Form not found")
+				return nil, &apierrors.InvalidInputError{Err: err}
+			}
+			zerolog.Ctx(ctx).Error().Err(err).Str("formId", *req.FormId).Msg("Failed to retrieve form")
+			return nil, &apierrors.InvalidApplicationStateError{}
+		}
+	}
+
+	secret := ""
+	if req.Secret != nil {
+		secret = *req.Secret
+	} else {
+		generated, err := generateSecret()
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("Failed to generate webhook secret")
+			return nil, &apierrors.InvalidApplicationStateError{}
+		}
+		secret = generated
+	}
+
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	for _, eventType := range req.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	created, err := s.webhookRepository.CreateWebhook(ctx, &model.WebhookModel{
+		FormID:     req.FormId,
+		URL:        req.Url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		return nil, toAPIError(ctx, err, "Failed to create webhook")
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("webhookId", created.ID).Msg("Webhook created successfully")
+	response := created.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL)
+	response.Secret = &secret
+	return response, nil
+}
+
+func (s *WebhookServiceImpl) ListWebhooks(ctx context.Context) (api.WebhookGetArray, error) {
+	webhooks, err := s.webhookRepository.ListWebhooks(ctx)
+	if err != nil {
+		return nil, toAPIError(ctx, err, "Failed to list webhooks")
+	}
+
+	response := make(api.WebhookGetArray, 0, len(webhooks))
+	for _, wh := range webhooks {
+		response = append(response, *wh.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL))
+	}
+	return response, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhookById(ctx context.Context, id string) (*api.WebhookResponseGet, error) {
+	wh, err := s.getWebhookById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return wh.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL), nil
+}
+
+func (s *WebhookServiceImpl) DeleteWebhookById(ctx context.Context, id string) error {
+	wh, err := s.getWebhookById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webhookRepository.DeleteWebhookById(ctx, wh.ID); err != nil {
+		return toAPIError(ctx, err, "Failed to delete webhook", "webhookId", id)
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("webhookId", id).Msg("Webhook deleted successfully")
+	return nil
+}
+
+func (s *WebhookServiceImpl) ListWebhookDeliveries(
+	ctx context.Context,
+	webhookId string,
+	params api.ListWebhookDeliveriesParams,
+) (*api.WebhookDeliveryPage, error) {
+	page, pageSize := 1, defaultDeliveriesPageSize
+	if params.Page != nil {
+		page = *params.Page
+	}
+	if params.PageSize != nil {
+		pageSize = *params.PageSize
+	}
+	if page < 1 || pageSize < 1 || pageSize > maxDeliveriesPageSize {
+		return nil, &apierrors.InvalidInputError{}
+	}
+
+	if _, err := s.getWebhookById(ctx, webhookId); err != nil {
+		return nil, err
+	}
+
+	deliveries, total, err := s.webhookRepository.ListDeliveries(ctx, webhookId, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, toAPIError(ctx, err, "Failed to list webhook deliveries", "webhookId", webhookId)
+	}
+
+	items := make([]api.WebhookDeliveryResponseGet, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, *delivery.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL))
+	}
+
+	return &api.WebhookDeliveryPage{
+		Items:    items,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhookDeliveryById(
+	ctx context.Context,
+	webhookId string,
+	deliveryId string,
+) (*api.WebhookDeliveryResponseGet, error) {
+	delivery, err := s.getDeliveryById(ctx, webhookId, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+	return delivery.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL), nil
+}
+
+func (s *WebhookServiceImpl) RedeliverWebhookDelivery(
+	ctx context.Context,
+	webhookId string,
+	deliveryId string,
+) (*api.WebhookDeliveryResponseGet, error) {
+	delivery, err := s.getDeliveryById(ctx, webhookId, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepository.ScheduleRedelivery(ctx, delivery); err != nil {
+		return nil, toAPIError(ctx, err, "Failed to schedule webhook redelivery", "deliveryId", deliveryId)
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("deliveryId", deliveryId).Msg("Webhook redelivery scheduled")
+	return delivery.ToResponse(s.config.Server.PublicUrl, s.config.Server.BaseURL), nil
+}
+
+// HandleEvent queues a delivery of a domain event for every webhook
+// subscribed to it. Events may be handled more than once; a webhook gets at
+// most one delivery per event.
+func (s *WebhookServiceImpl) HandleEvent(ctx context.Context, event events.Event) error {
+	log := zerolog.Ctx(ctx).With().Str("formId", event.FormID).Str("eventType", string(event.Type)).Logger()
+
+	webhooks, err := s.webhookRepository.FindSubscribedWebhooks(ctx, event.FormID, string(event.Type))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to look up subscribed webhooks")
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(webhook.Event{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		FormID:     event.FormID,
+		Data:       event.Data,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode webhook event")
+		return err
+	}
+
+	deliveries := make([]model.WebhookDeliveryModel, 0, len(webhooks))
+	for _, wh := range webhooks {
+		deliveries = append(deliveries, model.WebhookDeliveryModel{
+			WebhookID:     wh.ID,
+			EventID:       event.ID,
+			EventType:     string(event.Type),
+			Payload:       string(payload),
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: time.Now().UTC(),
+		})
+	}
+
+	if err := s.webhookRepository.CreateDeliveries(ctx, deliveries); err != nil {
+		log.Error().Err(err).Msg("Failed to queue webhook deliveries")
+		return err
+	}
+
+	log.Debug().Int("deliveries", len(deliveries)).Msg("Webhook deliveries queued")
+	return nil
+}
+
+func (s *WebhookServiceImpl) getWebhookById(ctx context.Context, id string) (*model.WebhookModel, error) {
+	wh, err := s.webhookRepository.GetWebhookById(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			zerolog.Ctx(ctx).Debug().Str("webhookId", id).Msg("Webhook not found")
+			return nil, &apierrors.ResourceNotFoundError{}
+		}
+		return nil, toAPIError(ctx, err, "Failed to retrieve webhook", "webhookId", id)
+	}
+	return wh, nil
+}
+
+func (s *WebhookServiceImpl) getDeliveryById(ctx context.Context, webhookId string, deliveryId string) (*model.WebhookDeliveryModel, error) {
+	delivery, err := s.webhookRepository.GetDeliveryById(ctx, webhookId, deliveryId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			zerolog.Ctx(ctx).Debug().Str("deliveryId", deliveryId).Msg("Webhook delivery not found")
+			return nil, &apierrors.ResourceNotFoundError{}
+		}
+		return nil, toAPIError(ctx, err, "Failed to retrieve webhook delivery", "deliveryId", deliveryId)
+	}
+	return delivery, nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
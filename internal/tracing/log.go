@@ -0,0 +1,24 @@
+package tracing
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDField = "traceId"
+	spanIDField  = "spanId"
+)
+
+// LogHook adds the trace and span IDs to log events given a context with
+// Event.Ctx or Context.Ctx. It is the only source of these fields, so
+// loggers should carry the context rather than the IDs.
+type LogHook struct{}
+
+func (LogHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	spanContext := trace.SpanContextFromContext(e.GetCtx())
+	if !spanContext.IsValid() {
+		return
+	}
+	e.Str(traceIDField, spanContext.TraceID().String()).Str(spanIDField, spanContext.SpanID().String())
+}
@@ -0,0 +1,55 @@
+package tracking
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const clickHref = "%s%s/t/c/%s"
+
+var linkPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+func GetClickHref(token string, publicUrl string, baseUrl string) string {
+	return fmt.Sprintf(clickHref, publicUrl, baseUrl, token)
+}
+
+// RewriteLinks replaces every http(s) URL found in the content with the
+// result of rewrite. Trailing punctuation that is most likely part of the
+// surrounding text is left in place.
+func RewriteLinks(content string, rewrite func(link string) (string, error)) (string, error) {
+	var rewriteErr error
+
+	rewritten := linkPattern.ReplaceAllStringFunc(content, func(match string) string {
+		if rewriteErr != nil {
+			return match
+		}
+
+		link := strings.TrimRight(match, ".,;:!?)")
+		suffix := match[len(link):]
+
+		replacement, err := rewrite(html.UnescapeString(link))
+		if err != nil {
+			rewriteErr = err
+			return match
+		}
+		return html.EscapeString(replacement) + suffix
+	})
+
+	if rewriteErr != nil {
+		return "", rewriteErr
+	}
+	return rewritten, nil
+}
+
+// IsRedirectable reports whether the link is an absolute http(s) URL that is
+// safe to redirect to.
+func IsRedirectable(link string) bool {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
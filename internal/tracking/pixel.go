@@ -0,0 +1,38 @@
+package tracking
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	PixelExtension   = ".gif"
+	PixelContentType = "image/gif"
+
+	openPixelHref = "%s%s/t/o/%s" + PixelExtension
+	openPixelTag  = `<img src="%s" width="1" height="1" alt="" style="display:none" />`
+)
+
+// TransparentGIF is a 1x1 transparent GIF89a image.
+var TransparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+func GetOpenPixelHref(token string, publicUrl string, baseUrl string) string {
+	return fmt.Sprintf(openPixelHref, publicUrl, baseUrl, token)
+}
+
+// AppendOpenPixel adds an invisible image pointing at the open-tracking pixel to the content.
+func AppendOpenPixel(content string, pixelHref string) string {
+	return content + fmt.Sprintf(openPixelTag, pixelHref)
+}
+
+// TokenFromPixel strips the image extension from the pixel path segment.
+func TokenFromPixel(pixel string) (string, bool) {
+	if !strings.HasSuffix(pixel, PixelExtension) {
+		return "", false
+	}
+	return strings.TrimSuffix(pixel, PixelExtension), true
+}
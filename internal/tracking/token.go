@@ -0,0 +1,91 @@
+package tracking
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidToken = errors.New("invalid tracking token")
+
+// Claims identify the recipient of a rendered form step and, for click
+// tracking, the original link. Tokens carrying them are handed out inside
+// rendered content and come back on public, unauthenticated tracking
+// endpoints, so they are always signed.
+type Claims struct {
+	FormID    string `json:"f"`
+	StepID    string `json:"s"`
+	Recipient string `json:"r"`
+	URL       string `json:"u,omitempty"`
+}
+
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// Kind is the purpose of a token. It is part of the signature, so that a
+// token issued for one endpoint is rejected by the others.
+type Kind string
+
+const (
+	KindOpen  Kind = "open"
+	KindClick Kind = "click"
+)
+
+// Sign encodes the claims as <payload>.<signature>, both base64url without padding.
+func (s *Signer) Sign(kind Kind, claims Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.signature(kind, encoded), nil
+}
+
+func (s *Signer) Verify(kind Kind, token string) (*Claims, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || encoded == "" || signature == "" {
+		return nil, ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(kind, encoded))) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
+
+// HashIP returns a keyed hash of the client IP so that repeat visitors can be
+// told apart without storing the address itself.
+func (s *Signer) HashIP(ip string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Signer) signature(kind Kind, encoded string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
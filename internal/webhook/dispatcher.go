@@ -0,0 +1,222 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"net/http"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/retry"
+	"salesforge-assignment/internal/tracing"
+	"time"
+)
+
+const (
+	defaultPollInterval   = 2 * time.Second
+	defaultBatchSize      = 20
+	defaultTimeout        = 10 * time.Second
+	defaultMaxAttempts    = 8
+	defaultInitialBackoff = 10 * time.Second
+	defaultMaxBackoff     = time.Hour
+
+	maxErrorLength = 512
+)
+
+// Dispatcher posts pending webhook deliveries to their receivers. Failed
+// deliveries are retried with exponential backoff until the retry budget is
+// exhausted, after which they are dead-lettered until redelivered manually.
+type Dispatcher struct {
+	log            *zerolog.Logger
+	repository     repository.WebhookRepository
+	client         *http.Client
+	pollInterval   time.Duration
+	batchSize      int
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	// enabled reports whether deliveries are sent, see SetEnabled
+	enabled func() bool
+}
+
+func NewDispatcher(
+	log *zerolog.Logger,
+	repository repository.WebhookRepository,
+	cfg config.WebhooksConfig,
+) *Dispatcher {
+	d := &Dispatcher{
+		log:        log,
+		repository: repository,
+		client: &http.Client{
+			Timeout: orDefault(cfg.Timeout, defaultTimeout),
+			// Receivers get the trace context, so they can join the trace
+			Transport: otelhttp.NewTransport(newTransport(newTargetGuard(cfg.AllowedNetworks))),
+		},
+		pollInterval:   orDefault(cfg.PollInterval, defaultPollInterval),
+		batchSize:      cfg.BatchSize,
+		maxAttempts:    cfg.MaxAttempts,
+		initialBackoff: orDefault(cfg.InitialBackoff, defaultInitialBackoff),
+		maxBackoff:     orDefault(cfg.MaxBackoff, defaultMaxBackoff),
+		enabled:        func() bool { return true },
+	}
+	if d.batchSize <= 0 {
+		d.batchSize = defaultBatchSize
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = defaultMaxAttempts
+	}
+	return d
+}
+
+// SetEnabled makes Run pause while enabled reports false. Due deliveries
+// stay pending meanwhile and are sent once it reports true again.
+func (d *Dispatcher) SetEnabled(enabled func() bool) {
+	d.enabled = enabled
+}
+
+// Run dispatches due deliveries until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	d.log.Info().Dur("pollInterval", d.pollInterval).Msg("Webhook dispatcher started")
+	for {
+		// Keep draining while full batches come back
+		if d.enabled() && d.DispatchDue(ctx) == d.batchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			d.log.Info().Msg("Webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends one batch of due deliveries and returns its size.
+func (d *Dispatcher) DispatchDue(ctx context.Context) int {
+	ctx, span := tracing.Tracer().Start(ctx, "Dispatcher.DispatchDue")
+	defer span.End()
+
+	// The lease must outlive the attempts of the whole batch
+	lease := d.client.Timeout*time.Duration(d.batchSize) + time.Minute
+
+	deliveries, err := d.repository.ClaimDueDeliveries(ctx, d.batchSize, lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.log.Error().Ctx(ctx).Err(err).Msg("Failed to claim due webhook deliveries")
+		}
+		return 0
+	}
+	span.SetAttributes(attribute.Int("webhook.deliveries", len(deliveries)))
+
+	for i := range deliveries {
+		d.deliver(ctx, &deliveries[i])
+	}
+
+	return len(deliveries)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *model.WebhookDeliveryModel) {
+	ctx, span := tracing.Tracer().Start(ctx, "Dispatcher.deliver", trace.WithAttributes(
+		attribute.String("webhook.delivery_id", delivery.ID),
+		attribute.String("webhook.id", delivery.WebhookID),
+		attribute.String("webhook.event_type", delivery.EventType),
+	))
+	defer span.End()
+
+	log := d.log.With().
+		Ctx(ctx).
+		Str("deliveryId", delivery.ID).
+		Str("webhookId", delivery.WebhookID).
+		Str("eventType", delivery.EventType).
+		Logger()
+
+	start := time.Now()
+	statusCode, err := d.post(ctx, delivery)
+	attempt := &model.WebhookDeliveryAttemptModel{
+		AttemptedAt: start.UTC(),
+		DurationMs:  time.Since(start).Milliseconds(),
+	}
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+
+	if err == nil {
+		delivery.Status = model.WebhookDeliverySucceeded
+		log.Debug().Int("status", statusCode).Msg("Webhook delivered")
+	} else {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		attempt.Error = truncate(err.Error(), maxErrorLength)
+		delivery.Attempts++
+		if delivery.Attempts >= d.maxAttempts {
+			delivery.Status = model.WebhookDeliveryDead
+			log.Warn().Err(err).Int("attempts", delivery.Attempts).Msg("Webhook delivery dead-lettered")
+		} else {
+			delivery.NextAttemptAt = time.Now().UTC().Add(retry.Backoff(delivery.Attempts, d.initialBackoff, d.maxBackoff))
+			log.Debug().Err(err).Int("attempts", delivery.Attempts).Time("nextAttemptAt", delivery.NextAttemptAt).
+				Msg("Webhook delivery failed, retry scheduled")
+		}
+	}
+
+	// Use a fresh context so a shutdown mid-attempt still records its outcome
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := d.repository.RecordAttempt(recordCtx, delivery, attempt); err != nil {
+		log.Error().Err(err).Msg("Failed to record webhook delivery attempt")
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, delivery *model.WebhookDeliveryModel) (int, error) {
+	if delivery.Webhook == nil {
+		return 0, fmt.Errorf("webhook %s no longer exists", delivery.WebhookID)
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "forms-api-webhooks/1.0")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(SignatureHeader, Sign(delivery.Webhook.Secret, time.Now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func orDefault(value time.Duration, fallback time.Duration) time.Duration {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length]
+}
@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	"salesforge-assignment/internal/api"
+	"time"
+)
+
+// Event is the envelope posted to webhook receivers.
+type Event struct {
+	ID         string               `json:"id"`
+	Type       api.WebhookEventType `json:"type"`
+	OccurredAt time.Time            `json:"occurredAt"`
+	FormID     string               `json:"formId"`
+	Data       interface{}          `json:"data"`
+}
+
+// SubmissionCompleted is the data of submission.completed events, sent when
+// a recipient completes the last step of a form.
+type SubmissionCompleted struct {
+	Form        api.SelfId `json:"form"`
+	Step        api.SelfId `json:"step"`
+	Recipient   string     `json:"recipient"`
+	CompletedAt time.Time  `json:"completedAt"`
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// nonPublicNetworks are special-purpose networks that the netip predicates do
+// not cover but that can reach internal services, e.g. the shared address
+// space of carrier-grade NAT that cloud providers use internally, or IPv6
+// prefixes that translate to IPv4 addresses.
+var nonPublicNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// targetGuard refuses connections to loopback, link-local, private and other
+// non-public addresses unless they are in one of the allowed networks. It is
+// checked on the address actually dialled, after DNS resolution and on every
+// redirect, so a receiver URL cannot be used to reach internal services.
+type targetGuard struct {
+	allowed []netip.Prefix
+}
+
+func (g *targetGuard) control(_ string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("webhook target %s: %w", address, err)
+	}
+
+	ip := addrPort.Addr().Unmap()
+	for _, prefix := range g.allowed {
+		if prefix.Contains(ip) {
+			return nil
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("webhook target %s is not a public address", ip)
+	}
+	for _, prefix := range nonPublicNetworks {
+		if prefix.Contains(ip) {
+			return fmt.Errorf("webhook target %s is not a public address", ip)
+		}
+	}
+	return nil
+}
+
+// newTargetGuard parses the allowed networks, which may be CIDRs or single
+// addresses. Invalid entries are rejected by the configuration validation.
+func newTargetGuard(networks []string) *targetGuard {
+	g := &targetGuard{}
+	for _, network := range networks {
+		if prefix, err := netip.ParsePrefix(network); err == nil {
+			g.allowed = append(g.allowed, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(network); err == nil {
+			g.allowed = append(g.allowed, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+	return g
+}
+
+func newTransport(guard *targetGuard) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   guard.control,
+	}).DialContext
+	return transport
+}
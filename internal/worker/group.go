@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Group runs the background workers of the application. Workers run until
+// Stop, and a worker that returns earlier, e.g. after a panic, makes the
+// group unhealthy.
+type Group struct {
+	log    *zerolog.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	stopped bool
+	failed  map[string]bool
+}
+
+func NewGroup(log *zerolog.Logger) *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{
+		log:    log,
+		ctx:    ctx,
+		cancel: cancel,
+		failed: map[string]bool{},
+	}
+}
+
+// Go runs the worker in a goroutine. run must return once its context is
+// done.
+func (g *Group) Go(name string, run func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer func() {
+			recovered := recover()
+			if recovered != nil {
+				g.log.Error().Str("worker", name).Interface("panic", recovered).Msg("Worker panicked")
+			}
+
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			if !g.stopped {
+				g.log.Error().Str("worker", name).Msg("Worker stopped unexpectedly")
+				g.failed[name] = true
+			}
+		}()
+
+		// Repositories log through the logger of the context
+		log := g.log.With().Str("worker", name).Logger()
+		run(log.WithContext(g.ctx))
+	}()
+}
+
+// Check fails when a worker has stopped before the group.
+func (g *Group) Check(context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.failed) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(g.failed))
+	for name := range g.failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("workers stopped: %s", strings.Join(names, ", "))
+}
+
+// Stop cancels the workers and waits for them to return, or for ctx to be
+// done.
+func (g *Group) Stop(ctx context.Context) error {
+	g.mu.Lock()
+	g.stopped = true
+	g.mu.Unlock()
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("workers did not stop in time: %w", ctx.Err())
+	}
+}
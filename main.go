@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	"gorm.io/driver/postgres"
@@ -10,86 +13,158 @@ import (
 	"log"
 	"os"
 	"salesforge-assignment/internal/config"
-	"salesforge-assignment/internal/handler"
 	"salesforge-assignment/internal/logger"
-	"salesforge-assignment/internal/middleware"
-	"salesforge-assignment/internal/middleware/auth"
-	"salesforge-assignment/internal/repository"
-	"salesforge-assignment/internal/service"
+	"salesforge-assignment/internal/retry"
+	"salesforge-assignment/internal/tracing"
+	"strconv"
+	"strings"
+	"time"
 )
 
+const usage = `Usage: server [command] [flags]
+
+Commands:
+  serve                                  run the API server (default)
+  migrate up|down|status|check           apply, revert or list the schema migrations, or
+                                         compare them with the models
+  seed                                   load development data from a YAML fixture
+  user create|reset-password|disable     manage the users that can log in
+
+Run "server <command> -h" for the flags of a command, which include one per
+configuration setting, e.g. -server.port=8081.
+`
+
 func main() {
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, relying on OS environment variables.")
 	}
 
-	cfg, err := config.LoadConfig("server.cfg.yaml")
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
 
-	if err != nil {
-		panic("FATAL: could not load server configuration")
+	switch command {
+	case "serve":
+		serve(args)
+	case "migrate":
+		migrateCommand(args)
+	case "seed":
+		seedCommand(args)
+	case "user":
+		userCommand(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
 	}
+}
+
+// commandFlags declares the flags shared by all commands, to which Load adds
+// one per setting.
+func commandFlags(command string, errorHandling flag.ErrorHandling) (*flag.FlagSet, *bool) {
+	flags := flag.NewFlagSet("server "+command, errorHandling)
+	printConfig := flags.Bool("print-config", false, "print the effective configuration with secrets masked and exit")
+	return flags, printConfig
+}
 
-	log := logger.InitLogger(cfg.Log.Level, cfg.Log.Pretty)
-
-	db := OpenDbConnection(log)
-
-	credentialsRepo := repository.NewCredentialsRepository(log, db)
-	formRepo := repository.NewFormRepository(log, db)
-	apiService := service.NewFormService(log, credentialsRepo, formRepo, cfg)
-
-	apiHandler := handler.NewFormHandler(apiService)
-
-	r := gin.New()
-
-	r.Use(middleware.InjectLogger(log))
-	r.Use(middleware.GinLogger())
-	r.Use(gin.Recovery())
-
-	baseGroup := r.Group(cfg.Server.BaseURL)
-	{
-		// --- Public Routes ---
-		baseGroup.POST("/login", apiHandler.LoginUser)
-
-		// --- Protected Routes ---
-		// Create a new group for all routes that require a valid JWT.
-		protected := baseGroup.Group("/")
-		protected.Use(auth.AuthMiddleware())
-		{
-			protected.POST("/form", apiHandler.CreateForm)
-			protected.GET("/form/:formId", func(c *gin.Context) {
-				apiHandler.GetFormById(c, c.Param("formId"))
-			})
-			protected.PATCH("/form/:formId", func(c *gin.Context) {
-				apiHandler.UpdateFormById(c, c.Param("formId"))
-			})
-			protected.DELETE("/form/:formId/steps/:stepId", func(c *gin.Context) {
-				apiHandler.DeleteFormStepById(c, c.Param("formId"), c.Param("stepId"))
-			})
-			protected.GET("/form/:formId/steps/:stepId", func(c *gin.Context) {
-				apiHandler.GetFormStepById(c, c.Param("formId"), c.Param("stepId"))
-			})
-			protected.PATCH("/form/:formId/steps/:stepId", func(c *gin.Context) {
-				apiHandler.UpdateFormStepById(c, c.Param("formId"), c.Param("stepId"))
-			})
+// loadConfig reads the configuration from the file, environment and flags,
+// exiting when it is invalid or was only to be printed.
+func loadConfig(flags *flag.FlagSet, printConfig *bool, args []string) *config.Config {
+	cfg, err := config.Load(flags, args, os.LookupEnv)
+	if err != nil {
+		log.Fatalf("Failed to load the configuration: %v", err)
+	}
+	if *printConfig {
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			log.Fatalf("Failed to print the configuration: %v", err)
 		}
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	if *printConfig {
+		os.Exit(0)
+	}
+	return cfg
+}
 
-	serverAddr := fmt.Sprintf(":%d", cfg.Server.Port)
-	log.Info().Msgf("Server starting on %s", serverAddr)
-	r.Run(serverAddr)
-
+// setupCommand prepares the commands other than serve, which log and use the
+// database like the server does.
+func setupCommand(flags *flag.FlagSet, printConfig *bool, args []string) (*config.Config, *zerolog.Logger, *gorm.DB) {
+	cfg := loadConfig(flags, printConfig, args)
+	log := logger.InitLogger(cfg.Log)
+	zerolog.DefaultContextLogger = log
+	db, err := OpenDbConnection(context.Background(), log, cfg.Database)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to the database")
+	}
+	return cfg, log, db
 }
 
-func OpenDbConnection(log *zerolog.Logger) *gorm.DB {
-	dbDSN := os.Getenv("DATABASE_DSN")
-	log.Info().Msgf("Connecting to database with DSN: %s", dbDSN)
+const (
+	connectInitialBackoff = 500 * time.Millisecond
+	connectMaxBackoff     = 10 * time.Second
+	pingTimeout           = 5 * time.Second
+)
 
-	db, err := gorm.Open(postgres.Open(dbDSN), &gorm.Config{})
+// OpenDbConnection opens the connection pool and waits for the database to
+// accept connections, retrying with backoff for up to cfg.ConnectTimeout or
+// until ctx is done, so that the server can start along with the database.
+func OpenDbConnection(ctx context.Context, log *zerolog.Logger, cfg config.DatabaseConfig) (*gorm.DB, error) {
+	connConfig, err := pgx.ParseConfig(cfg.DSN)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to connect to the database")
+		return nil, fmt.Errorf("invalid database DSN: %w", err)
+	}
+	log.Info().
+		Str("host", connConfig.Host).
+		Str("database", connConfig.Database).
+		Msg("Connecting to database")
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+	sqlDB := stdlib.OpenDB(*connConfig)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	if cfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	deadline := time.Now().Add(cfg.ConnectTimeout)
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		err = sqlDB.PingContext(pingCtx)
+		cancel()
+		if err == nil {
+			break
+		}
+
+		delay := retry.Backoff(attempt, connectInitialBackoff, connectMaxBackoff)
+		if time.Now().Add(delay).After(deadline) {
+			sqlDB.Close()
+			return nil, fmt.Errorf("database not reachable after %d attempts: %w", attempt, err)
+		}
+		log.Warn().Err(err).Dur("retryIn", delay).Msg("Database is not reachable yet")
+		select {
+		case <-ctx.Done():
+			sqlDB.Close()
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to trace database queries: %w", err)
 	}
 
 	log.Info().Msg("Database connection established successfully")
 
-	return db
+	return db, nil
 }
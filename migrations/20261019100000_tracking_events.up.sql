@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS public.tracking_events
+(
+    id          UUID        NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    event_type  TEXT        NOT NULL,
+    form_id     UUID        NOT NULL,
+    step_id     UUID        NOT NULL,
+    recipient   TEXT        NOT NULL,
+    user_agent  TEXT        NOT NULL DEFAULT '',
+    ip_hash     TEXT        NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT fk_tracking_events_form
+        FOREIGN KEY (form_id) REFERENCES public.form (id) ON DELETE CASCADE
+);
+
+-- Used for de-duplicating repeat events of the same recipient
+CREATE INDEX IF NOT EXISTS idx_tracking_events_recipient
+    ON public.tracking_events (form_id, step_id, recipient, event_type, occurred_at DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE public.tracking_events
+    ADD COLUMN IF NOT EXISTS url TEXT NOT NULL DEFAULT '';
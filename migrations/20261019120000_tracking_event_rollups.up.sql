@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS public.tracking_event_rollups
+(
+    form_id      UUID        NOT NULL,
+    step_id      UUID        NOT NULL,
+    event_type   TEXT        NOT NULL,
+    bucket_start TIMESTAMPTZ NOT NULL,
+    events       BIGINT      NOT NULL DEFAULT 0,
+    PRIMARY KEY (form_id, step_id, event_type, bucket_start),
+    CONSTRAINT fk_tracking_event_rollups_form
+        FOREIGN KEY (form_id) REFERENCES public.form (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.tracking_event_rollup_state
+(
+    id              INTEGER     NOT NULL DEFAULT 1 PRIMARY KEY CHECK (id = 1),
+    rolled_up_until TIMESTAMPTZ NOT NULL
+);
+
+-- Used by the analytics queries on the raw events
+CREATE INDEX IF NOT EXISTS idx_tracking_events_form_occurred_at
+    ON public.tracking_events (form_id, occurred_at);
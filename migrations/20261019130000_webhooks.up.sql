@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS public.webhooks
+(
+    id          UUID        NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    form_id     UUID,
+    url         TEXT        NOT NULL,
+    secret      TEXT        NOT NULL,
+    event_types JSONB       NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT fk_webhooks_form
+        FOREIGN KEY (form_id) REFERENCES public.form (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.webhook_deliveries
+(
+    id              UUID        NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    webhook_id      UUID        NOT NULL,
+    event_id        UUID        NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         TEXT        NOT NULL,
+    status          TEXT        NOT NULL DEFAULT 'pending',
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT fk_webhook_deliveries_webhook
+        FOREIGN KEY (webhook_id) REFERENCES public.webhooks (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.webhook_delivery_attempts
+(
+    id           UUID        NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    delivery_id  UUID        NOT NULL,
+    attempted_at TIMESTAMPTZ NOT NULL,
+    status_code  INTEGER,
+    error        TEXT        NOT NULL DEFAULT '',
+    duration_ms  BIGINT      NOT NULL,
+    CONSTRAINT fk_webhook_delivery_attempts_delivery
+        FOREIGN KEY (delivery_id) REFERENCES public.webhook_deliveries (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_form_id ON public.webhooks (form_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON public.webhook_deliveries (webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON public.webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery_id ON public.webhook_delivery_attempts (delivery_id);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS public.outbox_events
+(
+    id              UUID        NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    sequence        BIGSERIAL   NOT NULL UNIQUE,
+    form_id         UUID        NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         JSONB       NOT NULL,
+    occurred_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at    TIMESTAMPTZ,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error      TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON public.outbox_events (sequence) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_form_pending ON public.outbox_events (form_id, sequence) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON public.outbox_events (published_at) WHERE published_at IS NOT NULL;
+
+-- Events are published at least once, so a relayed event must not queue a second delivery to the same webhook
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_event ON public.webhook_deliveries (webhook_id, event_id);
@@ -0,0 +1,2 @@
+ALTER TABLE public.form ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE public.form_steps ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
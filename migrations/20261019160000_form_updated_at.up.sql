@@ -0,0 +1 @@
+ALTER TABLE public.form ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.idempotency_keys
+(
+    user_id       TEXT        NOT NULL,
+    key           TEXT        NOT NULL,
+    request_hash  TEXT        NOT NULL,
+    status_code   INTEGER,
+    content_type  TEXT        NOT NULL DEFAULT '',
+    response_body BYTEA,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON public.idempotency_keys (expires_at);
@@ -0,0 +1,2 @@
+ALTER TABLE authz.credentials
+    ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+-- Names were declared unique by the models but never by the schema, so
+-- creating a form or step with a taken name did not fail with a conflict
+CREATE UNIQUE INDEX IF NOT EXISTS uni_form_name ON public.form (name);
+CREATE UNIQUE INDEX IF NOT EXISTS uni_form_steps_name ON public.form_steps (name);
@@ -0,0 +1,3 @@
+-- Existing users keep changing forms and webhooks as before
+ALTER TABLE authz.credentials
+    ADD COLUMN IF NOT EXISTS roles JSONB NOT NULL DEFAULT '["editor"]';
@@ -0,0 +1,20 @@
+-- Distinct recipients per form step, event type and hour, so that unique
+-- counts over rolled up hours do not scan the raw events
+CREATE TABLE IF NOT EXISTS public.tracking_event_recipient_rollups
+(
+    form_id      UUID        NOT NULL,
+    event_type   TEXT        NOT NULL,
+    bucket_start TIMESTAMPTZ NOT NULL,
+    step_id      UUID        NOT NULL,
+    recipient    TEXT        NOT NULL,
+    PRIMARY KEY (form_id, event_type, bucket_start, step_id, recipient),
+    CONSTRAINT fk_tracking_event_recipient_rollups_form
+        FOREIGN KEY (form_id) REFERENCES public.form (id) ON DELETE CASCADE
+);
+
+-- Backfills the hours rolled up so far
+INSERT INTO public.tracking_event_recipient_rollups (form_id, event_type, bucket_start, step_id, recipient)
+SELECT DISTINCT t.form_id, t.event_type, date_trunc('hour', t.occurred_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', t.step_id, t.recipient
+FROM public.tracking_events t, public.tracking_event_rollup_state s
+WHERE s.id = 1 AND t.occurred_at < s.rolled_up_until
+ON CONFLICT DO NOTHING;
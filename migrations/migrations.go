@@ -0,0 +1,69 @@
+// Package migrations embeds the SQL migrations of the database schema, so
+// that the binary knows which schema version it expects and can apply them.
+package migrations
+
+import (
+	"cmp"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.up.sql rollback/*.down.sql
+var FS embed.FS
+
+// Migration is one step of the schema, named after its file, e.g.
+// 20250618144159_initial_schema.up.sql.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	// Down reverts Up, empty when the migration cannot be reverted
+	Down string
+}
+
+// All returns the migrations ordered by version.
+func All() ([]Migration, error) {
+	files, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]Migration, 0, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(file, ".up.sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version: %w", file, err)
+		}
+		up, err := fs.ReadFile(FS, file)
+		if err != nil {
+			return nil, err
+		}
+		down, err := fs.ReadFile(FS, path.Join("rollback", name+".down.sql"))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		all = append(all, Migration{Version: version, Name: name, Up: string(up), Down: string(down)})
+	}
+	slices.SortFunc(all, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return all, nil
+}
+
+// LatestVersion returns the version of the newest migration, which is the
+// schema version the application is written against.
+func LatestVersion() (uint64, error) {
+	all, err := All()
+	if err != nil || len(all) == 0 {
+		return 0, err
+	}
+	return all[len(all)-1].Version, nil
+}
@@ -0,0 +1,2 @@
+ALTER TABLE public.tracking_events
+    DROP COLUMN IF EXISTS url;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.idx_tracking_events_form_occurred_at;
+DROP TABLE IF EXISTS public.tracking_event_rollup_state;
+DROP TABLE IF EXISTS public.tracking_event_rollups;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.idx_webhook_deliveries_webhook_event;
+DROP TABLE IF EXISTS public.outbox_events;
@@ -0,0 +1,2 @@
+ALTER TABLE public.form_steps DROP COLUMN IF EXISTS version;
+ALTER TABLE public.form DROP COLUMN IF EXISTS version;
@@ -0,0 +1 @@
+ALTER TABLE public.form DROP COLUMN IF EXISTS updated_at;
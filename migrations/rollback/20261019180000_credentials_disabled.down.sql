@@ -0,0 +1 @@
+ALTER TABLE authz.credentials DROP COLUMN IF EXISTS disabled;
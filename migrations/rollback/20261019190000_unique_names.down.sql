@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.uni_form_steps_name;
+DROP INDEX IF EXISTS public.uni_form_name;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.tracking_event_recipient_rollups;
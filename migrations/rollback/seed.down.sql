@@ -1 +0,0 @@
-DELETE FROM authz.credentials WHERE username IN ('admin', 'testuser');
\ No newline at end of file
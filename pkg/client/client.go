@@ -0,0 +1,281 @@
+// Package client is a Go client of the forms API. The operations and types
+// are generated from openapi.yaml; New wraps them with authentication,
+// retries and typed errors.
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"io"
+	"net/http"
+	"salesforge-assignment/internal/retry"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	acceptHeader         = "application/problem+json, application/json"
+
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 200 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+	// tokenExpiryMargin renews tokens shortly before they expire, so that
+	// requests in flight do not fail
+	tokenExpiryMargin = 30 * time.Second
+)
+
+// Option configures a client returned by New.
+type Option func(*transport)
+
+// WithCredentials logs in with the credentials before the first request and
+// again whenever the token expires or is rejected.
+func WithCredentials(username string, password string) Option {
+	return func(t *transport) {
+		t.credentials = &Authentication{Username: username, Password: password}
+	}
+}
+
+// WithToken authenticates with a token obtained elsewhere.
+func WithToken(token string) Option {
+	return func(t *transport) {
+		t.token = token
+	}
+}
+
+// WithHTTPDoer sends requests with the doer instead of the default client,
+// which traces requests with the global OpenTelemetry provider and passes
+// the trace context of the request context on to the API.
+func WithHTTPDoer(doer HttpRequestDoer) Option {
+	return func(t *transport) {
+		t.doer = doer
+	}
+}
+
+// WithRetry sets how often requests failing with 429, 5xx or a network error
+// are attempted, and the backoff between attempts. One attempt disables
+// retries.
+func WithRetry(maxAttempts int, initialBackoff time.Duration, maxBackoff time.Duration) Option {
+	return func(t *transport) {
+		t.maxAttempts = maxAttempts
+		t.initialBackoff = initialBackoff
+		t.maxBackoff = maxBackoff
+	}
+}
+
+// New returns a client of the API at server, e.g.
+// https://forms.example.com/api/v1. Responses with a status of 400 or above
+// are returned as *Error, so the JSON4xx fields of the responses are never
+// set.
+func New(server string, opts ...Option) (*ClientWithResponses, error) {
+	t := &transport{
+		server:         server,
+		doer:           &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.maxAttempts < 1 {
+		t.maxAttempts = 1
+	}
+	return NewClientWithResponses(server, WithHTTPClient(t))
+}
+
+// transport is the HttpRequestDoer of the generated client. It adds the
+// token, retries failed requests and turns error responses into *Error.
+type transport struct {
+	server         string
+	doer           HttpRequestDoer
+	credentials    *Authentication
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	mu             sync.Mutex
+	token          string
+	tokenExpiresAt time.Time
+}
+
+func (t *transport) Do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Accept", acceptHeader)
+	// A key makes retrying a POST safe, the server replays the first response
+	if req.Method == http.MethodPost && t.maxAttempts > 1 && req.Header.Get(idempotencyKeyHeader) == "" {
+		req.Header.Set(idempotencyKeyHeader, uuid.NewString())
+	}
+
+	reauthenticated, sent := false, false
+	for attempt := 1; ; attempt++ {
+		if err := t.authorize(req); err != nil {
+			return nil, err
+		}
+		// Logging in again does not count as an attempt, so the body is
+		// restored whenever the request was sent before
+		if sent {
+			if err := rewind(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.doer.Do(req)
+		sent = true
+		if err != nil {
+			if attempt >= t.maxAttempts || req.Context().Err() != nil {
+				return nil, err
+			}
+			if err := t.wait(req.Context(), attempt, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && t.credentials != nil && !reauthenticated:
+			// The token may have been revoked or signed with a rotated key
+			discard(resp)
+			t.expireToken()
+			reauthenticated = true
+			attempt--
+		case retryable(resp.StatusCode) && attempt < t.maxAttempts:
+			discard(resp)
+			if err := t.wait(req.Context(), attempt, resp); err != nil {
+				return nil, err
+			}
+		case resp.StatusCode >= http.StatusBadRequest:
+			return nil, newError(resp)
+		default:
+			return resp, nil
+		}
+	}
+}
+
+// login obtains a token with the credentials of the client.
+func (t *transport) login(ctx context.Context) (string, time.Time, error) {
+	req, err := NewLoginUserRequest(t.server, *t.credentials)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", acceptHeader)
+
+	resp, err := t.doer.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, newError(resp)
+	}
+
+	var body AuthenticationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", time.Time{}, err
+	}
+	return body.Token, tokenExpiry(body.Token), nil
+}
+
+func (t *transport) authorize(req *http.Request) error {
+	if t.isLogin(req) {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.credentials != nil && (t.token == "" || t.expiresSoon()) {
+		token, expiresAt, err := t.login(req.Context())
+		if err != nil {
+			return err
+		}
+		t.token, t.tokenExpiresAt = token, expiresAt
+	}
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	return nil
+}
+
+func (t *transport) expiresSoon() bool {
+	return !t.tokenExpiresAt.IsZero() && time.Until(t.tokenExpiresAt) < tokenExpiryMargin
+}
+
+func (t *transport) expireToken() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.token = ""
+}
+
+func (t *transport) isLogin(req *http.Request) bool {
+	return req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/login")
+}
+
+// wait sleeps before the next attempt, as long as the server asked for with
+// Retry-After or else the backoff.
+func (t *transport) wait(ctx context.Context, attempt int, resp *http.Response) error {
+	delay := retry.Backoff(attempt, t.initialBackoff, t.maxBackoff)
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay = min(time.Duration(seconds)*time.Second, t.maxBackoff)
+		}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// rewind restores the body of a request that is sent again.
+func rewind(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+func discard(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// tokenExpiry reads the expiry of a JWT without verifying it, which is the
+// server's job. A zero time means the token is renewed only once rejected.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.ExpiresAt, 0)
+}
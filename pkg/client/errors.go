@@ -0,0 +1,147 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// Code is the stable, machine-readable code of an API error.
+type Code string
+
+// The codes mirror those of the server and never change meaning.
+const (
+	CodeInternal            Code = "internal_error"
+	CodePermissionDenied    Code = "permission_denied"
+	CodeInvalidInput        Code = "invalid_input"
+	CodeInvalidRequestBody  Code = "invalid_request_body"
+	CodeValidationFailed    Code = "validation_failed"
+	CodeResourceNotFound    Code = "resource_not_found"
+	CodeMethodNotAllowed    Code = "method_not_allowed"
+	CodePreconditionFailed  Code = "precondition_failed"
+	CodeConflict            Code = "conflict"
+	CodeConcurrentUpdate    Code = "concurrent_update"
+	CodeUnprocessableEntity Code = "unprocessable_entity"
+	CodeInvalidCredentials  Code = "invalid_credentials"
+	CodeUnauthorized        Code = "unauthorized"
+)
+
+// Sentinels to test errors of the client with errors.Is, e.g.
+// errors.Is(err, client.ErrResourceNotFound).
+var (
+	ErrInternal            = &Error{Code: CodeInternal}
+	ErrPermissionDenied    = &Error{Code: CodePermissionDenied}
+	ErrInvalidInput        = &Error{Code: CodeInvalidInput}
+	ErrInvalidRequestBody  = &Error{Code: CodeInvalidRequestBody}
+	ErrValidationFailed    = &Error{Code: CodeValidationFailed}
+	ErrResourceNotFound    = &Error{Code: CodeResourceNotFound}
+	ErrMethodNotAllowed    = &Error{Code: CodeMethodNotAllowed}
+	ErrPreconditionFailed  = &Error{Code: CodePreconditionFailed}
+	ErrConflict            = &Error{Code: CodeConflict}
+	ErrConcurrentUpdate    = &Error{Code: CodeConcurrentUpdate}
+	ErrUnprocessableEntity = &Error{Code: CodeUnprocessableEntity}
+	ErrInvalidCredentials  = &Error{Code: CodeInvalidCredentials}
+	ErrUnauthorized        = &Error{Code: CodeUnauthorized}
+)
+
+// statusCodes are the codes of error responses without problem details.
+var statusCodes = map[int]Code{
+	http.StatusBadRequest:            CodeInvalidInput,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodePermissionDenied,
+	http.StatusNotFound:              CodeResourceNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusPreconditionFailed:    CodePreconditionFailed,
+	http.StatusUnprocessableEntity:   CodeUnprocessableEntity,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusServiceUnavailable:    CodeInternal,
+	http.StatusTooManyRequests:       CodeInternal,
+	http.StatusRequestEntityTooLarge: CodeInvalidRequestBody,
+}
+
+// Error is an error response of the API.
+type Error struct {
+	StatusCode int
+	Code       Code
+	Message    string
+	// Field is the request field the error is about, if known
+	Field string
+	// Errors lists the fields that failed validation
+	Errors    []ValidationError
+	RequestID string
+}
+
+func (err *Error) Error() string {
+	message := fmt.Sprintf("forms api: %d %s", err.StatusCode, err.Code)
+	if err.Message != "" {
+		message += ": " + err.Message
+	}
+	if err.RequestID != "" {
+		message += " (request " + err.RequestID + ")"
+	}
+	return message
+}
+
+// Is matches errors with the same code, so the sentinels match every
+// occurrence.
+func (err *Error) Is(target error) bool {
+	other, ok := target.(*Error)
+	return ok && other.Code == err.Code
+}
+
+// newError reads an error response, preferring problem details.
+func newError(resp *http.Response) error {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	err := &Error{
+		StatusCode: resp.StatusCode,
+		Code:       statusCodes[resp.StatusCode],
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+	if err.Code == "" {
+		err.Code = CodeInternal
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/problem+json":
+		var problem Problem
+		if json.Unmarshal(body, &problem) == nil {
+			err.Code = Code(problem.Code)
+			err.Message = stringValue(problem.Detail)
+			err.Field = stringValue(problem.Field)
+			if problem.Errors != nil {
+				err.Errors = *problem.Errors
+			}
+			if problem.RequestId != nil {
+				err.RequestID = *problem.RequestId
+			}
+		}
+	case "application/json":
+		var legacy struct {
+			Message string            `json:"message"`
+			Field   *string           `json:"field"`
+			Errors  []ValidationError `json:"errors"`
+		}
+		if json.Unmarshal(body, &legacy) == nil {
+			err.Message = legacy.Message
+			err.Field = stringValue(legacy.Field)
+			err.Errors = legacy.Errors
+			if len(legacy.Errors) > 0 {
+				err.Code = CodeValidationFailed
+			}
+		}
+	}
+	return err
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// WebhookDeliveries iterates over all deliveries of a webhook, newest first,
+// fetching pageSize deliveries at a time. Iteration stops at the first
+// error, which is yielded with a zero delivery.
+func WebhookDeliveries(
+	ctx context.Context,
+	c ClientWithResponsesInterface,
+	webhookId string,
+	pageSize int,
+) iter.Seq2[WebhookDeliveryResponseGet, error] {
+	return func(yield func(WebhookDeliveryResponseGet, error) bool) {
+		var zero WebhookDeliveryResponseGet
+		for page := 1; ; page++ {
+			params := &ListWebhookDeliveriesParams{Page: &page, PageSize: &pageSize}
+			resp, err := c.ListWebhookDeliveriesWithResponse(ctx, webhookId, params)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if resp.JSON200 == nil {
+				yield(zero, fmt.Errorf("forms api: unexpected response %s", resp.Status()))
+				return
+			}
+
+			for _, delivery := range resp.JSON200.Items {
+				if !yield(delivery, nil) {
+					return
+				}
+			}
+			seen := int64((page-1)*resp.JSON200.PageSize + len(resp.JSON200.Items))
+			if len(resp.JSON200.Items) == 0 || seen >= resp.JSON200.Total {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"salesforge-assignment/internal/analytics"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/cache"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/docs"
+	"salesforge-assignment/internal/events"
+	"salesforge-assignment/internal/handler"
+	"salesforge-assignment/internal/health"
+	"salesforge-assignment/internal/idempotency"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/metrics"
+	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/middleware/auth"
+	"salesforge-assignment/internal/migrate"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/openapi"
+	"salesforge-assignment/internal/problem"
+	"salesforge-assignment/internal/ratelimit"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/service"
+	"salesforge-assignment/internal/tracing"
+	"salesforge-assignment/internal/webhook"
+	"salesforge-assignment/internal/worker"
+	"salesforge-assignment/migrations"
+	"syscall"
+	"time"
+)
+
+// serve runs the API server until SIGINT or SIGTERM.
+func serve(args []string) {
+	flags, printConfig := commandFlags("serve", flag.ExitOnError)
+	cfg := loadConfig(flags, printConfig, args)
+
+	baseLog := logger.InitLogger(cfg.Log).Hook(tracing.LogHook{})
+	log := &baseLog
+	// Fallback of zerolog.Ctx for contexts that do not come from a request
+	zerolog.DefaultContextLogger = log
+	liveConfig := config.NewLive(log, cfg, func() (*config.Config, error) {
+		flags, _ := commandFlags("serve", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		return config.Load(flags, args, os.LookupEnv)
+	})
+	liveConfig.OnReload(func(cfg *config.Config) {
+		logger.SetLevel(cfg.Log.Level)
+	})
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up tracing")
+	}
+
+	// Signals received while starting, e.g. while waiting for the database,
+	// stop the server once it runs
+	stop, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db, err := OpenDbConnection(stop, log, cfg.Database)
+	if err != nil {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error().Err(err).Msg("Failed to flush traces")
+		}
+		if stop.Err() != nil {
+			log.Info().Msg("Server stopped while connecting to the database")
+			return
+		}
+		log.Fatal().Err(err).Msg("Failed to connect to the database")
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to access the database pool")
+	}
+	migrationRepo := repository.NewMigrationRepository(log, db)
+	if cfg.Database.MigrateOnStartup {
+		migrateOnStartup(log, migrationRepo)
+	}
+	reportSchemaDrift(log, migrationRepo)
+	workers := worker.NewGroup(log)
+
+	credentialsRepo := repository.NewTracedCredentialsRepository(repository.NewCredentialsRepository(log, db))
+	formRepo := repository.NewFormRepository(log, db)
+	if cfg.Cache.Forms.Size > 0 {
+		forms := cache.NewLRU[string, model.FormModel](cfg.Cache.Forms.Size, cfg.Cache.Forms.TTL)
+		cachedFormRepo := repository.NewCachedFormRepository(log, db, formRepo, forms, cfg.Cache.InvalidationChannel)
+		workers.Go("form-cache-listener", func(ctx context.Context) {
+			cachedFormRepo.Listen(ctx, cfg.Database.DSN)
+		})
+		formRepo = cachedFormRepo
+	}
+	// Traced outside the cache, so that cache hits show in traces too
+	formRepo = repository.NewTracedFormRepository(formRepo)
+	trackingRepo := repository.NewTracedTrackingRepository(repository.NewTrackingRepository(log, db))
+	analyticsRepo := repository.NewTracedAnalyticsRepository(repository.NewAnalyticsRepository(log, db))
+	webhookRepo := repository.NewTracedWebhookRepository(repository.NewWebhookRepository(log, db))
+	outboxRepo := repository.NewTracedOutboxRepository(repository.NewOutboxRepository(log, db))
+	txManager := repository.NewTxManager(log, db)
+	webhookService := service.NewWebhookService(webhookRepo, formRepo, cfg)
+	apiService := service.NewTracedFormService(
+		service.NewFormService(log, credentialsRepo, formRepo, outboxRepo, txManager, cfg))
+	trackingService := service.NewTrackingService(log, formRepo, trackingRepo, outboxRepo, txManager, cfg)
+	analyticsService := service.NewAnalyticsService(formRepo, analyticsRepo, cfg)
+
+	apiHandler := handler.NewFormHandler(apiService, trackingService, analyticsService, webhookService)
+
+	aggregator := analytics.NewAggregator(log, analyticsRepo, cfg.Analytics.RollupInterval)
+	workers.Go("analytics-aggregator", aggregator.Run)
+
+	dispatcher := webhook.NewDispatcher(log, webhookRepo, cfg.Webhooks)
+	dispatcher.SetEnabled(func() bool { return liveConfig.Current().Enabled(config.FeatureWebhookDeliveries) })
+	workers.Go("webhook-dispatcher", dispatcher.Run)
+
+	bus := events.NewBus()
+	bus.Subscribe(webhookService.HandleEvent)
+	sinks := []events.Sink{bus}
+	var natsSink *events.NATSSink
+	if cfg.Outbox.NATS.URL != "" {
+		natsSink, err = events.NewNATSSink(cfg.Outbox.NATS.URL, cfg.Outbox.NATS.SubjectPrefix)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to connect to NATS")
+		}
+		sinks = append(sinks, natsSink)
+	}
+	var kafkaSink *events.KafkaSink
+	if len(cfg.Outbox.Kafka.Brokers) > 0 {
+		kafkaSink = events.NewKafkaSink(events.NewKafkaWriter(cfg.Outbox.Kafka.Brokers, cfg.Outbox.Kafka.Topic))
+		sinks = append(sinks, kafkaSink)
+	}
+	idempotencyRepo := repository.NewTracedIdempotencyRepository(repository.NewIdempotencyRepository(log, db))
+	idempotencyGuard := idempotency.NewGuard(log, idempotencyRepo, cfg.Idempotency)
+	workers.Go("idempotency-pruner", idempotencyGuard.Run)
+
+	relay := events.NewRelay(log, txManager, outboxRepo, sinks, cfg.Outbox)
+	workers.Go("outbox-relay", relay.Run)
+	workers.Go("config-watcher", liveConfig.Watch)
+
+	latestMigration, err := migrations.LatestVersion()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to read the embedded migrations")
+	}
+	healthHandler := health.NewHandler(log)
+	healthHandler.AddCheck("database", health.Database(sqlDB))
+	healthHandler.AddCheck("migrations", health.Migrations(migrationRepo, latestMigration))
+	healthHandler.AddCheck("workers", workers.Check)
+	healthHandler.ReportConfigVersion(func() uint64 { return liveConfig.Current().Version })
+
+	if err := metrics.RegisterDatabase(metrics.Registry, log, sqlDB, formRepo); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register database metrics")
+	}
+
+	specValidator, err := openapi.NewValidator(log, cfg.Validation)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load the OpenAPI spec")
+	}
+	docsHandler, err := docs.NewHandler(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to render the OpenAPI spec")
+	}
+
+	r := gin.New()
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(problem.NoRoute())
+	r.NoMethod(problem.NoMethod())
+
+	r.Use(middleware.RequestID())
+	r.Use(metrics.Middleware())
+	r.Use(middleware.InjectLogger(log))
+	r.Use(tracing.Middleware())
+	r.Use(middleware.GinLogger())
+	r.Use(problem.Recovery())
+	r.Use(middleware.CORS(func() config.CORSConfig { return liveConfig.Current().CORS }))
+	r.Use(middleware.CacheControl(cfg.Server.BaseURL, cfg.Cache.Control))
+
+	healthHandler.Register(r)
+	if cfg.Metrics.Address == "" {
+		r.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
+	docsHandler.Register(r)
+
+	operations, err := openapi.NewOperations(cfg.Server.BaseURL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load the OpenAPI spec")
+	}
+	security, err := operations.Security(map[string]openapi.Authenticator{auth.BearerScheme: auth.Authenticate(cfg.Auth.JWTSecret)})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to derive security from the OpenAPI spec")
+	}
+	rateLimits, err := operations.RateLimits(ratelimit.NewLimiter(func() map[string]config.RateLimitConfig {
+		return liveConfig.Current().RateLimits
+	}).Check)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to derive rate limits from the OpenAPI spec")
+	}
+	features, err := operations.Features(func(name string) bool { return liveConfig.Current().Enabled(name) })
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to derive features from the OpenAPI spec")
+	}
+
+	// The query deadline starts once a request is authenticated and valid,
+	// and leaves out the probes, docs and metrics registered above
+	apiGroup := r.Group(cfg.Server.BaseURL)
+	apiGroup.Use(security)
+	apiGroup.Use(features)
+	apiGroup.Use(rateLimits)
+	apiGroup.Use(specValidator.Middleware())
+	apiGroup.Use(middleware.QueryDeadline(cfg.Database.QueryTimeout))
+	apiGroup.Use(operations.WithHeader(idempotency.KeyHeader, idempotencyGuard.Middleware()))
+
+	api.RegisterHandlersWithOptions(apiGroup, apiHandler, api.GinServerOptions{
+		ErrorHandler: problem.BindingErrorHandler,
+	})
+	if err := operations.CheckRoutes(r.Routes()); err != nil {
+		log.Fatal().Err(err).Msg("The router does not match the OpenAPI spec")
+	}
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler: r,
+	}
+	go func() {
+		log.Info().Msgf("Server starting on %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal().Err(err).Msg("Server failed")
+		}
+	}()
+
+	var metricsServer *http.Server
+	if cfg.Metrics.Address != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", metrics.Handler())
+		metricsServer = &http.Server{Addr: cfg.Metrics.Address, Handler: metricsMux}
+		go func() {
+			log.Info().Msgf("Metrics server starting on %s", metricsServer.Addr)
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal().Err(err).Msg("Metrics server failed")
+			}
+		}()
+	}
+
+	<-stop.Done()
+
+	shutdownTimeout := cfg.Server.ShutdownTimeout
+	log.Info().Dur("timeout", shutdownTimeout).Dur("drainDelay", cfg.Server.DrainDelay).Msg("Shutting down")
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	// Keep serving while the load balancers notice that /readyz fails
+	healthHandler.Drain()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	// Stop accepting requests and wait for those in flight, then for the
+	// workers, so that nothing uses the connections closed last
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("Failed to drain requests")
+	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("Failed to stop the metrics server")
+		}
+	}
+	if err := workers.Stop(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("Failed to stop workers")
+	}
+	if natsSink != nil {
+		natsSink.Close()
+	}
+	if kafkaSink != nil {
+		if err := kafkaSink.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to flush the Kafka writer")
+		}
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("Failed to flush traces")
+	}
+	if err := sqlDB.Close(); err != nil {
+		log.Error().Err(err).Msg("Failed to close the database pool")
+	}
+	log.Info().Msg("Server stopped")
+}
+
+// migrateOnStartup applies the pending migrations. Replicas starting together
+// wait for the first one to finish, then find nothing left to apply.
+func migrateOnStartup(log *zerolog.Logger, repo repository.MigrationRepository) {
+	all, err := migrations.All()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to read the embedded migrations")
+	}
+	applied, err := migrate.NewMigrator(log, repo, all).Up(log.WithContext(context.Background()))
+	if err != nil {
+		log.Fatal().Err(err).Int("applied", applied).Msg("Failed to apply migrations")
+	}
+	log.Info().Int("applied", applied).Msg("Schema is up to date")
+}
+
+// reportSchemaDrift warns about models that do not match the schema, which
+// would otherwise only show when a query fails.
+func reportSchemaDrift(log *zerolog.Logger, repo repository.MigrationRepository) {
+	drifts, err := migrate.CheckDrift(log.WithContext(context.Background()), repo, model.Tables()...)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to compare the models with the schema")
+		return
+	}
+	for _, drift := range drifts {
+		log.Warn().Str("table", drift.Table).Str("column", drift.Column).Msgf("Schema drift: %s", drift.Problem)
+	}
+}
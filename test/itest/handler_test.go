@@ -2,18 +2,46 @@ package itest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"golang.org/x/crypto/bcrypt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"salesforge-assignment/internal/analytics"
 	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/events"
+	"salesforge-assignment/internal/idempotency"
+	"salesforge-assignment/internal/middleware"
 	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/problem"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/service"
+	"salesforge-assignment/internal/webhook"
+	"salesforge-assignment/pkg/client"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 func (suite *HandlerIntegrationSuite) performRequest(method, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	return suite.performRequestWithHeaders(method, path, body, token, nil)
+}
+
+func (suite *HandlerIntegrationSuite) performRequestWithHeaders(
+	method, path string,
+	body interface{},
+	token string,
+	headers map[string]string,
+) *httptest.ResponseRecorder {
 	var bodyReader io.Reader
 
 	if body != nil {
@@ -33,6 +61,9 @@ func (suite *HandlerIntegrationSuite) performRequest(method, path string, body i
 	if token != "" {
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 
 	w := httptest.NewRecorder()
 	suite.router.ServeHTTP(w, req)
@@ -57,6 +88,10 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+func stringPtr(s string) *string {
+	return &s
+}
+
 func (suite *HandlerIntegrationSuite) TestLoginUser() {
 	suite.getAuthTokenForTestUser("test@user.com", "password123")
 
@@ -136,11 +171,304 @@ func (suite *HandlerIntegrationSuite) TestFormAndStepsCRUD() {
 	})
 }
 
+func (suite *HandlerIntegrationSuite) TestOptimisticConcurrency() {
+	token, _ := suite.getAuthTokenForTestUser("etag@user.com", "password123")
+	form := suite.createForm(token, api.FormCreate{
+		Name:  "ETag Form",
+		Steps: api.FormStepCreateArray{{Name: "ETag Step", Content: "First", Step: 1}},
+	})
+	formPath := "/form/" + form.Self.Id
+	stepPath := fmt.Sprintf("/form/%s/steps/%s", form.Self.Id, form.Steps[0].Self.Id)
+
+	w := suite.performRequest("GET", formPath, nil, token)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	formTag := w.Header().Get("ETag")
+	suite.Equal(`"1"`, formTag)
+
+	w = suite.performRequest("GET", stepPath, nil, token)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	stepTag := w.Header().Get("ETag")
+	suite.Equal(`"1"`, stepTag)
+
+	suite.Run("Stale If-Match is rejected", func() {
+		update := api.FormUpdate{ClickTrackingEnabled: boolPtr(true)}
+		w := suite.performRequestWithHeaders("PATCH", formPath, update, token, map[string]string{"If-Match": `"7"`})
+		suite.Equal(http.StatusPreconditionFailed, w.Code)
+	})
+
+	suite.Run("Current If-Match updates the form", func() {
+		update := api.FormUpdate{ClickTrackingEnabled: boolPtr(true)}
+		w := suite.performRequestWithHeaders("PATCH", formPath, update, token, map[string]string{"If-Match": formTag})
+		suite.Require().Equal(http.StatusOK, w.Code)
+		suite.Equal(`"2"`, w.Header().Get("ETag"))
+
+		// The tag used for the update is now stale
+		w = suite.performRequestWithHeaders("PATCH", formPath, update, token, map[string]string{"If-Match": formTag})
+		suite.Equal(http.StatusPreconditionFailed, w.Code)
+	})
+
+	suite.Run("Updating a step changes the form version", func() {
+		content := "Updated"
+		w := suite.performRequestWithHeaders("PATCH", stepPath, api.FormStepUpdate{Content: &content}, token,
+			map[string]string{"If-Match": stepTag})
+		suite.Require().Equal(http.StatusOK, w.Code)
+		suite.Equal(`"2"`, w.Header().Get("ETag"))
+
+		w = suite.performRequest("GET", formPath, nil, token)
+		suite.Equal(`"3"`, w.Header().Get("ETag"))
+	})
+
+	suite.Run("Step of another form is not found whatever the If-Match", func() {
+		other := suite.createForm(token, api.FormCreate{
+			Name:  "Other ETag Form",
+			Steps: api.FormStepCreateArray{{Name: "Other ETag Step", Content: "First", Step: 1}},
+		})
+		otherStepPath := fmt.Sprintf("/form/%s/steps/%s", other.Self.Id, form.Steps[0].Self.Id)
+		w := suite.performRequestWithHeaders("DELETE", otherStepPath, nil, token, map[string]string{"If-Match": `"7"`})
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+
+	suite.Run("Stale If-Match does not delete the step", func() {
+		w := suite.performRequestWithHeaders("DELETE", stepPath, nil, token, map[string]string{"If-Match": stepTag})
+		suite.Equal(http.StatusPreconditionFailed, w.Code)
+
+		w = suite.performRequestWithHeaders("DELETE", stepPath, nil, token, map[string]string{"If-Match": `"2"`})
+		suite.Equal(http.StatusNoContent, w.Code)
+	})
+}
+
+// conflictingOutboxRepository fails the first events it records with a
+// serialization failure, as a concurrent transaction would.
+type conflictingOutboxRepository struct {
+	repository.OutboxRepository
+	conflicts int
+}
+
+func (r *conflictingOutboxRepository) CreateEvents(ctx context.Context, events []model.OutboxEventModel) error {
+	if r.conflicts > 0 {
+		r.conflicts--
+		return &pgconn.PgError{Code: "40001"}
+	}
+	return r.OutboxRepository.CreateEvents(ctx, events)
+}
+
+func (suite *HandlerIntegrationSuite) TestUpdatesAreRetriedAfterSerializationFailures() {
+	token, _ := suite.getAuthTokenForTestUser("retry@user.com", "password123")
+	form := suite.createForm(token, api.FormCreate{
+		Name:  "Retried Form",
+		Steps: api.FormStepCreateArray{{Name: "Retried Step", Content: "First", Step: 1}},
+	})
+	outbox := &conflictingOutboxRepository{OutboxRepository: repository.NewOutboxRepository(suite.log, suite.db)}
+	testConfig := &config.Config{}
+	formService := service.NewFormService(suite.log,
+		repository.NewCredentialsRepository(suite.log, suite.db),
+		repository.NewFormRepository(suite.log, suite.db),
+		outbox,
+		repository.NewTxManager(suite.log, suite.db),
+		testConfig)
+	ctx := suite.log.WithContext(context.Background())
+
+	suite.Run("Form update", func() {
+		outbox.conflicts = 1
+		updated, err := formService.UpdateFormById(ctx, form.Self.Id,
+			api.FormUpdate{ClickTrackingEnabled: boolPtr(true)}, `"1"`)
+		suite.Require().NoError(err)
+		suite.Equal(0, outbox.conflicts)
+		suite.Equal(2, updated.Version)
+	})
+
+	suite.Run("Step update", func() {
+		outbox.conflicts = 1
+		content := "Retried"
+		updated, err := formService.UpdateFormStepById(ctx, form.Self.Id, form.Steps[0].Self.Id,
+			api.FormStepUpdate{Content: &content}, `"1"`)
+		suite.Require().NoError(err)
+		suite.Equal(0, outbox.conflicts)
+		suite.Equal(2, updated.Version)
+	})
+}
+
+func (suite *HandlerIntegrationSuite) TestConditionalGet() {
+	token, _ := suite.getAuthTokenForTestUser("conditional@user.com", "password123")
+	form := suite.createForm(token, api.FormCreate{
+		Name:  "Conditional Form",
+		Steps: api.FormStepCreateArray{{Name: "Conditional Step", Content: "First", Step: 1}},
+	})
+	formPath := "/form/" + form.Self.Id
+
+	w := suite.performRequest("GET", formPath, nil, token)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	tag := w.Header().Get("ETag")
+	lastModified := w.Header().Get("Last-Modified")
+	suite.NotEmpty(lastModified)
+	suite.Equal("private, no-cache", w.Header().Get("Cache-Control"))
+
+	suite.Run("Current ETag is not modified", func() {
+		w := suite.performRequestWithHeaders("GET", formPath, nil, token, map[string]string{"If-None-Match": tag})
+		suite.Equal(http.StatusNotModified, w.Code)
+		suite.Empty(w.Body.Bytes())
+		suite.Equal(tag, w.Header().Get("ETag"))
+	})
+
+	suite.Run("Unchanged since Last-Modified is not modified", func() {
+		w := suite.performRequestWithHeaders("GET", formPath, nil, token, map[string]string{"If-Modified-Since": lastModified})
+		suite.Equal(http.StatusNotModified, w.Code)
+	})
+
+	suite.Run("Changes are served after an update", func() {
+		update := api.FormUpdate{ClickTrackingEnabled: boolPtr(true)}
+		suite.Require().Equal(http.StatusOK, suite.performRequest("PATCH", formPath, update, token).Code)
+
+		w := suite.performRequestWithHeaders("GET", formPath, nil, token, map[string]string{"If-None-Match": tag})
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var resp api.FormResponseGet
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.True(resp.ClickTrackingEnabled)
+		suite.NotEqual(tag, w.Header().Get("ETag"))
+	})
+
+	suite.Run("Changes made by another replica are served after its notification", func() {
+		suite.Require().NoError(suite.db.Exec("UPDATE public.form SET name = ?, version = version + 1 WHERE id = ?",
+			"Renamed Elsewhere", form.Self.Id).Error)
+		suite.Require().NoError(suite.db.Exec("SELECT pg_notify(?, ?)", formCacheChannel, form.Self.Id).Error)
+
+		suite.Eventually(func() bool {
+			w := suite.performRequest("GET", formPath, nil, token)
+			var resp api.FormResponseGet
+			return json.Unmarshal(w.Body.Bytes(), &resp) == nil && resp.Name == "Renamed Elsewhere"
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
+
+func (suite *HandlerIntegrationSuite) TestIdempotencyKey() {
+	token, _ := suite.getAuthTokenForTestUser("idempotent@user.com", "password123")
+	req := api.FormCreate{
+		Name:  "Idempotent Form",
+		Steps: api.FormStepCreateArray{{Name: "Idempotent Step", Content: "First", Step: 1}},
+	}
+	headers := map[string]string{idempotency.KeyHeader: "create-form-1"}
+
+	first := suite.performRequestWithHeaders("POST", "/form", req, token, headers)
+	suite.Require().Equal(http.StatusCreated, first.Code)
+
+	suite.Run("Identical retry replays the first response", func() {
+		w := suite.performRequestWithHeaders("POST", "/form", req, token, headers)
+		suite.Equal(http.StatusCreated, w.Code)
+		suite.Equal("true", w.Header().Get(idempotency.ReplayedHeader))
+		suite.JSONEq(first.Body.String(), w.Body.String())
+
+		var count int64
+		suite.Require().NoError(suite.db.Model(&model.FormModel{}).Where("name = ?", req.Name).Count(&count).Error)
+		suite.Equal(int64(1), count)
+	})
+
+	suite.Run("Key reused with a different body is rejected", func() {
+		other := req
+		other.Name = "Another Form"
+		w := suite.performRequestWithHeaders("POST", "/form", other, token, headers)
+		suite.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+
+	suite.Run("Retry while the first request is in flight conflicts", func() {
+		// A reservation for the same request without a stored response
+		suite.Require().NoError(suite.db.Exec(`INSERT INTO public.idempotency_keys (user_id, key, request_hash, expires_at)
+			SELECT user_id, 'in-flight', request_hash, now() + interval '1 hour'
+			FROM public.idempotency_keys WHERE key = 'create-form-1'`).Error)
+
+		w := suite.performRequestWithHeaders("POST", "/form", req, token, map[string]string{idempotency.KeyHeader: "in-flight"})
+		suite.Equal(http.StatusConflict, w.Code)
+	})
+}
+
+func (suite *HandlerIntegrationSuite) TestAbandonedIdempotencyKeysStayWithTheirNewOwner() {
+	repo := repository.NewIdempotencyRepository(suite.log, suite.db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	reserve := func(createdAt time.Time) *model.IdempotencyKeyModel {
+		record := &model.IdempotencyKeyModel{
+			UserID: "user-1", Key: "slow", RequestHash: "hash", CreatedAt: createdAt, ExpiresAt: createdAt.Add(time.Hour),
+		}
+		reserved, err := repo.ReserveKey(ctx, record, now.Add(-time.Minute))
+		suite.Require().NoError(err)
+		suite.Require().True(reserved)
+		return record
+	}
+
+	slow := reserve(now.Add(-2 * time.Minute))
+	retry := reserve(now)
+
+	status := http.StatusCreated
+	slow.StatusCode = &status
+	suite.ErrorIs(repo.CompleteKey(ctx, slow), repository.ErrKeyTakenOver)
+	suite.ErrorIs(repo.ReleaseKey(ctx, slow), repository.ErrKeyTakenOver)
+	stored, err := repo.GetKey(ctx, "user-1", "slow")
+	suite.Require().NoError(err)
+	suite.Nil(stored.StatusCode, "the retry is still in flight")
+
+	retry.StatusCode = &status
+	suite.NoError(repo.CompleteKey(ctx, retry))
+	suite.ErrorIs(repo.ReleaseKey(ctx, retry), repository.ErrKeyTakenOver, "completed keys are kept")
+}
+
+func (suite *HandlerIntegrationSuite) TestConstraintViolations() {
+	token, _ := suite.getAuthTokenForTestUser("constraints@user.com", "password123")
+	suite.createForm(token, api.FormCreate{
+		Name:  "Unique Form",
+		Steps: api.FormStepCreateArray{{Name: "Unique Step", Content: "First", Step: 1}},
+	})
+
+	for name, req := range map[string]api.FormCreate{
+		"Duplicate form name": {
+			Name:  "Unique Form",
+			Steps: api.FormStepCreateArray{{Name: "Other Step", Content: "First", Step: 1}},
+		},
+		"Duplicate step name": {
+			Name:  "Other Form",
+			Steps: api.FormStepCreateArray{{Name: "Unique Step", Content: "First", Step: 1}},
+		},
+	} {
+		suite.Run(name, func() {
+			w := suite.performRequest("POST", "/form", req, token)
+			suite.Equal(http.StatusConflict, w.Code)
+
+			var resp api.ErrorResponse
+			suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+			suite.Require().NotNil(resp.Field)
+			suite.Equal("name", *resp.Field)
+		})
+	}
+}
+
+func (suite *HandlerIntegrationSuite) TestProblemDetails() {
+	token, _ := suite.getAuthTokenForTestUser("problem@user.com", "password123")
+	accept := map[string]string{"Accept": problem.ContentType}
+
+	suite.Run("Missing form is reported as a problem", func() {
+		w := suite.performRequestWithHeaders("GET", "/form/"+uuid.NewString(), nil, token, accept)
+		suite.Equal(http.StatusNotFound, w.Code)
+		suite.Equal(problem.ContentType, w.Header().Get("Content-Type"))
+
+		var resp api.Problem
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal("resource_not_found", resp.Code)
+		suite.Equal(w.Header().Get(middleware.RequestIDHeader), *resp.RequestId)
+	})
+
+	suite.Run("Missing token is reported as a problem", func() {
+		w := suite.performRequestWithHeaders("GET", "/form/"+uuid.NewString(), nil, "", accept)
+		suite.Equal(http.StatusUnauthorized, w.Code)
+
+		var resp api.Problem
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal("unauthorized", resp.Code)
+	})
+}
+
 func (suite *HandlerIntegrationSuite) TestValidationErrors() {
 	token, _ := suite.getAuthTokenForTestUser("validator@user.com", "password123")
 
 	suite.Run("Create Form with Invalid Name", func() {
-		// Name is empty, which violates `validate:"required"`
+		// Name is empty, which violates `minLength: 1`
 		seqReq := api.FormCreate{
 			Name:  "",
 			Steps: api.FormStepCreateArray{{Name: "s", Content: "c", Step: 1}},
@@ -153,7 +481,63 @@ func (suite *HandlerIntegrationSuite) TestValidationErrors() {
 		suite.NoError(err)
 		suite.Equal(400, resp.Code)
 		suite.NotEmpty(resp.Errors)
-		suite.Equal("Name", resp.Errors[0].Field)
+		suite.Equal("name", resp.Errors[0].Field)
+		suite.Equal("minLength", resp.Errors[0].Rule)
+	})
+
+	suite.Run("Step errors are reported by path in the accepted language", func() {
+		seqReq := api.FormCreate{
+			Name:  "Localized",
+			Steps: api.FormStepCreateArray{{Name: "s", Content: strings.Repeat("x", 257), Step: 1}},
+		}
+		w := suite.performRequestWithHeaders("POST", "/form", seqReq, token, map[string]string{"Accept-Language": "fr-CH, fr;q=0.9"})
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.Equal("fr", w.Header().Get("Content-Language"))
+
+		var resp api.ValidationErrorResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Require().Len(resp.Errors, 1)
+		suite.Equal("steps[0].content", resp.Errors[0].Field)
+		suite.Equal("maxLength", resp.Errors[0].Rule)
+		suite.Equal("content doit faire au maximum 256 caractères", resp.Errors[0].Message)
+	})
+
+	suite.Run("Binding errors are reported by path in the accepted language", func() {
+		// The spec only limits the length of the URL, its format is checked when binding
+		invalidURL := "not a url"
+		batch := api.EventBatch{Events: []api.EventCreate{
+			{Type: api.Click, FormId: uuid.New().String(), StepId: uuid.New().String(), Recipient: "r1", Url: &invalidURL},
+		}}
+		w := suite.performRequestWithHeaders("POST", "/events", batch, token, map[string]string{"Accept-Language": "fr-CH, fr;q=0.9"})
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.Equal("fr", w.Header().Get("Content-Language"))
+
+		var resp api.ValidationErrorResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Require().Len(resp.Errors, 1)
+		suite.Equal("events[0].url", resp.Errors[0].Field)
+		suite.Equal("url", resp.Errors[0].Rule)
+		suite.Equal("url doit être une URL valide", resp.Errors[0].Message)
+	})
+
+	suite.Run("Malformed requests without credentials are unauthorized", func() {
+		w := suite.performRequest("POST", "/form", api.FormCreate{Name: ""}, "")
+		suite.Equal(http.StatusUnauthorized, w.Code)
+	})
+
+	suite.Run("Unknown fields are rejected", func() {
+		w := suite.performRequest("POST", "/form", map[string]any{
+			"name":  "Unknown Fields",
+			"nmae":  "typo",
+			"steps": []map[string]any{{"name": "s", "content": "c", "step": 1}},
+		}, token)
+		suite.Equal(http.StatusBadRequest, w.Code)
+
+		var resp api.ValidationErrorResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Require().Len(resp.Errors, 1)
+		suite.Equal("nmae", resp.Errors[0].Field)
+		suite.Equal("additionalProperties", resp.Errors[0].Rule)
 	})
 }
 
@@ -187,11 +571,520 @@ func (suite *HandlerIntegrationSuite) TestCreateForm_Validation_EmptySteps() {
 
 		found := false
 		for _, detail := range resp.Errors {
-			if detail.Field == "Steps" {
+			if detail.Field == "steps" {
 				found = true
-				suite.Contains(detail.Message, "min")
+				suite.Equal("minItems", detail.Rule)
 			}
 		}
-		suite.True(found, "Expected a validation error for the 'Steps' field")
+		suite.True(found, "Expected a validation error for the 'steps' field")
+	})
+}
+
+func (suite *HandlerIntegrationSuite) createForm(token string, req api.FormCreate) api.FormResponseGet {
+	w := suite.performRequest("POST", "/form", req, token)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var self api.SelfId
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &self))
+
+	w = suite.performRequest("GET", "/form/"+self.Id, nil, token)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var form api.FormResponseGet
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &form))
+	return form
+}
+
+func (suite *HandlerIntegrationSuite) countTrackingEvents(eventType string, formId string) int64 {
+	var count int64
+	suite.Require().NoError(suite.db.Model(&model.TrackingEventModel{}).
+		Where("event_type = ? AND form_id = ?", eventType, formId).
+		Count(&count).Error)
+	return count
+}
+
+func (suite *HandlerIntegrationSuite) TestOpenTracking() {
+	token, _ := suite.getAuthTokenForTestUser("tracking@user.com", "password123")
+	pixelPattern := regexp.MustCompile(`/api/v1(/t/o/[^"]+\.gif)`)
+
+	suite.Run("Pixel records a single open for tracked forms", func() {
+		form := suite.createForm(token, api.FormCreate{
+			Name:                "Tracked Form",
+			OpenTrackingEnabled: boolPtr(true),
+			Steps:               api.FormStepCreateArray{{Name: "Tracked Step", Content: "<p>Hi</p>", Step: 1}},
+		})
+		step := form.Steps[0]
+
+		w := suite.performRequest("GET", fmt.Sprintf("/form/%s/steps/%s/render?recipient=jane", form.Self.Id, step.Self.Id), nil, token)
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var rendered api.FormStepRenderResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &rendered))
+
+		match := pixelPattern.FindStringSubmatch(rendered.Content)
+		suite.Require().Len(match, 2, "Rendered content should contain the open pixel")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w := suite.performRequest("GET", match[1], nil, "")
+				suite.Equal(http.StatusOK, w.Code)
+				suite.Equal("image/gif", w.Header().Get("Content-Type"))
+			}()
+		}
+		wg.Wait()
+
+		suite.Equal(int64(1), suite.countTrackingEvents(model.TrackingEventOpen, form.Self.Id))
+	})
+
+	suite.Run("Untracked forms render without a pixel", func() {
+		form := suite.createForm(token, api.FormCreate{
+			Name:  "Untracked Form",
+			Steps: api.FormStepCreateArray{{Name: "Untracked Step", Content: "<p>Hi</p>", Step: 1}},
+		})
+
+		w := suite.performRequest("GET", fmt.Sprintf("/form/%s/steps/%s/render?recipient=jane", form.Self.Id, form.Steps[0].Self.Id), nil, token)
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var rendered api.FormStepRenderResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &rendered))
+		suite.Equal("<p>Hi</p>", rendered.Content)
+	})
+
+	suite.Run("Tampered pixels are served but not recorded", func() {
+		w := suite.performRequest("GET", "/t/o/bogus.token.gif", nil, "")
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("image/gif", w.Header().Get("Content-Type"))
+	})
+}
+
+func (suite *HandlerIntegrationSuite) TestClickTracking() {
+	token, _ := suite.getAuthTokenForTestUser("clicks@user.com", "password123")
+	clickPattern := regexp.MustCompile(`/api/v1(/t/c/[^"]+)"`)
+
+	form := suite.createForm(token, api.FormCreate{
+		Name:                 "Click Tracked Form",
+		ClickTrackingEnabled: boolPtr(true),
+		Steps: api.FormStepCreateArray{
+			{Name: "Click Step", Content: `<a href="https://example.com/offer">Offer</a>`, Step: 1},
+		},
+	})
+	step := form.Steps[0]
+
+	w := suite.performRequest("GET", fmt.Sprintf("/form/%s/steps/%s/render?recipient=jane", form.Self.Id, step.Self.Id), nil, token)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var rendered api.FormStepRenderResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &rendered))
+	suite.NotContains(rendered.Content, "https://example.com/offer")
+
+	match := clickPattern.FindStringSubmatch(rendered.Content)
+	suite.Require().Len(match, 2, "Rendered content should contain a tracking link")
+
+	suite.Run("Valid token redirects and records a click", func() {
+		w := suite.performRequest("GET", match[1], nil, "")
+		suite.Equal(http.StatusFound, w.Code)
+		suite.Equal("https://example.com/offer", w.Header().Get("Location"))
+		suite.Equal(int64(1), suite.countTrackingEvents(model.TrackingEventClick, form.Self.Id))
+	})
+
+	suite.Run("Tampered token is not redirected", func() {
+		w := suite.performRequest("GET", match[1]+"x", nil, "")
+		suite.Equal(http.StatusNotFound, w.Code)
+		suite.Empty(w.Header().Get("Location"))
+	})
+}
+
+func (suite *HandlerIntegrationSuite) TestEventIngestionAndAnalytics() {
+	token, _ := suite.getAuthTokenForTestUser("analytics@user.com", "password123")
+
+	form := suite.createForm(token, api.FormCreate{
+		Name:                 "Analytics Form",
+		OpenTrackingEnabled:  boolPtr(true),
+		ClickTrackingEnabled: boolPtr(true),
+		Steps: api.FormStepCreateArray{
+			{Name: "Analytics Step 1", Content: "First", Step: 1},
+			{Name: "Analytics Step 2", Content: "Second", Step: 2},
+		},
+	})
+	formId := form.Self.Id
+	step1, step2 := form.Steps[0].Self.Id, form.Steps[1].Self.Id
+	earlier := time.Now().UTC().Add(-3 * time.Hour)
+
+	suite.Run("Ingest a batch of events", func() {
+		batch := api.EventBatch{Events: []api.EventCreate{
+			{Type: api.Open, FormId: formId, StepId: step1, Recipient: "r1", OccurredAt: &earlier},
+			{Type: api.Open, FormId: formId, StepId: step1, Recipient: "r1", OccurredAt: &earlier},
+			{Type: api.Open, FormId: formId, StepId: step1, Recipient: "r2", OccurredAt: &earlier},
+			{Type: api.Click, FormId: formId, StepId: step1, Recipient: "r1", OccurredAt: &earlier},
+			{Type: api.View, FormId: formId, StepId: step1, Recipient: "r1"},
+			{Type: api.View, FormId: formId, StepId: step1, Recipient: "r2"},
+			{Type: api.StepCompleted, FormId: formId, StepId: step1, Recipient: "r1"},
+			{Type: api.View, FormId: formId, StepId: step2, Recipient: "r1"},
+		}}
+		w := suite.performRequest("POST", "/events", batch, token)
+		suite.Require().Equal(http.StatusAccepted, w.Code)
+		var resp api.EventBatchResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal(8, resp.Accepted)
+		suite.Equal(0, resp.Dropped)
+	})
+
+	suite.Run("Reject events of unknown forms", func() {
+		batch := api.EventBatch{Events: []api.EventCreate{
+			{Type: api.View, FormId: uuid.New().String(), StepId: step1, Recipient: "r1"},
+		}}
+		w := suite.performRequest("POST", "/events", batch, token)
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("Reject events older than the accepted age", func() {
+		longAgo := time.Now().UTC().Add(-48 * time.Hour)
+		batch := api.EventBatch{Events: []api.EventCreate{
+			{Type: api.View, FormId: formId, StepId: step1, Recipient: "r1", OccurredAt: &longAgo},
+		}}
+		w := suite.performRequest("POST", "/events", batch, token)
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.Contains(w.Body.String(), "occurredAt")
+	})
+
+	// Roll up the backdated events, then add one more open that is only in the raw table
+	analytics.NewAggregator(suite.log, repository.NewAnalyticsRepository(suite.log, suite.db), 0).Rollup(context.Background())
+	w := suite.performRequest("POST", "/events", api.EventBatch{Events: []api.EventCreate{
+		{Type: api.Open, FormId: formId, StepId: step1, Recipient: "r1"},
+	}}, token)
+	suite.Require().Equal(http.StatusAccepted, w.Code)
+
+	suite.Run("Analytics combine rollups and raw events", func() {
+		from := time.Now().UTC().Add(-4 * time.Hour).Format(time.RFC3339)
+		w := suite.performRequest("GET", fmt.Sprintf("/form/%s/analytics?bucket=hour&from=%s", formId, url.QueryEscape(from)), nil, token)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var resp api.FormAnalytics
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal(int64(4), resp.Totals.Opens)
+		suite.Equal(int64(2), resp.Totals.UniqueOpens)
+		suite.Equal(int64(1), resp.Totals.Clicks)
+		suite.Equal(int64(1), resp.Totals.UniqueClicks)
+		suite.Equal(0.5, resp.Totals.ClickThroughRate)
+		suite.Len(resp.Series, 5)
+
+		suite.Require().Len(resp.Funnel, 2)
+		suite.Equal(int64(2), resp.Funnel[0].Viewed)
+		suite.Equal(int64(1), resp.Funnel[0].Completed)
+		suite.Equal(int64(1), resp.Funnel[0].DropOff)
+		suite.Equal(0.5, resp.Funnel[0].DropOffRate)
+		suite.Equal(int64(1), resp.Funnel[1].Viewed)
+	})
+
+	suite.Run("Analytics of rolled up hours do not read raw events", func() {
+		suite.Require().NoError(suite.db.Where("form_id = ? AND occurred_at < ?", formId, time.Now().UTC().Add(-2*time.Hour)).
+			Delete(&model.TrackingEventModel{}).Error)
+
+		from := time.Now().UTC().Add(-4 * time.Hour).Format(time.RFC3339)
+		w := suite.performRequest("GET", fmt.Sprintf("/form/%s/analytics?bucket=hour&from=%s", formId, url.QueryEscape(from)), nil, token)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var resp api.FormAnalytics
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal(int64(4), resp.Totals.Opens)
+		suite.Equal(int64(2), resp.Totals.UniqueOpens)
+		suite.Equal(int64(1), resp.Totals.UniqueClicks)
+	})
+
+	suite.Run("Invalid range is rejected", func() {
+		w := suite.performRequest("GET", fmt.Sprintf("/form/%s/analytics?bucket=month", formId), nil, token)
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (suite *HandlerIntegrationSuite) TestWebhooks() {
+	token, _ := suite.getAuthTokenForTestUser("webhooks@user.com", "password123")
+
+	received := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	var fail atomic.Bool
+	fail.Store(true)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received <- r
+		bodies <- body
+	}))
+	defer receiver.Close()
+
+	secret := "integration-webhook-secret"
+	var created api.WebhookResponseGet
+	suite.Run("Create webhook", func() {
+		w := suite.performRequest("POST", "/webhooks", api.WebhookCreate{
+			Url:        receiver.URL,
+			EventTypes: []api.WebhookEventType{api.FormCreated, api.SubmissionCompleted},
+			Secret:     &secret,
+		}, token)
+		suite.Require().Equal(http.StatusCreated, w.Code)
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+		suite.Equal(secret, *created.Secret)
+
+		w = suite.performRequest("GET", "/webhooks/"+created.Self.Id, nil, token)
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var fetched api.WebhookResponseGet
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &fetched))
+		suite.Nil(fetched.Secret)
+	})
+
+	suite.Run("Invalid webhook is rejected", func() {
+		w := suite.performRequest("POST", "/webhooks", api.WebhookCreate{Url: "ftp://example.com"}, token)
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	form := suite.createForm(token, api.FormCreate{
+		Name:                 "Webhook Form",
+		OpenTrackingEnabled:  boolPtr(false),
+		ClickTrackingEnabled: boolPtr(false),
+		Steps: api.FormStepCreateArray{
+			{Name: "Webhook Step 1", Content: "First", Step: 1},
+			{Name: "Webhook Step 2", Content: "Second", Step: 2},
+		},
+	})
+
+	dispatcher := webhook.NewDispatcher(suite.log, suite.webhookRepo, config.WebhooksConfig{MaxAttempts: 1, AllowedNetworks: []string{"127.0.0.0/8"}})
+
+	var deliveryId string
+	suite.Run("Failed delivery is dead-lettered", func() {
+		suite.Equal(1, suite.relay.RelayPending(context.Background()))
+		suite.Equal(1, dispatcher.DispatchDue(context.Background()))
+
+		w := suite.performRequest("GET", fmt.Sprintf("/webhooks/%s/deliveries", created.Self.Id), nil, token)
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var page api.WebhookDeliveryPage
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &page))
+		suite.Require().Len(page.Items, 1)
+		suite.Equal(api.FormCreated, page.Items[0].EventType)
+		suite.Equal(api.Dead, page.Items[0].Status)
+		deliveryId = page.Items[0].Self.Id
+	})
+
+	suite.Run("Redelivered event is signed and delivered", func() {
+		fail.Store(false)
+		w := suite.performRequest("POST", fmt.Sprintf("/webhooks/%s/deliveries/%s/redeliver", created.Self.Id, deliveryId), nil, token)
+		suite.Require().Equal(http.StatusAccepted, w.Code)
+		suite.Equal(1, dispatcher.DispatchDue(context.Background()))
+
+		r, body := <-received, <-bodies
+		suite.Equal(string(api.FormCreated), r.Header.Get(webhook.EventHeader))
+		suite.NoError(webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), body, time.Minute))
+
+		w = suite.performRequest("GET", fmt.Sprintf("/webhooks/%s/deliveries/%s", created.Self.Id, deliveryId), nil, token)
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var delivery api.WebhookDeliveryResponseGet
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &delivery))
+		suite.Equal(api.Succeeded, delivery.Status)
+		suite.Require().NotNil(delivery.AttemptLog)
+		suite.Len(*delivery.AttemptLog, 2)
+	})
+
+	suite.Run("Completing the last step emits submission.completed", func() {
+		w := suite.performRequest("POST", "/events", api.EventBatch{Events: []api.EventCreate{
+			{Type: api.StepCompleted, FormId: form.Self.Id, StepId: form.Steps[0].Self.Id, Recipient: "r1"},
+			{Type: api.StepCompleted, FormId: form.Self.Id, StepId: form.Steps[1].Self.Id, Recipient: "r1"},
+		}}, token)
+		suite.Require().Equal(http.StatusAccepted, w.Code)
+		suite.Equal(1, suite.relay.RelayPending(context.Background()))
+		suite.Equal(1, dispatcher.DispatchDue(context.Background()))
+
+		r, body := <-received, <-bodies
+		suite.Equal(string(api.SubmissionCompleted), r.Header.Get(webhook.EventHeader))
+		var event struct {
+			Data webhook.SubmissionCompleted `json:"data"`
+		}
+		suite.Require().NoError(json.Unmarshal(body, &event))
+		suite.Equal(form.Steps[1].Self.Id, event.Data.Step.Id)
+		suite.Equal("r1", event.Data.Recipient)
+	})
+
+	suite.Run("Delete webhook", func() {
+		w := suite.performRequest("DELETE", "/webhooks/"+created.Self.Id, nil, token)
+		suite.Equal(http.StatusNoContent, w.Code)
+		w = suite.performRequest("GET", "/webhooks/"+created.Self.Id, nil, token)
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+type recordingSink struct {
+	failures int
+	events   []events.Event
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Publish(_ context.Context, event events.Event) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink unavailable")
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (suite *HandlerIntegrationSuite) TestOutbox() {
+	token, _ := suite.getAuthTokenForTestUser("outbox@user.com", "password123")
+	sink := &recordingSink{failures: 1}
+	relay := events.NewRelay(
+		suite.log,
+		repository.NewTxManager(suite.log, suite.db),
+		repository.NewOutboxRepository(suite.log, suite.db),
+		[]events.Sink{sink},
+		config.OutboxConfig{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	)
+
+	form := suite.createForm(token, api.FormCreate{
+		Name:                 "Outbox Form",
+		OpenTrackingEnabled:  boolPtr(false),
+		ClickTrackingEnabled: boolPtr(false),
+		Steps:                api.FormStepCreateArray{{Name: "Outbox Step", Content: "Content", Step: 1}},
+	})
+	w := suite.performRequest("PATCH", "/form/"+form.Self.Id, api.FormUpdate{OpenTrackingEnabled: boolPtr(true)}, token)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	w = suite.performRequest("PATCH", fmt.Sprintf("/form/%s/steps/%s", form.Self.Id, form.Steps[0].Self.Id),
+		api.FormStepUpdate{Name: stringPtr("Renamed Step")}, token)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	suite.Run("Failed event holds back later events of the form", func() {
+		relay.RelayPending(context.Background())
+		suite.Empty(sink.events)
+
+		var pending int64
+		suite.db.Model(&model.OutboxEventModel{}).Where("form_id = ? AND published_at IS NULL", form.Self.Id).Count(&pending)
+		suite.Equal(int64(3), pending)
+	})
+
+	suite.Run("Events are published in order once the sink recovers", func() {
+		time.Sleep(5 * time.Millisecond)
+		suite.Equal(3, relay.RelayPending(context.Background()))
+
+		suite.Require().Len(sink.events, 3)
+		suite.Equal(api.FormCreated, sink.events[0].Type)
+		suite.Equal(api.FormUpdated, sink.events[1].Type)
+		suite.Equal(api.StepUpdated, sink.events[2].Type)
+		suite.Less(sink.events[0].Sequence, sink.events[1].Sequence)
+
+		var created api.FormResponseGet
+		suite.Require().NoError(json.Unmarshal(sink.events[0].Data, &created))
+		suite.Equal(form.Self.Id, created.Self.Id)
+
+		suite.Equal(0, relay.RelayPending(context.Background()))
+	})
+}
+
+func (suite *HandlerIntegrationSuite) TestProbes() {
+	for _, path := range []string{"/healthz", "/readyz"} {
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		suite.Equal(http.StatusOK, w.Code, path)
+	}
+}
+
+func (suite *HandlerIntegrationSuite) TestMetrics() {
+	token, _ := suite.getAuthTokenForTestUser("metrics@user.com", "password123")
+	suite.createForm(token, api.FormCreate{
+		Name:  "Metrics Form",
+		Steps: api.FormStepCreateArray{{Name: "Metrics Step", Content: "Content", Step: 1}},
+	})
+	suite.performRequest("GET", "/form/"+uuid.NewString(), nil, token)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	suite.Require().Equal(http.StatusOK, w.Code)
+	body := w.Body.String()
+	suite.Contains(body, `forms_api_logins_total{outcome="success"}`)
+	suite.Contains(body, `forms_api_http_requests_total{method="GET",route="/api/v1/form/:formId",status="404"}`)
+	suite.Contains(body, "forms_api_forms 1")
+	suite.Contains(body, "forms_api_form_steps 1")
+	suite.Contains(body, `go_sql_open_connections{db_name="forms"}`)
+}
+
+func (suite *HandlerIntegrationSuite) TestClient() {
+	username, password := "client@user.com", "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	suite.Require().NoError(suite.db.Create(&model.CredentialsModel{Username: username, Password: string(hashedPassword)}).Error)
+
+	server := httptest.NewServer(suite.router)
+	defer server.Close()
+	c, err := client.New(server.URL+"/api/v1", client.WithCredentials(username, password))
+	suite.Require().NoError(err)
+	ctx := context.Background()
+
+	var formId string
+	suite.Run("Create and get a form", func() {
+		created, err := c.CreateFormWithResponse(ctx, nil, client.FormCreate{
+			Name:                "Client Form",
+			OpenTrackingEnabled: boolPtr(false),
+			Steps: client.FormStepCreateArray{
+				{Name: "Client Step", Content: "Content", Step: 1},
+			},
+		})
+		suite.Require().NoError(err)
+		suite.Require().NotNil(created.JSON201)
+		formId = created.JSON201.Id
+
+		fetched, err := c.GetFormByIdWithResponse(ctx, formId, nil)
+		suite.Require().NoError(err)
+		suite.Require().NotNil(fetched.JSON200)
+		suite.Equal("Client Form", fetched.JSON200.Name)
+	})
+
+	suite.Run("Errors are typed", func() {
+		_, err := c.GetFormByIdWithResponse(ctx, uuid.NewString(), nil)
+		suite.Require().ErrorIs(err, client.ErrResourceNotFound)
+		var apiErr *client.Error
+		suite.Require().True(errors.As(err, &apiErr))
+		suite.Equal(http.StatusNotFound, apiErr.StatusCode)
+		suite.NotEmpty(apiErr.RequestID)
+
+		_, err = c.CreateFormWithResponse(ctx, nil, client.FormCreate{Name: "No Steps"})
+		suite.Require().ErrorIs(err, client.ErrValidationFailed)
+		suite.Require().True(errors.As(err, &apiErr))
+		suite.NotEmpty(apiErr.Errors)
+	})
+
+	suite.Run("Wrong credentials are rejected", func() {
+		other, err := client.New(server.URL+"/api/v1", client.WithCredentials(username, "wrong"))
+		suite.Require().NoError(err)
+		_, err = other.GetFormByIdWithResponse(ctx, formId, nil)
+		suite.ErrorIs(err, client.ErrInvalidCredentials)
+	})
+
+	suite.Run("Webhook deliveries are iterated across pages", func() {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		hook, err := c.CreateWebhookWithResponse(ctx, nil, client.WebhookCreate{
+			Url:        receiver.URL,
+			EventTypes: []client.WebhookEventType{client.FormUpdated},
+			FormId:     &formId,
+		})
+		suite.Require().NoError(err)
+		suite.Require().NotNil(hook.JSON201)
+		defer c.DeleteWebhookByIdWithResponse(ctx, hook.JSON201.Self.Id)
+
+		for i := range 3 {
+			_, err := c.UpdateFormByIdWithResponse(ctx, formId, nil, client.FormUpdate{OpenTrackingEnabled: boolPtr(i%2 == 0)})
+			suite.Require().NoError(err)
+		}
+		suite.relay.RelayPending(ctx)
+		dispatcher := webhook.NewDispatcher(suite.log, suite.webhookRepo, config.WebhooksConfig{MaxAttempts: 1, AllowedNetworks: []string{"127.0.0.0/8"}})
+		dispatcher.DispatchDue(ctx)
+
+		count := 0
+		for delivery, err := range client.WebhookDeliveries(ctx, c, hook.JSON201.Self.Id, 2) {
+			suite.Require().NoError(err)
+			suite.Equal(client.FormUpdated, delivery.EventType)
+			count++
+		}
+		suite.Equal(3, count)
 	})
 }
@@ -0,0 +1,109 @@
+package itest
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/migrate"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/migrations"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// openEmptyDatabase creates a database of its own, the suite's schema being
+// created by AutoMigrate rather than the migrations.
+func openEmptyDatabase(t *testing.T, name string) *gorm.DB {
+	db, err := gorm.Open(pg.Open(testDbConnStr), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE DATABASE "+name).Error)
+
+	db, err = gorm.Open(pg.Open(strings.Replace(testDbConnStr, "/test-db?", "/"+name+"?", 1)), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
+
+func TestMigrations_ApplyRevertAndMatchModels(t *testing.T) {
+	ctx := context.Background()
+	log := logger.InitLogger(config.LogConfig{Level: "panic"})
+	repo := repository.NewMigrationRepository(log, openEmptyDatabase(t, "migrations_roundtrip"))
+	all, err := migrations.All()
+	require.NoError(t, err)
+	migrator := migrate.NewMigrator(log, repo, all)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(all), applied)
+
+	drifts, err := migrate.CheckDrift(ctx, repo, model.Tables()...)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	reverted, err := migrator.Down(ctx, len(all))
+	require.NoError(t, err)
+	assert.Equal(t, len(all), reverted)
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Len(t, status.Pending, len(all))
+}
+
+func TestMigrations_ConcurrentRunsApplyOnce(t *testing.T) {
+	ctx := context.Background()
+	log := logger.InitLogger(config.LogConfig{Level: "panic"})
+	db := openEmptyDatabase(t, "migrations_concurrent")
+	all, err := migrations.All()
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	applied := make([]int, 3)
+	errs := make([]error, 3)
+	for i := range applied {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			migrator := migrate.NewMigrator(log, repository.NewMigrationRepository(log, db), all)
+			applied[i], errs[i] = migrator.Up(ctx)
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for i := range applied {
+		require.NoError(t, errs[i])
+		total += applied[i]
+	}
+	assert.Equal(t, len(all), total)
+}
+
+func TestMigrations_RejectDuplicateNames(t *testing.T) {
+	ctx := context.Background()
+	log := logger.InitLogger(config.LogConfig{Level: "panic"})
+	db := openEmptyDatabase(t, "migrations_unique_names")
+	all, err := migrations.All()
+	require.NoError(t, err)
+	_, err = migrate.NewMigrator(log, repository.NewMigrationRepository(log, db), all).Up(ctx)
+	require.NoError(t, err)
+
+	forms := repository.NewFormRepository(log, db)
+	_, err = forms.CreateForm(ctx, &model.FormModel{Name: "Unique Form", Steps: []model.FormStepModel{{Name: "Unique Step", Content: "c", StepOrder: 1}}})
+	require.NoError(t, err)
+
+	for name, form := range map[string]*model.FormModel{
+		"form name": {Name: "Unique Form", Steps: []model.FormStepModel{{Name: "Other Step", Content: "c", StepOrder: 1}}},
+		"step name": {Name: "Other Form", Steps: []model.FormStepModel{{Name: "Unique Step", Content: "c", StepOrder: 1}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := forms.CreateForm(ctx, form)
+			var conflict *apierrors.ConflictError
+			require.ErrorAs(t, err, &conflict)
+			assert.Equal(t, "name", conflict.Field)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -12,19 +13,31 @@ import (
 	"gorm.io/gorm"
 	"os"
 	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/cache"
 	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/events"
 	"salesforge-assignment/internal/handler"
+	"salesforge-assignment/internal/health"
+	"salesforge-assignment/internal/idempotency"
 	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/metrics"
 	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/middleware/auth"
 	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/openapi"
+	"salesforge-assignment/internal/problem"
+	"salesforge-assignment/internal/ratelimit"
 	"salesforge-assignment/internal/repository"
 	"salesforge-assignment/internal/service"
+	"salesforge-assignment/internal/tracing"
 	"testing"
 	"time"
 )
 
 var testDbConnStr string
 
+const formCacheChannel = "form_cache_invalidation"
+
 func TestMain(m *testing.M) {
 	ctx := context.Background()
 
@@ -66,6 +79,12 @@ type HandlerIntegrationSuite struct {
 	suite.Suite
 	db     *gorm.DB
 	router *gin.Engine
+	log    *zerolog.Logger
+
+	webhookRepo repository.WebhookRepository
+	relay       *events.Relay
+	bus         *events.Bus
+	stopListen  context.CancelFunc
 }
 
 func (suite *HandlerIntegrationSuite) SetupSuite() {
@@ -74,31 +93,103 @@ func (suite *HandlerIntegrationSuite) SetupSuite() {
 	suite.db = db
 
 	suite.db.Exec("CREATE SCHEMA IF NOT EXISTS authz;")
-	err = suite.db.AutoMigrate(&model.CredentialsModel{}, &model.FormModel{}, &model.FormStepModel{})
+	err = suite.db.AutoMigrate(&model.CredentialsModel{}, &model.FormModel{}, &model.FormStepModel{}, &model.TrackingEventModel{}, &model.TrackingEventRollupModel{}, &model.TrackingEventRecipientRollupModel{}, &model.TrackingEventRollupStateModel{}, &model.WebhookModel{}, &model.WebhookDeliveryModel{}, &model.WebhookDeliveryAttemptModel{}, &model.OutboxEventModel{}, &model.IdempotencyKeyModel{})
 	suite.Require().NoError(err)
 
 	gin.SetMode(gin.TestMode)
-	os.Setenv("JWT_SECRET_KEY", "integration-test-secret")
-	disabledLogger := logger.InitLogger("panic", false)
+	disabledLogger := logger.InitLogger(config.LogConfig{Level: "panic"})
+	suite.log = disabledLogger
 	testConfig := &config.Config{}
 	testConfig.Server.PublicUrl = "http://localhost:3000"
 	testConfig.Server.BaseURL = "/api/v1"
+	testConfig.Tracking.OpenDedupWindow = time.Minute
+	testConfig.Tracking.MaxEventAge = 24 * time.Hour
+	testConfig.Tracking.Secret = "integration-test-tracking-secret"
+	testConfig.Auth.JWTSecret = "integration-test-secret"
+	testConfig.Cache.Control = map[string]string{"GET /form/:formId": "private, no-cache"}
 
 	credRepo := repository.NewCredentialsRepository(disabledLogger, suite.db)
-	seqRepo := repository.NewFormRepository(disabledLogger, suite.db)
-	appService := service.NewFormService(disabledLogger, credRepo, seqRepo, testConfig)
-	apiHandler := handler.NewFormHandler(appService)
+	forms := cache.NewLRU[string, model.FormModel](100, time.Minute)
+	seqRepo := repository.NewCachedFormRepository(disabledLogger, suite.db,
+		repository.NewFormRepository(disabledLogger, suite.db), forms, formCacheChannel)
+	listenCtx, stopListen := context.WithCancel(context.Background())
+	suite.stopListen = stopListen
+	go seqRepo.Listen(listenCtx, testDbConnStr)
+	trackingRepo := repository.NewTrackingRepository(disabledLogger, suite.db)
+	suite.webhookRepo = repository.NewWebhookRepository(disabledLogger, suite.db)
+	outboxRepo := repository.NewOutboxRepository(disabledLogger, suite.db)
+	txManager := repository.NewTxManager(disabledLogger, suite.db)
+	webhookService := service.NewWebhookService(suite.webhookRepo, seqRepo, testConfig)
+	appService := service.NewFormService(disabledLogger, credRepo, seqRepo, outboxRepo, txManager, testConfig)
+	analyticsRepo := repository.NewAnalyticsRepository(disabledLogger, suite.db)
+	trackingService := service.NewTrackingService(disabledLogger, seqRepo, trackingRepo, outboxRepo, txManager, testConfig)
+	analyticsService := service.NewAnalyticsService(seqRepo, analyticsRepo, testConfig)
+	apiHandler := handler.NewFormHandler(appService, trackingService, analyticsService, webhookService)
+
+	suite.bus = events.NewBus()
+	suite.bus.Subscribe(webhookService.HandleEvent)
+	suite.relay = events.NewRelay(disabledLogger, txManager, outboxRepo, []events.Sink{suite.bus}, config.OutboxConfig{})
 
 	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(problem.NoRoute())
+	router.NoMethod(problem.NoMethod())
+	router.Use(middleware.RequestID())
+	router.Use(metrics.Middleware())
 	router.Use(middleware.InjectLogger(disabledLogger))
-	router.Use(gin.Recovery())
+	router.Use(tracing.Middleware())
+	router.Use(problem.Recovery())
+	router.Use(middleware.CORS(func() config.CORSConfig { return testConfig.CORS }))
+	router.Use(middleware.CacheControl(testConfig.Server.BaseURL, testConfig.Cache.Control))
+	sqlDB, err := suite.db.DB()
+	suite.Require().NoError(err)
+	healthHandler := health.NewHandler(disabledLogger)
+	healthHandler.AddCheck("database", health.Database(sqlDB))
+	healthHandler.Register(router)
+	suite.Require().NoError(metrics.RegisterDatabase(metrics.Registry, disabledLogger, sqlDB, seqRepo))
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
-	baseGroup := router.Group(testConfig.Server.BaseURL)
-	api.RegisterHandlers(baseGroup, apiHandler) // Use the generated registration
+	operations, err := openapi.NewOperations(testConfig.Server.BaseURL)
+	suite.Require().NoError(err)
+	security, err := operations.Security(map[string]openapi.Authenticator{auth.BearerScheme: auth.Authenticate(testConfig.Auth.JWTSecret)})
+	suite.Require().NoError(err)
+	rateLimits, err := operations.RateLimits(ratelimit.NewLimiter(func() map[string]config.RateLimitConfig {
+		return testConfig.RateLimits
+	}).Check)
+	suite.Require().NoError(err)
+	features, err := operations.Features(testConfig.Enabled)
+	suite.Require().NoError(err)
+	apiGroup := router.Group(testConfig.Server.BaseURL)
+	apiGroup.Use(security)
+	apiGroup.Use(features)
+	apiGroup.Use(rateLimits)
+	specValidator, err := openapi.NewValidator(disabledLogger, config.ValidationConfig{})
+	suite.Require().NoError(err)
+	apiGroup.Use(specValidator.Middleware())
+	apiGroup.Use(middleware.QueryDeadline(testConfig.Database.QueryTimeout))
+	idempotencyRepo := repository.NewIdempotencyRepository(disabledLogger, suite.db)
+	apiGroup.Use(operations.WithHeader(idempotency.KeyHeader, idempotency.NewGuard(disabledLogger, idempotencyRepo, config.IdempotencyConfig{}).Middleware()))
+
+	api.RegisterHandlersWithOptions(apiGroup, apiHandler, api.GinServerOptions{
+		ErrorHandler: problem.BindingErrorHandler,
+	})
+	suite.Require().NoError(operations.CheckRoutes(router.Routes()))
 	suite.router = router
 }
 
+func (suite *HandlerIntegrationSuite) TearDownSuite() {
+	suite.stopListen()
+}
+
 func (suite *HandlerIntegrationSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM public.idempotency_keys")
+	suite.db.Exec("DELETE FROM public.outbox_events")
+	suite.db.Exec("DELETE FROM public.webhook_delivery_attempts")
+	suite.db.Exec("DELETE FROM public.webhook_deliveries")
+	suite.db.Exec("DELETE FROM public.webhooks")
+	suite.db.Exec("DELETE FROM public.tracking_event_rollup_state")
+	suite.db.Exec("DELETE FROM public.tracking_event_rollups")
+	suite.db.Exec("DELETE FROM public.tracking_events")
 	suite.db.Exec("DELETE FROM public.form_steps")
 	suite.db.Exec("DELETE FROM public.form")
 	suite.db.Exec("DELETE FROM authz.credentials")
@@ -0,0 +1,110 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"salesforge-assignment/internal/analytics"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/service"
+	"testing"
+	"time"
+)
+
+func TestTruncate(t *testing.T) {
+	// Thursday
+	ts := time.Date(2025, 6, 19, 14, 35, 12, 0, time.FixedZone("CEST", 2*60*60))
+
+	hour, err := analytics.Truncate(ts, analytics.BucketHour)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 19, 12, 0, 0, 0, time.UTC), hour)
+
+	day, err := analytics.Truncate(ts, analytics.BucketDay)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 19, 0, 0, 0, 0, time.UTC), day)
+
+	week, err := analytics.Truncate(ts, analytics.BucketWeek)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), week)
+	assert.Equal(t, time.Monday, week.Weekday())
+
+	_, err = analytics.Truncate(ts, "month")
+	assert.Error(t, err)
+}
+
+func TestTruncate_WeekStartsOnMonday(t *testing.T) {
+	sunday := time.Date(2025, 6, 22, 23, 0, 0, 0, time.UTC)
+	week, err := analytics.Truncate(sunday, analytics.BucketWeek)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), week)
+}
+
+func TestBuckets(t *testing.T) {
+	from := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+
+	assert.Len(t, analytics.Buckets(from, from.Add(6*time.Hour), analytics.BucketHour), 6)
+	assert.Len(t, analytics.Buckets(from, from.AddDate(0, 0, 3), analytics.BucketDay), 3)
+	assert.Equal(t,
+		[]time.Time{from, from.AddDate(0, 0, 7)},
+		analytics.Buckets(from, from.AddDate(0, 0, 14), analytics.BucketWeek))
+	assert.Empty(t, analytics.Buckets(from, from, analytics.BucketDay))
+}
+
+func TestCount(t *testing.T) {
+	from := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+
+	assert.EqualValues(t, 6, analytics.Count(from, from.Add(6*time.Hour), analytics.BucketHour))
+	assert.EqualValues(t, 3, analytics.Count(from, from.AddDate(0, 0, 3), analytics.BucketDay))
+	assert.EqualValues(t, 2, analytics.Count(from, from.AddDate(0, 0, 14), analytics.BucketWeek))
+	assert.EqualValues(t, 0, analytics.Count(from, from, analytics.BucketDay))
+	assert.EqualValues(t, 0, analytics.Count(from, from.Add(-time.Hour), analytics.BucketHour))
+
+	ancient := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.EqualValues(t, 17746008, analytics.Count(ancient, from, analytics.BucketHour))
+}
+
+func TestAnalyticsService_RejectsVeryWideRangesBeforeBucketing(t *testing.T) {
+	// Without repositories: the range is rejected before any query
+	analyticsService := service.NewAnalyticsService(nil, nil, &config.Config{})
+	from := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	bucket := api.Hour
+
+	var allocated runtime.MemStats
+	runtime.ReadMemStats(&allocated)
+	before := allocated.TotalAlloc
+	_, err := analyticsService.GetFormAnalytics(context.Background(), "form-1",
+		api.GetFormAnalyticsParams{From: &from, To: &to, Bucket: &bucket})
+	runtime.ReadMemStats(&allocated)
+
+	var invalid *apierrors.InvalidInputError
+	assert.ErrorAs(t, err, &invalid)
+	assert.Less(t, allocated.TotalAlloc-before, uint64(16<<20), "the buckets of the range are not allocated")
+}
+
+func TestAnalyticsService_LogsWithRequestFields(t *testing.T) {
+	level := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	t.Cleanup(func() { zerolog.SetGlobalLevel(level) })
+	var out bytes.Buffer
+	log := zerolog.New(&out).With().Str("requestId", "req-1").Logger()
+	analyticsService := service.NewAnalyticsService(nil, nil, &config.Config{})
+	from := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	_, err := analyticsService.GetFormAnalytics(log.WithContext(context.Background()), "form-1",
+		api.GetFormAnalyticsParams{From: &from, To: &to})
+
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), `"requestId":"req-1"`)
+	assert.Contains(t, out.String(), "Invalid analytics range")
+}
+
+func TestRatio(t *testing.T) {
+	assert.Equal(t, 0.0, analytics.Ratio(5, 0))
+	assert.Equal(t, 0.5, analytics.Ratio(1, 2))
+}
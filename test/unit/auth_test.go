@@ -7,9 +7,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	_ "salesforge-assignment/internal/logger"
 	"salesforge-assignment/internal/middleware/auth"
+	"salesforge-assignment/internal/problem"
 	"testing"
 	"time"
 )
@@ -23,11 +23,11 @@ func dummyLoggerMiddleware() gin.HandlerFunc {
 }
 
 // setupRouter applies dummy logger and auth middleware
-func setupRouter() *gin.Engine {
+func setupRouter(jwtSecret string) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
 	r.Use(dummyLoggerMiddleware())
-	r.Use(auth.AuthMiddleware())
+	r.Use(auth.AuthMiddleware(jwtSecret))
 	return r
 }
 
@@ -36,7 +36,7 @@ func TestGenerateToken_Valid(t *testing.T) {
 	username := "testuser"
 	jwtKey := []byte("mysecretkey")
 
-	tokenString, err := auth.GenerateToken(userID, username, jwtKey)
+	tokenString, err := auth.GenerateToken(userID, username, nil, jwtKey)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 
@@ -56,8 +56,7 @@ func TestGenerateToken_Valid(t *testing.T) {
 }
 
 func TestAuthMiddleware_NoHeader(t *testing.T) {
-	os.Setenv("JWT_SECRET_KEY", "testkey")
-	r := setupRouter()
+	r := setupRouter("testkey")
 	r.GET("/", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -71,8 +70,7 @@ func TestAuthMiddleware_NoHeader(t *testing.T) {
 }
 
 func TestAuthMiddleware_InvalidToken(t *testing.T) {
-	os.Setenv("JWT_SECRET_KEY", "anotherkey")
-	r := setupRouter()
+	r := setupRouter("anotherkey")
 	r.GET("/", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -88,12 +86,11 @@ func TestAuthMiddleware_InvalidToken(t *testing.T) {
 
 func TestAuthMiddleware_ValidToken(t *testing.T) {
 	jwtKey := []byte("supersecret")
-	os.Setenv("JWT_SECRET_KEY", string(jwtKey))
 
-	tokenStr, err := auth.GenerateToken("u1", "user1", jwtKey)
+	tokenStr, err := auth.GenerateToken("u1", "user1", nil, jwtKey)
 	assert.NoError(t, err)
 
-	r := setupRouter()
+	r := setupRouter(string(jwtKey))
 	r.GET("/protected", func(c *gin.Context) {
 		claims, exists := c.Get("user_claims")
 		assert.True(t, exists)
@@ -110,3 +107,44 @@ func TestAuthMiddleware_ValidToken(t *testing.T) {
 	assert.Contains(t, body, "user_id")
 	assert.Contains(t, body, "username")
 }
+
+func TestAuthenticate_RequiresRoles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtKey := []byte("supersecret")
+	authenticate := auth.Authenticate(string(jwtKey))
+
+	tests := []struct {
+		name     string
+		roles    []string
+		required []string
+		expected int
+	}{
+		{"no roles required", nil, nil, http.StatusOK},
+		{"role present", []string{auth.RoleEditor}, []string{auth.RoleEditor}, http.StatusOK},
+		{"role missing", nil, []string{auth.RoleEditor}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenStr, err := auth.GenerateToken("u1", "user1", tt.roles, jwtKey)
+			assert.NoError(t, err)
+
+			r := gin.New()
+			r.Use(dummyLoggerMiddleware())
+			r.GET("/", func(c *gin.Context) {
+				if err := authenticate(c, tt.required); err != nil {
+					problem.Abort(c, err)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tokenStr)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
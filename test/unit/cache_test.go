@@ -0,0 +1,108 @@
+package unit
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/cache"
+	"salesforge-assignment/internal/etag"
+	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"testing"
+	"time"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	lru := cache.NewLRU[string, int](2, 0)
+	lru.Add("a", 1)
+	lru.Add("b", 2)
+	lru.Get("a")
+	lru.Add("c", 3)
+
+	_, ok := lru.Get("b")
+	assert.False(t, ok)
+	value, ok := lru.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 2, lru.Len())
+
+	lru.Remove("a")
+	_, ok = lru.Get("a")
+	assert.False(t, ok)
+
+	lru.Purge()
+	assert.Equal(t, 0, lru.Len())
+}
+
+func TestLRU_ExpiresEntries(t *testing.T) {
+	lru := cache.NewLRU[string, int](2, 10*time.Millisecond)
+	lru.Add("a", 1)
+	time.Sleep(20 * time.Millisecond)
+
+	_, ok := lru.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, lru.Len())
+}
+
+type fakeFormReader struct {
+	repository.FormRepository
+	reads int
+}
+
+func (r *fakeFormReader) GetFormById(_ context.Context, id string) (*model.FormModel, error) {
+	r.reads++
+	enabled := true
+	return &model.FormModel{
+		ID:                  id,
+		OpenTrackingEnabled: &enabled,
+		Steps:               []model.FormStepModel{{ID: "step1", Name: "Step 1"}},
+	}, nil
+}
+
+func TestCachedFormRepository_ReturnsCopies(t *testing.T) {
+	log := zerolog.Nop()
+	reader := &fakeFormReader{}
+	repo := repository.NewCachedFormRepository(&log, nil, reader, cache.NewLRU[string, model.FormModel](10, time.Minute), "")
+
+	form, err := repo.GetFormById(context.Background(), "form1")
+	assert.NoError(t, err)
+	*form.OpenTrackingEnabled = false
+	form.Steps[0].Name = "Changed"
+
+	cached, err := repo.GetFormById(context.Background(), "form1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reader.reads)
+	assert.True(t, *cached.OpenTrackingEnabled)
+	assert.Equal(t, "Step 1", cached.Steps[0].Name)
+}
+
+func TestETag_MatchesWeak(t *testing.T) {
+	assert.True(t, etag.MatchesWeak("*", 3))
+	assert.True(t, etag.MatchesWeak(`"3"`, 3))
+	assert.True(t, etag.MatchesWeak(`W/"3"`, 3))
+	assert.True(t, etag.MatchesWeak(`"1", W/"3"`, 3))
+	assert.False(t, etag.MatchesWeak(`"2"`, 3))
+	assert.False(t, etag.MatchesWeak("", 3))
+}
+
+func TestCacheControl_SetsConfiguredHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.CacheControl("/api/v1", map[string]string{
+		"GET /form/:formId": "private, no-cache",
+	}))
+	r.GET("/api/v1/form/:formId", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.PATCH("/api/v1/form/:formId", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/form/123", nil))
+	assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("PATCH", "/api/v1/form/123", nil))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,298 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/pkg/client"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeAPI records the requests to an API whose responses are set per test.
+type fakeAPI struct {
+	logins atomic.Int32
+	calls  atomic.Int32
+	// token is the token issued by the next login
+	token  atomic.Value
+	handle func(w http.ResponseWriter, r *http.Request, call int32)
+}
+
+func newFakeAPI(t *testing.T, handle func(w http.ResponseWriter, r *http.Request, call int32)) (*fakeAPI, *httptest.Server) {
+	api := &fakeAPI{handle: handle}
+	api.token.Store("token-1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			api.logins.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(client.AuthenticationResponse{Token: api.token.Load().(string)})
+			return
+		}
+		api.handle(w, r, api.calls.Add(1))
+	}))
+	t.Cleanup(server.Close)
+	return api, server
+}
+
+func newTestClient(t *testing.T, server *httptest.Server, opts ...client.Option) *client.ClientWithResponses {
+	opts = append([]client.Option{
+		client.WithCredentials("user@example.com", "password"),
+		client.WithRetry(3, time.Millisecond, time.Millisecond),
+	}, opts...)
+	c, err := client.New(server.URL, opts...)
+	require.NoError(t, err)
+	return c
+}
+
+// bodyCopyingDoer sends a copy of the body read from the request, so that,
+// unlike http.Client, it does not restore bodies of requests sent again.
+type bodyCopyingDoer struct{}
+
+func (bodyCopyingDoer) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+	sent := req.Clone(req.Context())
+	sent.Body, sent.GetBody, sent.ContentLength = io.NopCloser(bytes.NewReader(body)), nil, int64(len(body))
+	return http.DefaultClient.Do(sent)
+}
+
+func writeForm(w http.ResponseWriter, name string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(client.FormResponseGet{Name: name})
+}
+
+func writeProblem(w http.ResponseWriter, status int, code string, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"type":      "about:blank",
+		"title":     http.StatusText(status),
+		"status":    status,
+		"code":      code,
+		"detail":    detail,
+		"requestId": "req-1",
+	})
+}
+
+func TestClient_LogsInAndSendsToken(t *testing.T) {
+	var authorization atomic.Value
+	api, server := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request, _ int32) {
+		authorization.Store(r.Header.Get("Authorization"))
+		writeForm(w, "Form")
+	})
+	c := newTestClient(t, server)
+
+	for range 2 {
+		resp, err := c.GetFormByIdWithResponse(context.Background(), "form-id", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Form", resp.JSON200.Name)
+	}
+	assert.Equal(t, "Bearer token-1", authorization.Load())
+	assert.Equal(t, int32(1), api.logins.Load())
+}
+
+func TestClient_RenewsExpiringToken(t *testing.T) {
+	api, server := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request, _ int32) {
+		writeForm(w, "Form")
+	})
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Second).Unix())))
+	api.token.Store("header." + claims + ".signature")
+	c := newTestClient(t, server)
+
+	for range 2 {
+		_, err := c.GetFormByIdWithResponse(context.Background(), "form-id", nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), api.logins.Load())
+}
+
+func TestClient_LogsInAgainWhenTokenIsRejected(t *testing.T) {
+	t.Run("GET", func(t *testing.T) {
+		var authorization atomic.Value
+		api, server := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request, call int32) {
+			if call == 1 {
+				writeProblem(w, http.StatusUnauthorized, "unauthorized", "invalid token")
+				return
+			}
+			authorization.Store(r.Header.Get("Authorization"))
+			writeForm(w, "Form")
+		})
+		c := newTestClient(t, server)
+		api.token.Store("token-2")
+
+		_, err := c.GetFormByIdWithResponse(context.Background(), "form-id", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-2", authorization.Load())
+		assert.Equal(t, int32(2), api.logins.Load())
+	})
+
+	t.Run("POST", func(t *testing.T) {
+		var names []string
+		api, server := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request, call int32) {
+			var body client.FormCreate
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			names = append(names, body.Name)
+			if call == 1 {
+				writeProblem(w, http.StatusUnauthorized, "unauthorized", "invalid token")
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(client.SelfId{Id: "form-id"})
+		})
+		c := newTestClient(t, server, client.WithHTTPDoer(bodyCopyingDoer{}))
+		api.token.Store("token-2")
+
+		resp, err := c.CreateFormWithResponse(context.Background(), nil, client.FormCreate{Name: "Form"})
+		require.NoError(t, err)
+		assert.Equal(t, "form-id", resp.JSON201.Id)
+		assert.Equal(t, []string{"Form", "Form"}, names)
+		assert.Equal(t, int32(2), api.logins.Load())
+	})
+}
+
+func TestClient_RetriesUnavailableAndThrottledRequests(t *testing.T) {
+	var keys []string
+	api, server := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request, call int32) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		var body client.FormCreate
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Form", body.Name)
+
+		switch call {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", strconv.Itoa(0))
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(client.SelfId{Id: "form-id"})
+		}
+	})
+	c := newTestClient(t, server)
+
+	resp, err := c.CreateFormWithResponse(context.Background(), nil, client.FormCreate{Name: "Form"})
+	require.NoError(t, err)
+	assert.Equal(t, "form-id", resp.JSON201.Id)
+	assert.Equal(t, int32(3), api.calls.Load())
+	require.Len(t, keys, 3)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+	assert.Equal(t, keys[0], keys[2])
+}
+
+func TestClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	api, server := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request, _ int32) {
+		writeProblem(w, http.StatusServiceUnavailable, "internal_error", "")
+	})
+	c := newTestClient(t, server)
+
+	_, err := c.GetFormByIdWithResponse(context.Background(), "form-id", nil)
+	assert.ErrorIs(t, err, client.ErrInternal)
+	assert.Equal(t, int32(3), api.calls.Load())
+}
+
+func TestClient_ReturnsTypedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		handle   func(w http.ResponseWriter)
+		expected error
+		message  string
+		field    string
+	}{
+		{
+			name: "Problem details",
+			handle: func(w http.ResponseWriter) {
+				writeProblem(w, http.StatusNotFound, "resource_not_found", "form not found")
+			},
+			expected: client.ErrResourceNotFound,
+			message:  "form not found",
+		},
+		{
+			name: "Legacy error",
+			handle: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"message":"name already exists","field":"name"}`))
+			},
+			expected: client.ErrConflict,
+			message:  "name already exists",
+			field:    "name",
+		},
+		{
+			name: "No body",
+			handle: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+			},
+			expected: client.ErrPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, server := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request, _ int32) {
+				tt.handle(w)
+			})
+			c := newTestClient(t, server)
+
+			_, err := c.GetFormByIdWithResponse(context.Background(), "form-id", nil)
+			require.ErrorIs(t, err, tt.expected)
+			assert.False(t, errors.Is(err, client.ErrInternal))
+			var apiErr *client.Error
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.message, apiErr.Message)
+			assert.Equal(t, tt.field, apiErr.Field)
+		})
+	}
+}
+
+func TestClient_IteratesWebhookDeliveries(t *testing.T) {
+	deliveries := []string{"d1", "d2", "d3", "d4", "d5"}
+	api, server := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request, _ int32) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		start := min((page-1)*pageSize, len(deliveries))
+		end := min(start+pageSize, len(deliveries))
+
+		body := client.WebhookDeliveryPage{Page: page, PageSize: pageSize, Total: int64(len(deliveries))}
+		body.Items = []client.WebhookDeliveryResponseGet{}
+		for _, id := range deliveries[start:end] {
+			body.Items = append(body.Items, client.WebhookDeliveryResponseGet{Self: client.SelfId{Id: id}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	})
+	c := newTestClient(t, server)
+
+	var ids []string
+	for delivery, err := range client.WebhookDeliveries(context.Background(), c, "webhook-id", 2) {
+		require.NoError(t, err)
+		ids = append(ids, delivery.Self.Id)
+	}
+	assert.Equal(t, deliveries, ids)
+	assert.Equal(t, int32(3), api.calls.Load())
+
+	ids = nil
+	for delivery := range client.WebhookDeliveries(context.Background(), c, "webhook-id", 2) {
+		ids = append(ids, delivery.Self.Id)
+		break
+	}
+	assert.Equal(t, []string{"d1"}, ids)
+}
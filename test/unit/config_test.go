@@ -0,0 +1,270 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"salesforge-assignment/internal/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConfigFile = `
+server:
+  port: 8080
+  shutdownTimeout: 10s
+log:
+  level: debug
+database:
+  dsn: postgres://app:file-password@db/forms
+`
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "server.cfg.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func envOf(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+}
+
+func loadConfig(t *testing.T, args []string, env map[string]string) (*config.Config, error) {
+	return config.Load(flag.NewFlagSet("test", flag.ContinueOnError), args, envOf(env))
+}
+
+func TestConfig_Precedence(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	cfg, err := loadConfig(t, []string{"-config", path, "-server.port=9000"}, map[string]string{
+		"FORMS_SERVER_PORT":             "8081",
+		"FORMS_LOG_LEVEL":               "warn",
+		"FORMS_WEBHOOKS_MAX_BACKOFF":    "30m",
+		"FORMS_LOG_REDACTION_KEYS":      "password, ssn",
+		"FORMS_LOG_REDACTION_KEEP_IPS":  "true",
+		"FORMS_TRACING_SAMPLE_RATIO":    "0.5",
+		"FORMS_OUTBOX_NATS_URL":         "nats://nats:4222",
+		"FORMS_CACHE_FORMS_SIZE":        "10",
+		"FORMS_SERVER_SHUTDOWN_TIMEOUT": "",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 9000, cfg.Server.Port, "flags override the environment")
+	assert.Equal(t, "warn", cfg.Log.Level, "the environment overrides the file")
+	assert.Equal(t, "/api/v1", cfg.Server.BaseURL, "defaults apply to what nothing sets")
+	assert.Equal(t, 10*time.Second, cfg.Server.ShutdownTimeout, "empty durations are ignored")
+	assert.Equal(t, 5*time.Second, cfg.Server.DrainDelay)
+	assert.Equal(t, 30*time.Minute, cfg.Webhooks.MaxBackoff)
+	assert.Equal(t, []string{"password", "ssn"}, cfg.Log.Redaction.Keys)
+	assert.True(t, cfg.Log.Redaction.KeepIPs)
+	assert.Equal(t, 0.5, cfg.Tracing.SampleRatio)
+	assert.Equal(t, "nats://nats:4222", cfg.Outbox.NATS.URL)
+	assert.Equal(t, 10, cfg.Cache.Forms.Size)
+	assert.Equal(t, "postgres://app:file-password@db/forms", cfg.Database.DSN)
+}
+
+func TestConfig_InvalidEnvironmentValuesAreAggregated(t *testing.T) {
+	_, err := loadConfig(t, []string{"-config", writeConfigFile(t, testConfigFile)}, map[string]string{
+		"FORMS_SERVER_PORT":          "eighty",
+		"FORMS_WEBHOOKS_MAX_BACKOFF": "soon",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FORMS_SERVER_PORT")
+	assert.Contains(t, err.Error(), "FORMS_WEBHOOKS_MAX_BACKOFF")
+}
+
+func TestConfig_SecretsFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "jwt")
+	require.NoError(t, os.WriteFile(secretFile, []byte("from-file\n"), 0o600))
+
+	cfg, err := loadConfig(t, []string{"-config", writeConfigFile(t, testConfigFile)}, map[string]string{
+		"FORMS_AUTH_JWT_SECRET_FILE": secretFile,
+		"TRACKING_SECRET_KEY":        "legacy",
+		"DATABASE_DSN":               "postgres://legacy",
+		"FORMS_DATABASE_DSN":         "postgres://preferred",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", cfg.Auth.JWTSecret)
+	assert.Equal(t, "legacy", cfg.Tracking.Secret, "legacy variable names are still read")
+	assert.Equal(t, "postgres://preferred", cfg.Database.DSN, "FORMS_* variables win over legacy names")
+}
+
+func TestConfig_MissingFiles(t *testing.T) {
+	_, err := loadConfig(t, []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")}, nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = loadConfig(t, []string{"-config", writeConfigFile(t, testConfigFile)}, map[string]string{
+		"FORMS_AUTH_JWT_SECRET_FILE": filepath.Join(t.TempDir(), "missing"),
+	})
+	assert.ErrorContains(t, err, "FORMS_AUTH_JWT_SECRET_FILE")
+}
+
+func TestConfig_UnknownKeysAreRejected(t *testing.T) {
+	_, err := loadConfig(t, []string{"-config", writeConfigFile(t, "server:\n  prot: 8080\n")}, nil)
+
+	assert.ErrorContains(t, err, "field prot not found")
+}
+
+func TestConfig_ValidateAggregatesErrors(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Server.Port = 0
+	cfg.Server.DrainDelay = 30 * time.Second
+	cfg.Log.Level = "loud"
+	cfg.Webhooks.MaxAttempts = -1
+	cfg.Tracing.Exporter = "zipkin"
+	cfg.Database.MaxOpenConns = 5
+	cfg.Database.MaxIdleConns = 10
+	cfg.Tracking.MaxEventAge = 0
+	cfg.Webhooks.AllowedNetworks = []string{"10.0.0.0/8", "internal"}
+	cfg.RateLimits["login"] = config.RateLimitConfig{Requests: 0, Per: time.Minute}
+	cfg.Features = map[string]bool{"ingets": false}
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	for _, expected := range []string{
+		"server.port: must be between 1 and 65535",
+		"server.drainDelay: must be between 0 and server.shutdownTimeout (25s), got 30s",
+		"log.level: unknown level",
+		"webhooks.maxAttempts: must not be negative",
+		"tracing.exporter: must be otlp, stdout or empty",
+		"database.dsn: is required",
+		"database.maxIdleConns: must not exceed database.maxOpenConns (5), got 10",
+		"auth.jwtSecret: is required",
+		"tracking.secret: is required",
+		"tracking.maxEventAge: must be positive, got 0s",
+		`webhooks.allowedNetworks: must be CIDRs or IP addresses, got "internal"`,
+		"rateLimits.login.requests: must be positive, got 0",
+		"features.ingets: unknown feature, known are ingest, webhookDeliveries",
+	} {
+		assert.Contains(t, err.Error(), expected)
+	}
+}
+
+func TestConfig_ValidateAcceptsCompleteConfig(t *testing.T) {
+	cfg, err := config.LoadConfig("../../server.cfg.yaml")
+	require.NoError(t, err)
+	cfg.Database.DSN = "postgres://app:secret@db/forms"
+	cfg.Auth.JWTSecret = "jwt"
+	cfg.Tracking.Secret = "tracking"
+
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_PrintMasksSecrets(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Database.DSN = "postgres://app:secret@db/forms"
+	cfg.Auth.JWTSecret = "jwt-secret"
+	cfg.Tracking.Secret = "tracking-secret"
+
+	var out bytes.Buffer
+	require.NoError(t, config.Print(&out, cfg))
+
+	assert.NotContains(t, out.String(), "secret@")
+	assert.NotContains(t, out.String(), "jwt-secret")
+	assert.NotContains(t, out.String(), "tracking-secret")
+	assert.Contains(t, out.String(), "jwtSecret: '[REDACTED]'")
+	assert.Contains(t, out.String(), "shutdownTimeout: 25s")
+	assert.Equal(t, "jwt-secret", cfg.Auth.JWTSecret, "the printed configuration is a copy")
+}
+
+var liveSecrets = map[string]string{
+	"FORMS_AUTH_JWT_SECRET": "jwt",
+	"TRACKING_SECRET_KEY":   "tracking",
+}
+
+func newLiveConfig(t *testing.T, path string) *config.Live {
+	load := func() (*config.Config, error) {
+		return loadConfig(t, []string{"-config", path}, liveSecrets)
+	}
+	cfg, err := load()
+	require.NoError(t, err)
+	log := zerolog.Nop()
+	return config.NewLive(&log, cfg, load)
+}
+
+func TestLiveConfig_ReloadAppliesSafeSettings(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+	live := newLiveConfig(t, path)
+	var reloaded *config.Config
+	live.OnReload(func(cfg *config.Config) { reloaded = cfg })
+
+	require.NoError(t, os.WriteFile(path, []byte(testConfigFile+`
+cors:
+  allowedOrigins: ["https://app.example.com"]
+rateLimits:
+  login: {requests: 5, per: 1m}
+features:
+  webhookDeliveries: false
+`), 0o600))
+	require.NoError(t, live.Reload())
+	assert.Equal(t, []string{"https://app.example.com"}, live.Current().CORS.AllowedOrigins)
+	assert.Equal(t, 5, live.Current().RateLimits["login"].Requests)
+	assert.False(t, live.Current().Enabled(config.FeatureWebhookDeliveries))
+	assert.True(t, live.Current().Enabled(config.FeatureIngest))
+	require.NoError(t, os.WriteFile(path, []byte(strings.NewReplacer("level: debug", "level: warn", "port: 8080", "port: 9000").Replace(testConfigFile)), 0o600))
+	require.NoError(t, live.Reload())
+
+	current := live.Current()
+	assert.Equal(t, uint64(3), current.Version)
+	assert.Equal(t, "warn", current.Log.Level)
+	assert.Empty(t, current.CORS.AllowedOrigins)
+	assert.True(t, current.Enabled(config.FeatureWebhookDeliveries))
+	assert.Equal(t, 8080, current.Server.Port, "restart-only settings are kept")
+	assert.Same(t, current.Config, reloaded)
+}
+
+func TestLiveConfig_ReloadWithoutChanges(t *testing.T) {
+	live := newLiveConfig(t, writeConfigFile(t, testConfigFile))
+
+	require.NoError(t, live.Reload())
+
+	assert.Equal(t, uint64(1), live.Current().Version)
+}
+
+func TestLiveConfig_RejectsInvalidReloads(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+	live := newLiveConfig(t, path)
+	before := live.Current()
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(testConfigFile, "level: debug", "level: loud", 1)), 0o600))
+	assert.ErrorContains(t, live.Reload(), "log.level")
+	require.NoError(t, os.WriteFile(path, []byte("server: ["), 0o600))
+	assert.Error(t, live.Reload())
+
+	assert.Same(t, before, live.Current())
+}
+
+func TestLiveConfig_WatchReloadsOnFileChanges(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+	live := newLiveConfig(t, path)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		live.Watch(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	// The watch starts asynchronously, so the file is written until it is seen
+	assert.Eventually(t, func() bool {
+		content := strings.Replace(testConfigFile, "level: debug", "level: error", 1)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return live.Current().Log.Level == "error"
+	}, 5*time.Second, 300*time.Millisecond)
+}
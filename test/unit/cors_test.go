@@ -0,0 +1,85 @@
+package unit
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/middleware"
+	"testing"
+	"time"
+)
+
+func corsRouter(cfg *config.CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.CORS(func() config.CORSConfig { return *cfg }))
+	r.GET("/form/:formId", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func corsRequest(r *gin.Engine, method string, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/form/1", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	cfg := &config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, MaxAge: 10 * time.Minute}
+	r := corsRouter(cfg)
+
+	w := corsRequest(r, http.MethodOptions, "https://app.example.com")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Idempotency-Key")
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+
+	w = corsRequest(r, http.MethodGet, "https://app.example.com")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "X-Request-ID")
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
+
+func TestCORS_OtherOrigin(t *testing.T) {
+	r := corsRouter(&config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}})
+
+	w := corsRequest(r, http.MethodGet, "https://evil.example.com")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
+
+func TestCORS_VariesByOriginWithoutOrigin(t *testing.T) {
+	r := corsRouter(&config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}})
+
+	w := corsRequest(r, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	r := corsRouter(&config.CORSConfig{AllowedOrigins: []string{"*"}})
+
+	w := corsRequest(r, http.MethodGet, "https://any.example.com")
+	assert.Equal(t, "https://any.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_AppliesConfigurationChanges(t *testing.T) {
+	cfg := &config.CORSConfig{}
+	r := corsRouter(cfg)
+	assert.Empty(t, corsRequest(r, http.MethodGet, "https://app.example.com").Header().Get("Access-Control-Allow-Origin"))
+
+	cfg.AllowedOrigins = []string{"https://app.example.com"}
+	assert.Equal(t, "https://app.example.com",
+		corsRequest(r, http.MethodGet, "https://app.example.com").Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,37 @@
+package unit
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/middleware"
+	"testing"
+	"time"
+)
+
+func requestDeadline(timeout time.Duration) (time.Time, bool) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.QueryDeadline(timeout))
+	var deadline time.Time
+	var ok bool
+	r.GET("/form/:formId", func(c *gin.Context) {
+		deadline, ok = c.Request.Context().Deadline()
+		c.Status(http.StatusOK)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/form/1", nil))
+	return deadline, ok
+}
+
+func TestQueryDeadline(t *testing.T) {
+	start := time.Now()
+	deadline, ok := requestDeadline(2 * time.Second)
+	assert.True(t, ok)
+	assert.WithinDuration(t, start.Add(2*time.Second), deadline, time.Second)
+}
+
+func TestQueryDeadline_Disabled(t *testing.T) {
+	_, ok := requestDeadline(0)
+	assert.False(t, ok)
+}
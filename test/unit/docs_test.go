@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/docs"
+	"testing"
+)
+
+type specDocument struct {
+	OpenAPI string `json:"openapi" yaml:"openapi"`
+	Servers []struct {
+		URL string `json:"url" yaml:"url"`
+	} `json:"servers" yaml:"servers"`
+	Paths map[string]any `json:"paths" yaml:"paths"`
+}
+
+func setupDocsRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Server.PublicUrl = "https://forms.example.com/"
+	cfg.Server.BaseURL = "/api/v1"
+	handler, err := docs.NewHandler(cfg)
+	require.NoError(t, err)
+
+	r := gin.New()
+	handler.Register(r)
+	return r
+}
+
+func getDocs(r *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestDocs_ServesSpecWithPublicServer(t *testing.T) {
+	r := setupDocsRouter(t)
+
+	tests := []struct {
+		path        string
+		contentType string
+		unmarshal   func([]byte, any) error
+	}{
+		{"/openapi.json", "application/json", json.Unmarshal},
+		{"/openapi.yaml", "application/yaml", yaml.Unmarshal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := getDocs(r, tt.path)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			var spec specDocument
+			require.NoError(t, tt.unmarshal(w.Body.Bytes(), &spec))
+			assert.Equal(t, "3.0.0", spec.OpenAPI)
+			require.Len(t, spec.Servers, 1)
+			assert.Equal(t, "https://forms.example.com/api/v1", spec.Servers[0].URL)
+			assert.Contains(t, spec.Paths, "/form/{formId}")
+		})
+	}
+}
+
+func TestDocs_ServesBundledExplorer(t *testing.T) {
+	r := setupDocsRouter(t)
+
+	w := getDocs(r, "/docs")
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+
+	w = getDocs(r, "/docs/")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "swagger-ui-bundle.js")
+	assert.NotContains(t, w.Body.String(), "https://")
+
+	w = getDocs(r, "/docs/swagger-initializer.js")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"../openapi.json"`)
+
+	w = getDocs(r, "/docs/swagger-ui-bundle.js")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Body.Bytes())
+}
@@ -70,12 +70,15 @@ func TestHandleError_HTTPErrorTypes(t *testing.T) {
 		code int
 		msg  string
 	}{
-		{&apierrors.InvalidApplicationStateError{}, 400, "Invalid application state"},
+		{&apierrors.InvalidApplicationStateError{}, 500, "Invalid application state"},
 		{&apierrors.PermissionDeniedError{}, 403, "Permission denied"},
 		{&apierrors.InvalidInputError{}, 400, "Invalid input"},
 		{&apierrors.ResourceNotFoundError{}, 404, "Resource not found"},
 		{&apierrors.InvalidCredentialsError{}, 401, "Invalid credentials"},
 		{&apierrors.UnauthorizedError{}, 401, "Unauthorized access"},
+		{&apierrors.PreconditionFailedError{}, 412, "Precondition failed"},
+		{&apierrors.ConflictError{}, 409, "Conflict"},
+		{&apierrors.UnprocessableEntityError{}, 422, "Unprocessable entity"},
 	}
 
 	for _, tt := range types {
@@ -125,7 +128,7 @@ func TestHandleError_DefaultError(t *testing.T) {
 	handler.HandleError(c, errors.New("unknown"))
 
 	resp := w.Result()
-	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 
 	var body api.ErrorResponse
 	err := json.NewDecoder(resp.Body).Decode(&body)
@@ -0,0 +1,21 @@
+package unit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"salesforge-assignment/internal/etag"
+	"testing"
+)
+
+func TestETag_Format(t *testing.T) {
+	assert.Equal(t, `"3"`, etag.Format(3))
+}
+
+func TestETag_Matches(t *testing.T) {
+	assert.True(t, etag.Matches("", 3))
+	assert.True(t, etag.Matches("*", 3))
+	assert.True(t, etag.Matches(`"3"`, 3))
+	assert.True(t, etag.Matches(`"1", "3"`, 3))
+	assert.False(t, etag.Matches(`"2"`, 3))
+	assert.False(t, etag.Matches(`W/"3"`, 3))
+	assert.False(t, etag.Matches(`3`, 3))
+}
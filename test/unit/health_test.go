@@ -0,0 +1,170 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/health"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/worker"
+	"salesforge-assignment/migrations"
+	"testing"
+	"time"
+)
+
+type fakeMigrationRepository struct {
+	repository.MigrationRepository
+	version uint64
+	dirty   bool
+	err     error
+}
+
+func (r *fakeMigrationRepository) GetVersion(context.Context) (uint64, bool, error) {
+	return r.version, r.dirty, r.err
+}
+
+type probeResponse struct {
+	Status        string            `json:"status"`
+	Checks        map[string]string `json:"checks"`
+	ConfigVersion uint64            `json:"configVersion"`
+}
+
+func probe(t *testing.T, h *health.Handler, path string) (int, probeResponse) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h.Register(r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	var resp probeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func TestHealth_LivenessIgnoresChecks(t *testing.T) {
+	log := zerolog.Nop()
+	h := health.NewHandler(&log)
+	h.AddCheck("database", func(context.Context) error { return errors.New("down") })
+
+	code, resp := probe(t, h, "/healthz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", resp.Status)
+}
+
+func TestHealth_Readiness(t *testing.T) {
+	log := zerolog.Nop()
+
+	t.Run("Ready when all checks pass", func(t *testing.T) {
+		h := health.NewHandler(&log)
+		h.AddCheck("database", func(context.Context) error { return nil })
+
+		code, resp := probe(t, h, "/readyz")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, map[string]string{"database": "ok"}, resp.Checks)
+	})
+
+	t.Run("Unavailable when a check fails", func(t *testing.T) {
+		h := health.NewHandler(&log)
+		h.AddCheck("database", func(context.Context) error { return nil })
+		h.AddCheck("migrations", health.Migrations(&fakeMigrationRepository{version: 1}, 2))
+
+		code, resp := probe(t, h, "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "unavailable", resp.Status)
+		assert.Equal(t, "ok", resp.Checks["database"])
+		assert.Equal(t, "schema version 1, expected 2", resp.Checks["migrations"])
+	})
+
+	t.Run("Reports the configuration version", func(t *testing.T) {
+		h := health.NewHandler(&log)
+		h.ReportConfigVersion(func() uint64 { return 3 })
+
+		code, resp := probe(t, h, "/readyz")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, uint64(3), resp.ConfigVersion)
+	})
+
+	t.Run("Unavailable while draining", func(t *testing.T) {
+		h := health.NewHandler(&log)
+		h.Drain()
+
+		code, resp := probe(t, h, "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "draining", resp.Status)
+	})
+}
+
+func TestHealth_Migrations(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository *fakeMigrationRepository
+		expected   string
+	}{
+		{"Current", &fakeMigrationRepository{version: 2}, ""},
+		{"Newer", &fakeMigrationRepository{version: 3}, ""},
+		{"Pending", &fakeMigrationRepository{version: 1}, "schema version 1, expected 2"},
+		{"Failed", &fakeMigrationRepository{version: 2, dirty: true}, "migration 2 failed"},
+		{"Unknown", &fakeMigrationRepository{err: errors.New("no table")}, "no table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := health.Migrations(tt.repository, 2)(context.Background())
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMigrations_LatestVersionIsNewestFile(t *testing.T) {
+	version, err := migrations.LatestVersion()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, version, uint64(20261019170000))
+}
+
+func TestWorkerGroup(t *testing.T) {
+	log := zerolog.Nop()
+
+	t.Run("Stop waits for workers", func(t *testing.T) {
+		group := worker.NewGroup(&log)
+		var stopped bool
+		group.Go("worker", func(ctx context.Context) {
+			<-ctx.Done()
+			stopped = true
+		})
+
+		require.NoError(t, group.Stop(context.Background()))
+		assert.True(t, stopped)
+		assert.NoError(t, group.Check(context.Background()))
+	})
+
+	t.Run("Crashed worker fails the check", func(t *testing.T) {
+		group := worker.NewGroup(&log)
+		group.Go("crashing", func(context.Context) { panic("boom") })
+
+		assert.Eventually(t, func() bool {
+			return group.Check(context.Background()) != nil
+		}, time.Second, time.Millisecond)
+		assert.EqualError(t, group.Check(context.Background()), "workers stopped: crashing")
+	})
+
+	t.Run("Stop gives up on hanging workers", func(t *testing.T) {
+		group := worker.NewGroup(&log)
+		release := make(chan struct{})
+		defer close(release)
+		group.Go("hanging", func(context.Context) { <-release })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, group.Stop(ctx), context.DeadlineExceeded)
+	})
+}
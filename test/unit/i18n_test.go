@@ -0,0 +1,118 @@
+package unit
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/handler"
+	"salesforge-assignment/internal/i18n"
+	"strings"
+	"testing"
+)
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{"", "en"},
+		{"es", "es"},
+		{"es-MX,es;q=0.9", "es"},
+		{"de-DE,fr;q=0.8,en;q=0.5", "fr"},
+		{"en;q=0.5,fr;q=0.9", "fr"},
+		{"de", "en"},
+		{"not a language;;", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			assert.Equal(t, tt.expected, i18n.Locale(tt.acceptLanguage))
+		})
+	}
+}
+
+func handleErrorWithLanguage(err error, acceptLanguage string) *httptest.ResponseRecorder {
+	c, w := setupContext()
+	c.Request = httptest.NewRequest(http.MethodPost, "/form", nil)
+	c.Request.Header.Set("Accept-Language", acceptLanguage)
+	handler.HandleError(c, err)
+	return w
+}
+
+func TestValidationError_ReportsPathRuleAndParam(t *testing.T) {
+	req := api.FormCreate{
+		Name: "Form",
+		Steps: api.FormStepCreateArray{
+			{Name: "Step 1", Content: "Content", Step: 1},
+			{Name: "Step 2", Content: strings.Repeat("x", 257), Step: 2},
+		},
+	}
+	err := i18n.Validator().Struct(&req)
+	require.Error(t, err)
+
+	tests := []struct {
+		acceptLanguage string
+		message        string
+	}{
+		{"", "content must be a maximum of 256 characters in length"},
+		{"es", "content debe tener un máximo de 256 caracteres de longitud"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			w := handleErrorWithLanguage(err, tt.acceptLanguage)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var body api.ValidationErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			require.Len(t, body.Errors, 1)
+			assert.Equal(t, "steps[1].content", body.Errors[0].Field)
+			assert.Equal(t, "max", body.Errors[0].Rule)
+			assert.Equal(t, "256", *body.Errors[0].Param)
+			assert.Equal(t, tt.message, body.Errors[0].Message)
+		})
+	}
+}
+
+func TestHandleError_LocalizesMessages(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		acceptLanguage string
+		message        string
+		language       string
+	}{
+		{"English by default", &apierrors.ConflictError{Field: "name"}, "", "Value of 'name' is already in use", "en"},
+		{"Unsupported language", &apierrors.ResourceNotFoundError{}, "de", "Resource not found", "en"},
+		{"Spanish", &apierrors.ResourceNotFoundError{}, "es-ES", "Recurso no encontrado", "es"},
+		{"French with field", &apierrors.ConflictError{Field: "name"}, "fr", "La valeur de 'name' est déjà utilisée", "fr"},
+		{"Reason", &apierrors.UnauthorizedError{Reason: apierrors.ReasonInvalidToken}, "es", "Token no válido", "es"},
+		{"Untranslated reason", &apierrors.UnauthorizedError{Reason: "Token expired"}, "fr", "Token expired", "fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := handleErrorWithLanguage(tt.err, tt.acceptLanguage)
+
+			var body api.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.message, body.Message)
+			assert.Equal(t, tt.language, w.Header().Get("Content-Language"))
+		})
+	}
+}
+
+func TestHandleError_ValidationErrorWithoutRequest(t *testing.T) {
+	c, w := setupContext()
+	gin.SetMode(gin.TestMode)
+
+	handler.HandleError(c, &apierrors.InvalidInputError{})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+}
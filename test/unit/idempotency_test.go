@@ -0,0 +1,162 @@
+package unit
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/idempotency"
+	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/problem"
+	"salesforge-assignment/internal/repository"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeIdempotencyRepository struct {
+	repository.IdempotencyRepository
+	keys map[string]model.IdempotencyKeyModel
+}
+
+func (r *fakeIdempotencyRepository) ReserveKey(_ context.Context, record *model.IdempotencyKeyModel, _ time.Time) (bool, error) {
+	if _, ok := r.keys[record.Key]; ok {
+		return false, nil
+	}
+	r.keys[record.Key] = *record
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepository) GetKey(_ context.Context, _ string, key string) (*model.IdempotencyKeyModel, error) {
+	record, ok := r.keys[key]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &record, nil
+}
+
+func (r *fakeIdempotencyRepository) CompleteKey(_ context.Context, record *model.IdempotencyKeyModel) error {
+	if !r.holds(record) {
+		return repository.ErrKeyTakenOver
+	}
+	r.keys[record.Key] = *record
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) ReleaseKey(_ context.Context, record *model.IdempotencyKeyModel) error {
+	if !r.holds(record) {
+		return repository.ErrKeyTakenOver
+	}
+	delete(r.keys, record.Key)
+	return nil
+}
+
+// holds reports whether the key is still reserved by record.
+func (r *fakeIdempotencyRepository) holds(record *model.IdempotencyKeyModel) bool {
+	stored, ok := r.keys[record.Key]
+	return ok && stored.StatusCode == nil && stored.CreatedAt.Equal(record.CreatedAt) && stored.RequestHash == record.RequestHash
+}
+
+func TestIdempotencyGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &fakeIdempotencyRepository{keys: make(map[string]model.IdempotencyKeyModel)}
+	// A local logger, since InitLogger changes the global level for the other tests
+	nop := zerolog.Nop()
+	log := &nop
+	guard := idempotency.NewGuard(log, repo, config.IdempotencyConfig{})
+
+	calls := 0
+	status := http.StatusCreated
+	r := gin.New()
+	r.Use(middleware.InjectLogger(log))
+	r.Use(problem.Recovery())
+	r.Use(guard.Middleware())
+	r.POST("/form", func(c *gin.Context) {
+		calls++
+		c.JSON(status, gin.H{"call": calls})
+	})
+	r.POST("/panic", func(c *gin.Context) {
+		panic("handler failed")
+	})
+	r.POST("/slow", func(c *gin.Context) {
+		// A retry takes over the key while the request is still running
+		key := c.GetHeader(idempotency.KeyHeader)
+		retry := repo.keys[key]
+		retry.CreatedAt = retry.CreatedAt.Add(2 * time.Minute)
+		repo.keys[key] = retry
+		c.JSON(status, gin.H{"slow": true})
+	})
+
+	postTo := func(target string, key string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", target, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(idempotency.KeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	post := func(key string, body string) *httptest.ResponseRecorder {
+		return postTo("/form", key, body)
+	}
+
+	t.Run("retry replays the first response", func(t *testing.T) {
+		first := post("key-1", `{"name":"a"}`)
+		retry := post("key-1", `{"name":"a"}`)
+
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get(idempotency.ReplayedHeader))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("different body is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnprocessableEntity, post("key-1", `{"name":"b"}`).Code)
+	})
+
+	t.Run("in-flight request conflicts", func(t *testing.T) {
+		repo.keys["key-2"] = model.IdempotencyKeyModel{Key: "key-2", RequestHash: repo.keys["key-1"].RequestHash}
+		assert.Equal(t, http.StatusConflict, post("key-2", `{"name":"a"}`).Code)
+	})
+
+	t.Run("server errors release the key", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		post("key-3", `{}`)
+		_, stored := repo.keys["key-3"]
+		assert.False(t, stored)
+		status = http.StatusCreated
+	})
+
+	t.Run("panics release the key", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, postTo("/panic", "key-4", `{}`).Code)
+		_, stored := repo.keys["key-4"]
+		assert.False(t, stored)
+	})
+
+	t.Run("responses of requests whose key was taken over are not stored", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, postTo("/slow", "key-5", `{}`).Code)
+		assert.Nil(t, repo.keys["key-5"].StatusCode, "the retry holding the key is still in flight")
+
+		status = http.StatusInternalServerError
+		postTo("/slow", "key-6", `{}`)
+		_, stored := repo.keys["key-6"]
+		assert.True(t, stored, "the retry keeps the key")
+		status = http.StatusCreated
+	})
+
+	t.Run("different query is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnprocessableEntity, postTo("/form?draft=true", "key-1", `{"name":"a"}`).Code)
+	})
+
+	t.Run("requests without a key pass through", func(t *testing.T) {
+		before := calls
+		post("", `{}`)
+		post("", `{}`)
+		assert.Equal(t, before+2, calls)
+	})
+}
@@ -1,6 +1,7 @@
 package unit
 
 import (
+	"bytes"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -8,6 +9,7 @@ import (
 	"net/http/httptest"
 	"salesforge-assignment/internal/logger"
 	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/middleware/auth"
 	"strings"
 	"testing"
 	"time"
@@ -65,5 +67,71 @@ func TestGinLogger_EmitsStructuredLog(t *testing.T) {
 	assert.Contains(t, output, "\"path\":\"/ping?foo=bar\"")
 	assert.Contains(t, output, "\"status\":200")
 	assert.Contains(t, output, "\"latency\":")
-	assert.Contains(t, output, "\"ip\":\"203.0.113.5\"")
+	assert.Contains(t, output, "\"ip\":\"203.0.113.0\"")
+}
+
+func TestGinLogger_LogsRouteTemplateInsteadOfPathParameters(t *testing.T) {
+	buf := new(strings.Builder)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(captureLogsMiddleware(buf))
+	r.Use(middleware.GinLogger())
+	r.GET("/t/c/:token", func(c *gin.Context) {
+		c.Status(http.StatusFound)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/t/c/s3cr3t-token?url=https%3A%2F%2Fexample.com", nil)
+	r.ServeHTTP(w, req)
+
+	output := buf.String()
+	assert.NotContains(t, output, "s3cr3t-token")
+	assert.Contains(t, output, "\"path\":\"/t/c/:token?url=https%3A%2F%2Fexample.com\"")
+}
+
+func TestInjectLogger_RequestContextLoggerHasRequestFields(t *testing.T) {
+	var logs bytes.Buffer
+	log := captureLogs(t, &logs)
+	jwtKey := []byte("contextkey")
+	token, err := auth.GenerateToken("user-1", "alice", nil, jwtKey)
+	assert.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.RequestID())
+	r.Use(middleware.InjectLogger(&log))
+	r.Use(middleware.GinLogger())
+	r.Use(auth.AuthMiddleware(string(jwtKey)))
+	r.GET("/form/:formId", func(c *gin.Context) {
+		zerolog.Ctx(c.Request.Context()).Info().Msg("From the service")
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/form/123", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, "req-123", w.Header().Get(middleware.RequestIDHeader))
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Contains(t, line, `"requestId":"req-123"`)
+		assert.Contains(t, line, `"route":"/form/:formId"`)
+		assert.Contains(t, line, `"userId":"user-1"`)
+	}
+	assert.Equal(t, 1, strings.Count(lines[1], "requestId"))
+}
+
+func TestUpdateLogger_WithoutLoggerIsNoop(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	middleware.UpdateLogger(c, func(log zerolog.Context) zerolog.Context {
+		return log.Str("userId", "user-1")
+	})
+
+	_, ok := c.Get(middleware.LoggerKey)
+	assert.False(t, ok)
 }
@@ -0,0 +1,78 @@
+package unit
+
+import (
+	"context"
+	"database/sql"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/metrics"
+	"strings"
+	"testing"
+)
+
+type fakeFormCounter struct{}
+
+func (fakeFormCounter) CountFormsAndSteps(context.Context) (int64, int64, error) {
+	return 3, 7, nil
+}
+
+func scrapeMetrics(t *testing.T, handler http.Handler) string {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	return w.Body.String()
+}
+
+func TestMetrics_RequestsAreLabelledByRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// The request metrics are global, a route of its own keeps the counts of
+	// this run apart from those of other runs
+	route := "/" + uuid.NewString() + "/form/:formId"
+	r := gin.New()
+	r.Use(metrics.Middleware())
+	r.GET(route, func(c *gin.Context) { c.Status(http.StatusTeapot) })
+
+	for _, path := range []string{strings.Replace(route, ":formId", "a", 1), strings.Replace(route, ":formId", "b", 1), "/wp-admin.php"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrapeMetrics(t, metrics.Handler())
+	assert.Contains(t, body, `forms_api_http_requests_total{method="GET",route="`+route+`",status="418"} 2`)
+	assert.Contains(t, body, `forms_api_http_requests_total{method="GET",route="unmatched",status="404"}`)
+	assert.Contains(t, body, `forms_api_http_request_duration_seconds_count{method="GET",route="`+route+`",status="418"} 2`)
+	assert.NotContains(t, body, strings.Replace(route, ":formId", "a", 1))
+}
+
+func TestMetrics_LoginsAreCountedByOutcome(t *testing.T) {
+	metrics.ObserveLogin(nil)
+	metrics.ObserveLogin(&apierrors.InvalidCredentialsError{})
+	metrics.ObserveLogin(&apierrors.InvalidApplicationStateError{})
+
+	body := scrapeMetrics(t, metrics.Handler())
+	assert.Contains(t, body, `forms_api_logins_total{outcome="success"}`)
+	assert.Contains(t, body, `forms_api_logins_total{outcome="invalid_credentials"}`)
+	assert.Contains(t, body, `forms_api_logins_total{outcome="error"}`)
+}
+
+func TestMetrics_DatabaseGauges(t *testing.T) {
+	db, err := sql.Open("pgx", "postgres://localhost:1/unused")
+	require.NoError(t, err)
+	defer db.Close()
+	log := zerolog.Nop()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, metrics.RegisterDatabase(registry, &log, db, fakeFormCounter{}))
+
+	body := scrapeMetrics(t, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	assert.Contains(t, body, "forms_api_forms 3")
+	assert.Contains(t, body, "forms_api_form_steps 7")
+	assert.Contains(t, body, `go_sql_open_connections{db_name="forms"} 0`)
+}
@@ -0,0 +1,186 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"salesforge-assignment/internal/migrate"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/migrations"
+	"testing"
+)
+
+// fakeSchema records the scripts run by the migrator.
+type fakeSchema struct {
+	repository.MigrationRepository
+	version uint64
+	dirty   bool
+	applied bool
+	locked  bool
+	scripts []string
+	tables  map[string]*repository.TableSchema
+}
+
+func (s *fakeSchema) WithLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.locked = true
+	defer func() { s.locked = false }()
+	return fn(ctx)
+}
+
+func (s *fakeSchema) DescribeTable(_ context.Context, name string) (*repository.TableSchema, error) {
+	return s.tables[name], nil
+}
+
+func (s *fakeSchema) EnsureVersionTable(context.Context) error {
+	return nil
+}
+
+func (s *fakeSchema) GetVersion(context.Context) (uint64, bool, error) {
+	if !s.applied {
+		return 0, false, repository.ErrNoMigration
+	}
+	return s.version, s.dirty, nil
+}
+
+func (s *fakeSchema) Migrate(_ context.Context, script string, version uint64) error {
+	if !s.locked {
+		return errors.New("migrating without the lock")
+	}
+	s.scripts = append(s.scripts, script)
+	s.version, s.applied = version, version != 0
+	return nil
+}
+
+var testMigrations = []migrations.Migration{
+	{Version: 1, Name: "1_first", Up: "up 1", Down: "down 1"},
+	{Version: 2, Name: "2_second", Up: "up 2", Down: "down 2"},
+	{Version: 3, Name: "3_third", Up: "up 3"},
+}
+
+func newTestMigrator(schema *fakeSchema) *migrate.Migrator {
+	log := zerolog.Nop()
+	return migrate.NewMigrator(&log, schema, testMigrations)
+}
+
+func TestMigrator_UpAppliesPendingInOrder(t *testing.T) {
+	schema := &fakeSchema{version: 1, applied: true}
+
+	applied, err := newTestMigrator(schema).Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.Equal(t, []string{"up 2", "up 3"}, schema.scripts)
+	assert.Equal(t, uint64(3), schema.version)
+
+	status, err := newTestMigrator(schema).Status(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, status.Pending)
+}
+
+func TestMigrator_StatusOfEmptyDatabase(t *testing.T) {
+	status, err := newTestMigrator(&fakeSchema{}).Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), status.Version)
+	assert.Len(t, status.Pending, 3)
+}
+
+func TestMigrator_DownRevertsToPreviousVersion(t *testing.T) {
+	schema := &fakeSchema{version: 2, applied: true}
+
+	reverted, err := newTestMigrator(schema).Down(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reverted)
+	assert.Equal(t, []string{"down 2", "down 1"}, schema.scripts)
+	assert.False(t, schema.applied)
+}
+
+func TestMigrator_DownStopsAtIrreversibleMigration(t *testing.T) {
+	schema := &fakeSchema{version: 3, applied: true}
+
+	reverted, err := newTestMigrator(schema).Down(context.Background(), 1)
+	assert.ErrorContains(t, err, "3_third cannot be reverted")
+	assert.Equal(t, 0, reverted)
+	assert.Empty(t, schema.scripts)
+}
+
+func TestMigrator_RefusesDirtyOrUnknownSchema(t *testing.T) {
+	for name, schema := range map[string]*fakeSchema{
+		"dirty":   {version: 2, dirty: true, applied: true},
+		"unknown": {version: 4, applied: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newTestMigrator(schema).Up(context.Background())
+			assert.Error(t, err)
+			assert.Empty(t, schema.scripts)
+		})
+	}
+}
+
+func TestMigrations_AllAreOrderedAndReversible(t *testing.T) {
+	all, err := migrations.All()
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+	for i, migration := range all {
+		assert.NotEmpty(t, migration.Up, migration.Name)
+		assert.NotEmpty(t, migration.Down, migration.Name)
+		if i > 0 {
+			assert.Greater(t, migration.Version, all[i-1].Version)
+		}
+	}
+}
+
+func TestModelSchema(t *testing.T) {
+	table, err := migrate.ModelSchema(&model.CredentialsModel{})
+	require.NoError(t, err)
+	assert.Equal(t, "authz.credentials", table.Name)
+	assert.Equal(t, map[string]repository.ColumnSchema{
+		"id":       {NotNull: true},
+		"username": {NotNull: true},
+		"password": {NotNull: true},
+		"disabled": {NotNull: true},
+		"roles":    {NotNull: true},
+	}, table.Columns)
+	assert.ElementsMatch(t, [][]string{{"username"}, {"id"}}, table.Unique)
+
+	table, err = migrate.ModelSchema(&model.IdempotencyKeyModel{})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"user_id", "key"}}, table.Unique)
+	assert.False(t, table.Columns["status_code"].NotNull)
+}
+
+func TestCheckDrift(t *testing.T) {
+	schema := &fakeSchema{tables: map[string]*repository.TableSchema{
+		"authz.credentials": {
+			Name: "authz.credentials",
+			Columns: map[string]repository.ColumnSchema{
+				"id":       {NotNull: true},
+				"username": {NotNull: true},
+				"password": {NotNull: false},
+				"roles":    {NotNull: true},
+			},
+			Unique: [][]string{{"id"}},
+		},
+		"public.idempotency_keys": {
+			Name: "public.idempotency_keys",
+			Columns: map[string]repository.ColumnSchema{
+				"user_id": {NotNull: true}, "key": {NotNull: true}, "request_hash": {NotNull: true},
+				"status_code": {}, "content_type": {NotNull: true}, "response_body": {},
+				"created_at": {NotNull: true}, "expires_at": {NotNull: true},
+			},
+			Unique: [][]string{{"key", "user_id"}},
+		},
+	}}
+
+	drifts, err := migrate.CheckDrift(context.Background(), schema,
+		&model.CredentialsModel{}, &model.IdempotencyKeyModel{}, &model.WebhookModel{})
+	require.NoError(t, err)
+	assert.Equal(t, []migrate.Drift{
+		{Table: "authz.credentials", Column: "disabled", Problem: "column does not exist"},
+		{Table: "authz.credentials", Column: "password", Problem: "model declares not null, column is nullable"},
+		{Table: "authz.credentials", Column: "username", Problem: "model declares unique, no unique constraint or index"},
+		{Table: "public.webhooks", Problem: "table does not exist"},
+	}, drifts)
+	assert.Equal(t, "authz.credentials.disabled: column does not exist", drifts[0].String())
+}
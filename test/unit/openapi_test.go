@@ -0,0 +1,162 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/openapi"
+	"strings"
+	"testing"
+)
+
+func setupOpenAPIRouter(t *testing.T, responses bool, createStatus int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	l := zerolog.Nop()
+	validator, err := openapi.NewValidator(&l, config.ValidationConfig{Responses: responses})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(middleware.InjectLogger(&l))
+	r.Use(validator.Middleware())
+	r.POST("/api/v1/form", func(c *gin.Context) {
+		c.JSON(createStatus, api.SelfId{Id: "0b8f7a1e-3c2d-4e5f-8a9b-1c2d3e4f5a6b", Href: "/form/0b8f7a1e-3c2d-4e5f-8a9b-1c2d3e4f5a6b"})
+	})
+	r.GET("/api/v1/form/:formId/analytics", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	r.GET("/api/v1/unspecified", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func performOpenAPIRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+const validFormCreate = `{"name": "Form", "steps": [{"name": "Step", "content": "Content", "step": 1}]}`
+
+func TestOpenAPIValidator_Requests(t *testing.T) {
+	r := setupOpenAPIRouter(t, false, http.StatusCreated)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		field  string
+		rule   string
+	}{
+		{"Valid request", validFormCreate, http.StatusCreated, "", ""},
+		{"Unknown field", `{"name": "Form", "nmae": "x", "steps": [{"name": "Step", "content": "Content", "step": 1}]}`,
+			http.StatusBadRequest, "nmae", "additionalProperties"},
+		{"Unknown nested field", `{"name": "Form", "steps": [{"name": "Step", "content": "Content", "step": 1, "extra": 1}]}`,
+			http.StatusBadRequest, "steps[0].extra", "additionalProperties"},
+		{"Missing field", `{"steps": [{"name": "Step", "content": "Content", "step": 1}]}`,
+			http.StatusBadRequest, "name", "required"},
+		{"Too long value", `{"name": "Form", "steps": [{"name": "Step", "content": "` + strings.Repeat("x", 257) + `", "step": 1}]}`,
+			http.StatusBadRequest, "steps[0].content", "maxLength"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performOpenAPIRequest(r, http.MethodPost, "/api/v1/form", tt.body)
+
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.field == "" {
+				return
+			}
+			var body api.ValidationErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusBadRequest, body.Code)
+			require.NotEmpty(t, body.Errors)
+			assert.Equal(t, tt.field, body.Errors[0].Field)
+			assert.Equal(t, tt.rule, body.Errors[0].Rule)
+		})
+	}
+}
+
+func TestOpenAPIValidator_Parameters(t *testing.T) {
+	r := setupOpenAPIRouter(t, false, http.StatusCreated)
+
+	w := performOpenAPIRequest(r, http.MethodGet, "/api/v1/form/0b8f7a1e-3c2d-4e5f-8a9b-1c2d3e4f5a6b/analytics?bucket=century", "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var body api.ValidationErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.NotEmpty(t, body.Errors)
+	assert.Equal(t, "bucket", body.Errors[0].Field)
+}
+
+func TestOpenAPIValidator_PathsMissingFromSpecAreNotValidated(t *testing.T) {
+	r := setupOpenAPIRouter(t, false, http.StatusCreated)
+
+	w := performOpenAPIRequest(r, http.MethodGet, "/api/v1/unspecified", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestOpenAPIValidator_Responses(t *testing.T) {
+	t.Run("Matching response is passed through", func(t *testing.T) {
+		r := setupOpenAPIRouter(t, true, http.StatusCreated)
+
+		w := performOpenAPIRequest(r, http.MethodPost, "/api/v1/form", validFormCreate)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var body api.SelfId
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.NotEmpty(t, body.Id)
+	})
+
+	t.Run("Undocumented status is reported", func(t *testing.T) {
+		r := setupOpenAPIRouter(t, true, http.StatusOK)
+
+		w := performOpenAPIRequest(r, http.MethodPost, "/api/v1/form", validFormCreate)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		var body api.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusInternalServerError, body.Code)
+		assert.NotEmpty(t, body.Message)
+	})
+
+	t.Run("Responses are not validated by default", func(t *testing.T) {
+		r := setupOpenAPIRouter(t, false, http.StatusOK)
+
+		w := performOpenAPIRequest(r, http.MethodPost, "/api/v1/form", validFormCreate)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestOpenAPIValidator_LocalizesMessages(t *testing.T) {
+	r := setupOpenAPIRouter(t, false, http.StatusCreated)
+	body := `{"name": "Form", "steps": [{"name": "Step", "content": "` + strings.Repeat("x", 257) + `", "step": 1}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/form", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "es")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "es", w.Header().Get("Content-Language"))
+	var resp api.ValidationErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "content debe tener un máximo de 256 caracteres", resp.Errors[0].Message)
+	assert.Equal(t, "256", *resp.Errors[0].Param)
+}
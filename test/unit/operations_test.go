@@ -0,0 +1,294 @@
+package unit
+
+import (
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/handler"
+	"salesforge-assignment/internal/openapi"
+	"testing"
+	"time"
+)
+
+func setupOperations(t *testing.T) *openapi.Operations {
+	operations, err := openapi.NewOperations("/api/v1")
+	require.NoError(t, err)
+	return operations
+}
+
+func TestOperations_SecurityFollowsSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	operations := setupOperations(t)
+	security, err := operations.Security(map[string]openapi.Authenticator{
+		"BearerAuth": func(c *gin.Context, scopes []string) error {
+			return &apierrors.UnauthorizedError{Reason: apierrors.ReasonMissingToken}
+		},
+	})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(dummyLoggerMiddleware())
+	r.Use(security)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/v1/form/:formId", ok)
+	r.POST("/api/v1/login", ok)
+	r.GET("/api/v1/t/o/:pixel", ok)
+	r.GET("/openapi.json", ok)
+
+	tests := []struct {
+		method   string
+		path     string
+		expected int
+	}{
+		{http.MethodGet, "/api/v1/form/123", http.StatusUnauthorized},
+		{http.MethodPost, "/api/v1/login", http.StatusOK},
+		{http.MethodGet, "/api/v1/t/o/pixel.gif", http.StatusOK},
+		{http.MethodGet, "/openapi.json", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func TestOperations_SecurityAcceptsAnyRequirement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test, version: "1"}
+paths:
+  /either:
+    get:
+      security: [{Key: []}, {Bearer: []}]
+      responses: {"200": {description: OK}}
+  /both:
+    get:
+      security: [{Key: [], Bearer: []}]
+      responses: {"200": {description: OK}}
+`))
+	require.NoError(t, err)
+	header := func(name string) openapi.Authenticator {
+		return func(c *gin.Context, scopes []string) error {
+			if c.GetHeader(name) == "" {
+				return &apierrors.UnauthorizedError{Reason: apierrors.ReasonMissingToken}
+			}
+			return nil
+		}
+	}
+	security, err := openapi.OperationsOf(doc, "").Security(map[string]openapi.Authenticator{
+		"Key":    header("X-Key"),
+		"Bearer": header("Authorization"),
+	})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(dummyLoggerMiddleware())
+	r.Use(security)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/either", ok)
+	r.GET("/both", ok)
+
+	tests := []struct {
+		path     string
+		headers  []string
+		expected int
+	}{
+		{"/either", []string{"X-Key"}, http.StatusOK},
+		{"/either", []string{"Authorization"}, http.StatusOK},
+		{"/either", nil, http.StatusUnauthorized},
+		{"/both", []string{"X-Key", "Authorization"}, http.StatusOK},
+		{"/both", []string{"Authorization"}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.path, tt.headers), func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for _, name := range tt.headers {
+				req.Header.Set(name, "credentials")
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func TestOperations_SecurityPassesScopesOfRequirement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	operations := setupOperations(t)
+	var scopes []string
+	security, err := operations.Security(map[string]openapi.Authenticator{
+		"BearerAuth": func(c *gin.Context, requirement []string) error {
+			scopes = requirement
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(security)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/v1/form/:formId", ok)
+	r.POST("/api/v1/form", ok)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/form/123", nil))
+	assert.Empty(t, scopes)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/form", nil))
+	assert.Equal(t, []string{"editor"}, scopes)
+}
+
+func TestOperations_SecurityRequiresAuthenticatorOfEveryScheme(t *testing.T) {
+	operations := setupOperations(t)
+
+	_, err := operations.Security(map[string]openapi.Authenticator{})
+	assert.ErrorContains(t, err, `security scheme "BearerAuth"`)
+}
+
+func TestOperations_WithHeaderRunsForOperationsDeclaringIt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	operations := setupOperations(t)
+
+	r := gin.New()
+	r.Use(operations.WithHeader("Idempotency-Key", func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusConflict)
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.POST("/api/v1/form", ok)
+	r.POST("/api/v1/login", ok)
+	r.GET("/api/v1/t/o/:pixel", ok)
+
+	tests := []struct {
+		method   string
+		path     string
+		expected int
+	}{
+		{http.MethodPost, "/api/v1/form", http.StatusConflict},
+		{http.MethodPost, "/api/v1/login", http.StatusOK},
+		{http.MethodGet, "/api/v1/t/o/pixel.gif", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func TestOperations_RateLimitsFollowSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	operations := setupOperations(t)
+	var limited []string
+	rateLimits, err := operations.RateLimits(func(c *gin.Context, name string) error {
+		limited = append(limited, name)
+		return &apierrors.TooManyRequestsError{RetryAfter: 1500 * time.Millisecond}
+	})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(dummyLoggerMiddleware())
+	r.Use(rateLimits)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.POST("/api/v1/login", ok)
+	r.GET("/api/v1/t/o/:pixel", ok)
+	r.GET("/api/v1/form/:formId", ok)
+
+	tests := []struct {
+		method   string
+		path     string
+		expected int
+	}{
+		{http.MethodPost, "/api/v1/login", http.StatusTooManyRequests},
+		{http.MethodGet, "/api/v1/t/o/pixel.gif", http.StatusTooManyRequests},
+		{http.MethodGet, "/api/v1/form/123", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.expected == http.StatusTooManyRequests {
+				assert.Equal(t, "2", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+	assert.Equal(t, []string{"login", "tracking"}, limited)
+}
+
+func TestOperations_RateLimitsRejectInvalidExtensions(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test, version: "1"}
+paths:
+  /limited:
+    get:
+      x-rate-limit: 10
+      responses: {"200": {description: OK}}
+`))
+	require.NoError(t, err)
+
+	_, err = openapi.OperationsOf(doc, "").RateLimits(func(c *gin.Context, name string) error { return nil })
+	assert.ErrorContains(t, err, "x-rate-limit of GET /limited must be a name")
+}
+
+func TestOperations_FeaturesFollowSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	operations := setupOperations(t)
+	enabled := map[string]bool{"ingest": false}
+	features, err := operations.Features(func(name string) bool { return enabled[name] })
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(dummyLoggerMiddleware())
+	r.Use(features)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.POST("/api/v1/events", ok)
+	r.POST("/api/v1/login", ok)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/events", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/login", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	enabled["ingest"] = true
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/events", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestOperations_CheckRoutesReportsMissingHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	operations := setupOperations(t)
+
+	r := gin.New()
+	r.POST("/api/v1/login", func(c *gin.Context) {})
+
+	err := operations.CheckRoutes(r.Routes())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GET /api/v1/form/:formId (GetFormById)")
+	assert.NotContains(t, err.Error(), "LoginUser")
+}
+
+func TestOperations_GeneratedRoutesCoverSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	operations := setupOperations(t)
+
+	r := gin.New()
+	api.RegisterHandlersWithOptions(r, handler.NewFormHandler(nil, nil, nil, nil), api.GinServerOptions{BaseURL: "/api/v1"})
+
+	assert.NoError(t, operations.CheckRoutes(r.Routes()))
+}
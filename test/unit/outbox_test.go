@@ -0,0 +1,181 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/events"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"testing"
+	"time"
+)
+
+type fakeOutboxRepository struct {
+	repository.OutboxRepository
+	locked    bool
+	pending   []model.OutboxEventModel
+	published []string
+	failed    []model.OutboxEventModel
+	postponed map[string]time.Time
+	inTx      bool
+}
+
+type fakeTxManager struct {
+	repo *fakeOutboxRepository
+}
+
+func (m fakeTxManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.repo.inTx = true
+	defer func() { m.repo.inTx = false }()
+	return fn(ctx)
+}
+
+func (r *fakeOutboxRepository) TryLockRelay(context.Context) (bool, error) {
+	return r.locked, nil
+}
+
+func (r *fakeOutboxRepository) FindPendingEvents(context.Context, int) ([]model.OutboxEventModel, error) {
+	return r.pending, nil
+}
+
+func (r *fakeOutboxRepository) PostponeEvents(_ context.Context, ids []string, until time.Time) error {
+	if r.postponed == nil {
+		r.postponed = make(map[string]time.Time)
+	}
+	for _, id := range ids {
+		r.postponed[id] = until
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepository) MarkPublished(_ context.Context, ids []string, _ time.Time) error {
+	r.published = append(r.published, ids...)
+	return nil
+}
+
+func (r *fakeOutboxRepository) MarkFailed(_ context.Context, event *model.OutboxEventModel) error {
+	r.failed = append(r.failed, *event)
+	return nil
+}
+
+type failingSink struct {
+	repo      *fakeOutboxRepository
+	failEvent string
+	published []string
+}
+
+func (s *failingSink) Name() string {
+	return "failing"
+}
+
+func (s *failingSink) Publish(_ context.Context, event events.Event) error {
+	if s.repo != nil && s.repo.inTx {
+		return errors.New("published within the claim transaction")
+	}
+	if event.ID == s.failEvent {
+		return errors.New("unavailable")
+	}
+	s.published = append(s.published, event.ID)
+	return nil
+}
+
+func TestRelay_FailedEventHoldsBackItsForm(t *testing.T) {
+	log := logger.InitLogger(config.LogConfig{Level: "panic"})
+	repo := &fakeOutboxRepository{
+		locked: true,
+		pending: []model.OutboxEventModel{
+			{ID: "a1", FormID: "formA", Sequence: 1, EventType: string(api.FormCreated), Payload: `{}`},
+			{ID: "b1", FormID: "formB", Sequence: 2, EventType: string(api.FormCreated), Payload: `{}`},
+			{ID: "a2", FormID: "formA", Sequence: 3, EventType: string(api.FormUpdated), Payload: `{}`},
+			{ID: "b2", FormID: "formB", Sequence: 4, EventType: string(api.FormUpdated), Payload: `{}`},
+		},
+	}
+	sink := &failingSink{repo: repo, failEvent: "a1"}
+	relay := events.NewRelay(log, fakeTxManager{repo}, repo, []events.Sink{sink}, config.OutboxConfig{})
+
+	assert.Equal(t, 4, relay.RelayPending(context.Background()))
+
+	assert.Equal(t, []string{"b1", "b2"}, sink.published)
+	assert.Equal(t, []string{"b1", "b2"}, repo.published)
+	if assert.Len(t, repo.failed, 1) {
+		assert.Equal(t, "a1", repo.failed[0].ID)
+		assert.Equal(t, 1, repo.failed[0].Attempts)
+		assert.Contains(t, repo.failed[0].LastError, "failing: unavailable")
+		assert.True(t, repo.failed[0].NextAttemptAt.After(time.Now()))
+	}
+	// a2 was claimed but held back behind a1, it is released rather than
+	// left waiting for the lease
+	assert.False(t, repo.postponed["a2"].After(time.Now()))
+}
+
+func TestRelay_SkipsWhenAnotherRelayHoldsTheLock(t *testing.T) {
+	log := logger.InitLogger(config.LogConfig{Level: "panic"})
+	repo := &fakeOutboxRepository{
+		pending: []model.OutboxEventModel{{ID: "a1", FormID: "formA", Payload: `{}`}},
+	}
+	sink := &failingSink{}
+	relay := events.NewRelay(log, fakeTxManager{repo}, repo, []events.Sink{sink}, config.OutboxConfig{})
+
+	assert.Equal(t, 0, relay.RelayPending(context.Background()))
+	assert.Empty(t, sink.published)
+}
+
+func TestBus_PublishesToAllSubscribers(t *testing.T) {
+	bus := events.NewBus()
+	var received []string
+	bus.Subscribe(func(_ context.Context, event events.Event) error {
+		received = append(received, "first:"+event.ID)
+		return errors.New("first failed")
+	})
+	bus.Subscribe(func(_ context.Context, event events.Event) error {
+		received = append(received, "second:"+event.ID)
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), events.Event{ID: "e1"})
+	assert.ErrorContains(t, err, "first failed")
+	assert.Equal(t, []string{"first:e1", "second:e1"}, received)
+}
+
+type fakeKafkaWriter struct {
+	messages []kafka.Message
+}
+
+func (w *fakeKafkaWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeKafkaWriter) Close() error {
+	return nil
+}
+
+func TestKafkaSink_KeysEventsByForm(t *testing.T) {
+	writer := &fakeKafkaWriter{}
+	sink := events.NewKafkaSink(writer)
+
+	err := sink.Publish(context.Background(), events.Event{
+		ID:     "event-1",
+		Type:   api.FormCreated,
+		FormID: "form-1",
+		Data:   json.RawMessage(`{"name":"Signup"}`),
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, writer.messages, 1) {
+		message := writer.messages[0]
+		assert.Equal(t, "form-1", string(message.Key))
+		assert.JSONEq(t, `{"id":"event-1","type":"form.created","formId":"form-1","sequence":0,
+			"occurredAt":"0001-01-01T00:00:00Z","data":{"name":"Signup"}}`, string(message.Value))
+		assert.Equal(t, []kafka.Header{
+			{Key: "event-id", Value: []byte("event-1")},
+			{Key: "event-type", Value: []byte("form.created")},
+		}, message.Headers)
+	}
+}
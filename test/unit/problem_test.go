@@ -0,0 +1,186 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/problem"
+	"strings"
+	"testing"
+)
+
+func setupProblemRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	l := zerolog.Nop()
+
+	r := gin.New()
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(problem.NoRoute())
+	r.NoMethod(problem.NoMethod())
+	r.Use(middleware.RequestID())
+	r.Use(middleware.InjectLogger(&l))
+	r.Use(problem.Recovery())
+
+	r.GET("/form/:formId", func(c *gin.Context) {
+		problem.Respond(c, &apierrors.ConflictError{Field: "name"})
+	})
+	r.POST("/form", func(c *gin.Context) {
+		problem.Respond(c, validator.ValidationErrors{fakeFieldError{"Name", "required"}})
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return r
+}
+
+func performProblemRequest(r *gin.Engine, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) api.Problem {
+	t.Helper()
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+	var body api.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body
+}
+
+func TestProblem_LegacyBodyWithoutAccept(t *testing.T) {
+	r := setupProblemRouter()
+
+	w := performProblemRequest(r, http.MethodGet, "/form/1", nil)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "application/json"))
+	var body api.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 409, body.Code)
+	assert.Equal(t, "name", *body.Field)
+	assert.NotEmpty(t, w.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestProblem_ProblemDetailsWhenAccepted(t *testing.T) {
+	r := setupProblemRouter()
+
+	w := performProblemRequest(r, http.MethodGet, "/form/1", map[string]string{
+		"Accept":                   "application/problem+json, application/json",
+		middleware.RequestIDHeader: "req-123",
+	})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	body := decodeProblem(t, w)
+	assert.Equal(t, "urn:forms-api:problem:conflict", body.Type)
+	assert.Equal(t, string(apierrors.CodeConflict), body.Code)
+	assert.Equal(t, apierrors.CodeConflict.Title(), body.Title)
+	assert.Equal(t, http.StatusConflict, body.Status)
+	assert.NotEmpty(t, *body.Detail)
+	assert.Equal(t, "name", *body.Field)
+	assert.Equal(t, "/form/1", *body.Instance)
+	assert.Equal(t, "req-123", *body.RequestId)
+	assert.Equal(t, "req-123", w.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestProblem_ValidationErrors(t *testing.T) {
+	r := setupProblemRouter()
+
+	w := performProblemRequest(r, http.MethodPost, "/form", map[string]string{"Accept": problem.ContentType})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	body := decodeProblem(t, w)
+	assert.Equal(t, string(apierrors.CodeValidationFailed), body.Code)
+	require.NotNil(t, body.Errors)
+	require.Len(t, *body.Errors, 1)
+	assert.Equal(t, "Name", (*body.Errors)[0].Field)
+}
+
+func TestProblem_RouterFallbacksAndRecovery(t *testing.T) {
+	r := setupProblemRouter()
+	accept := map[string]string{"Accept": problem.ContentType}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		code   apierrors.Code
+	}{
+		{"Unknown route", http.MethodGet, "/unknown", http.StatusNotFound, apierrors.CodeResourceNotFound},
+		{"Unsupported method", http.MethodDelete, "/form", http.StatusMethodNotAllowed, apierrors.CodeMethodNotAllowed},
+		{"Panic", http.MethodGet, "/panic", http.StatusInternalServerError, apierrors.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performProblemRequest(r, tt.method, tt.path, accept)
+
+			assert.Equal(t, tt.status, w.Code)
+			body := decodeProblem(t, w)
+			assert.Equal(t, string(tt.code), body.Code)
+			assert.Equal(t, tt.path, *body.Instance)
+			assert.NotEmpty(t, *body.RequestId)
+		})
+	}
+}
+
+func TestProblem_LogsOnlyServerErrorsAtErrorLevel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	level := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	t.Cleanup(func() { zerolog.SetGlobalLevel(level) })
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&apierrors.ResourceNotFoundError{}, "debug"},
+		{&apierrors.PreconditionFailedError{}, "debug"},
+		{&apierrors.UnprocessableEntityError{}, "debug"},
+		{&apierrors.InvalidApplicationStateError{}, "error"},
+		{errors.New("unexpected"), "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%T", tt.err), func(t *testing.T) {
+			var out bytes.Buffer
+			l := zerolog.New(&out)
+			r := gin.New()
+			r.Use(middleware.InjectLogger(&l))
+			r.GET("/", func(c *gin.Context) { problem.Respond(c, tt.err) })
+
+			performProblemRequest(r, http.MethodGet, "/", nil)
+
+			var line struct{ Level string }
+			require.NoError(t, json.Unmarshal(out.Bytes(), &line), out.String())
+			assert.Equal(t, tt.expected, line.Level)
+		})
+	}
+}
+
+func TestRequestID_RejectsInvalidIncomingID(t *testing.T) {
+	r := setupProblemRouter()
+
+	w := performProblemRequest(r, http.MethodGet, "/form/1", map[string]string{
+		middleware.RequestIDHeader: strings.Repeat("x", 200),
+	})
+
+	id := w.Header().Get(middleware.RequestIDHeader)
+	assert.NotEmpty(t, id)
+	assert.NotEqual(t, strings.Repeat("x", 200), id)
+}
@@ -0,0 +1,71 @@
+package unit
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/middleware/auth"
+	"salesforge-assignment/internal/ratelimit"
+	"testing"
+	"time"
+)
+
+func TestLimiter_AllowsBurstThenRejects(t *testing.T) {
+	limiter := ratelimit.NewLimiter(func() map[string]config.RateLimitConfig {
+		return map[string]config.RateLimitConfig{"login": {Requests: 2, Per: time.Minute}}
+	})
+
+	for i := 0; i < 2; i++ {
+		ok, _ := limiter.Allow("login", "ip:203.0.113.1")
+		assert.True(t, ok)
+	}
+	ok, retryAfter := limiter.Allow("login", "ip:203.0.113.1")
+	assert.False(t, ok)
+	assert.InDelta(t, 30*time.Second, retryAfter, float64(time.Second))
+
+	ok, _ = limiter.Allow("login", "ip:203.0.113.2")
+	assert.True(t, ok, "other clients have their own bucket")
+	ok, _ = limiter.Allow("ingest", "ip:203.0.113.1")
+	assert.True(t, ok, "limits that are not configured allow everything")
+}
+
+func TestLimiter_AppliesReloadedLimits(t *testing.T) {
+	limits := map[string]config.RateLimitConfig{"login": {Requests: 1, Per: time.Hour}}
+	limiter := ratelimit.NewLimiter(func() map[string]config.RateLimitConfig { return limits })
+
+	ok, _ := limiter.Allow("login", "ip:203.0.113.1")
+	assert.True(t, ok)
+	ok, _ = limiter.Allow("login", "ip:203.0.113.1")
+	assert.False(t, ok)
+
+	limits = map[string]config.RateLimitConfig{"login": {Requests: 1, Per: time.Millisecond}}
+	_, retryAfter := limiter.Allow("login", "ip:203.0.113.1")
+	assert.LessOrEqual(t, retryAfter, time.Millisecond, "the bucket refills at the reloaded rate")
+}
+
+func TestLimiter_CheckLimitsUsersAndAddresses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewLimiter(func() map[string]config.RateLimitConfig {
+		return map[string]config.RateLimitConfig{"ingest": {Requests: 1, Per: time.Minute}}
+	})
+	request := func(userId string) error {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("POST", "/events", nil)
+		c.Request.RemoteAddr = "203.0.113.1:1234"
+		if userId != "" {
+			c.Set(auth.ClaimsKey, &auth.Claims{UserId: userId})
+		}
+		return limiter.Check(c, "ingest")
+	}
+
+	assert.NoError(t, request(""))
+	assert.NoError(t, request("user-1"))
+	assert.NoError(t, request("user-2"))
+
+	var tooMany *apierrors.TooManyRequestsError
+	assert.ErrorAs(t, request(""), &tooMany)
+	assert.ErrorAs(t, request("user-1"), &tooMany)
+	assert.Positive(t, tooMany.RetryAfter)
+}
@@ -0,0 +1,49 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/repository"
+	"testing"
+)
+
+func TestTranslateError_UniqueViolation(t *testing.T) {
+	pgErr := &pgconn.PgError{Code: "23505", Detail: "Key (name)=(Welcome) already exists."}
+
+	err := repository.TranslateError(fmt.Errorf("insert: %w", pgErr))
+
+	var conflict *apierrors.ConflictError
+	if assert.ErrorAs(t, err, &conflict) {
+		assert.Equal(t, "name", conflict.Field)
+		response := conflict.APIErrorResponse()
+		assert.Equal(t, 409, response.Code)
+		assert.NotContains(t, response.Message, "Welcome")
+	}
+}
+
+func TestTranslateError_Classes(t *testing.T) {
+	var invalid *apierrors.InvalidInputError
+	assert.ErrorAs(t, repository.TranslateError(&pgconn.PgError{Code: "23502", ColumnName: "step_order"}), &invalid)
+	assert.Equal(t, "step", invalid.Field)
+
+	var unprocessable *apierrors.UnprocessableEntityError
+	assert.ErrorAs(t, repository.TranslateError(&pgconn.PgError{
+		Code:   "23503",
+		Detail: `Key (form_id)=(5b4c) is not present in table "form".`,
+	}), &unprocessable)
+	assert.Equal(t, "formId", unprocessable.Field)
+
+	var concurrent *apierrors.ConcurrentUpdateError
+	assert.ErrorAs(t, repository.TranslateError(&pgconn.PgError{Code: "40001"}), &concurrent)
+}
+
+func TestTranslateError_KeepsOtherErrors(t *testing.T) {
+	assert.Equal(t, gorm.ErrRecordNotFound, repository.TranslateError(gorm.ErrRecordNotFound))
+
+	other := &pgconn.PgError{Code: "57014"}
+	assert.True(t, errors.Is(repository.TranslateError(other), other))
+}
@@ -0,0 +1,136 @@
+package unit
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"salesforge-assignment/internal/api"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/seed"
+	"salesforge-assignment/internal/service"
+	"strings"
+	"testing"
+)
+
+// fakeSeedFormService records the forms created by the seeder.
+type fakeSeedFormService struct {
+	service.FormService
+	created []api.FormCreate
+}
+
+func (s *fakeSeedFormService) CreateForm(_ context.Context, req api.FormCreate) (*api.SelfId, error) {
+	s.created = append(s.created, req)
+	return &api.SelfId{}, nil
+}
+
+type fakeFormNames struct {
+	repository.FormRepository
+	names map[string]bool
+}
+
+func (r *fakeFormNames) ExistsFormWithName(_ context.Context, name string) (bool, error) {
+	return r.names[name], nil
+}
+
+func writeFixture(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "seed.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// noEnv is a lookupEnv without any variables.
+func noEnv(string) (string, bool) { return "", false }
+
+func TestLoadFixture(t *testing.T) {
+	fixture, err := seed.LoadFixture(writeFixture(t, `
+users:
+  - username: admin
+    password: test123
+forms:
+  - name: Signup
+    openTrackingEnabled: true
+    steps:
+      - name: Welcome
+        content: Hello
+        step: 1
+`), noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, []seed.User{{Username: "admin", Password: "test123"}}, fixture.Users)
+	require.Len(t, fixture.Forms, 1)
+	assert.True(t, fixture.Forms[0].OpenTrackingEnabled)
+	assert.Equal(t, []seed.Step{{Name: "Welcome", Content: "Hello", Step: 1}}, fixture.Forms[0].Steps)
+}
+
+func TestLoadFixture_RejectsUnknownKeys(t *testing.T) {
+	_, err := seed.LoadFixture(writeFixture(t, "users:\n  - username: admin\n    passwd: test123\n"), noEnv)
+	assert.ErrorContains(t, err, "passwd")
+}
+
+func TestLoadFixture_ReadsPasswordsFromEnvironment(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(secret, []byte("from-file\n"), 0o600))
+	env := map[string]string{"ADMIN_PASSWORD": "from-env", "EDITOR_PASSWORD_FILE": secret}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	fixture, err := seed.LoadFixture(writeFixture(t, `
+users:
+  - username: admin
+    passwordEnv: ADMIN_PASSWORD
+  - username: editor
+    passwordEnv: EDITOR_PASSWORD
+`), lookupEnv)
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", fixture.Users[0].Password)
+	assert.Equal(t, "from-file", fixture.Users[1].Password)
+}
+
+func TestLoadFixture_RejectsUsersWithoutPassword(t *testing.T) {
+	_, err := seed.LoadFixture(writeFixture(t, "users:\n  - username: admin\n    passwordEnv: ADMIN_PASSWORD\n"), noEnv)
+	assert.ErrorContains(t, err, "ADMIN_PASSWORD is not set")
+
+	_, err = seed.LoadFixture(writeFixture(t, "users:\n  - username: admin\n"), noEnv)
+	assert.ErrorContains(t, err, "user admin")
+}
+
+func TestLoadFixture_ShippedFixture(t *testing.T) {
+	lookupEnv := func(name string) (string, bool) { return "test123", strings.HasSuffix(name, "_PASSWORD") }
+
+	fixture, err := seed.LoadFixture("../../init-db/seed.yaml", lookupEnv)
+	require.NoError(t, err)
+	assert.NotEmpty(t, fixture.Users)
+	for _, user := range fixture.Users {
+		assert.NotEmpty(t, user.PasswordEnv, "the fixture holds no plain passwords")
+	}
+}
+
+func TestSeeder_SkipsExistingUsersAndForms(t *testing.T) {
+	log := zerolog.Nop()
+	credentials := newFakeCredentialsRepository()
+	users := service.NewUserService(credentials)
+	require.NoError(t, users.CreateUser(context.Background(), "admin", "old"))
+	forms := &fakeSeedFormService{}
+	seeder := seed.NewSeeder(&log, users, forms, &fakeFormNames{names: map[string]bool{"Existing": true}})
+
+	err := seeder.Seed(context.Background(), &seed.Fixture{
+		Users: []seed.User{{Username: "admin", Password: "new"}, {Username: "alice", Password: "s3cret"}},
+		Forms: []seed.Form{
+			{Name: "Existing"},
+			{Name: "Signup", ClickTrackingEnabled: true, Steps: []seed.Step{{Name: "Welcome", Content: "Hello", Step: 1}}},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, login(t, credentials, "admin", "old"))
+	assert.NoError(t, login(t, credentials, "alice", "s3cret"))
+	require.Len(t, forms.created, 1)
+	assert.Equal(t, "Signup", forms.created[0].Name)
+	assert.True(t, *forms.created[0].ClickTrackingEnabled)
+	assert.Equal(t, api.FormStepCreateArray{{Name: "Welcome", Content: "Hello", Step: 1}}, forms.created[0].Steps)
+}
@@ -0,0 +1,210 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/middleware"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/service"
+	"salesforge-assignment/internal/tracing"
+	"salesforge-assignment/internal/webhook"
+	"strings"
+	"testing"
+)
+
+func setupTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+// captureLogs returns a logger writing to logs, regardless of the global
+// level set by other tests.
+func captureLogs(t *testing.T, logs *bytes.Buffer) zerolog.Logger {
+	level := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	t.Cleanup(func() { zerolog.SetGlobalLevel(level) })
+	return zerolog.New(logs)
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracing_MiddlewareContinuesIncomingTrace(t *testing.T) {
+	exporter := setupTracing(t)
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	log := captureLogs(t, &logs).Hook(tracing.LogHook{})
+
+	r := gin.New()
+	r.Use(middleware.InjectLogger(&log))
+	r.Use(tracing.Middleware())
+	r.GET("/form/:formId", func(c *gin.Context) {
+		logger.FromContext(c).Info().Msg("Handling")
+		c.Status(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/form/123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /form/:formId", span.Name)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+	assert.Equal(t, "/form/:formId", spanAttribute(span, "http.route").AsString())
+	assert.Equal(t, int64(500), spanAttribute(span, "http.response.status_code").AsInt64())
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Contains(t, logs.String(), `"traceId":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+	assert.Contains(t, logs.String(), `"spanId":"`+span.SpanContext.SpanID().String()+`"`)
+	assert.Equal(t, 1, strings.Count(logs.String(), `"traceId"`), "the trace is logged once")
+}
+
+func TestTracing_LogHookAddsTraceOfContext(t *testing.T) {
+	setupTracing(t)
+	var logs bytes.Buffer
+	log := captureLogs(t, &logs).Hook(tracing.LogHook{})
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "operation")
+	log.Info().Ctx(ctx).Msg("Traced")
+	span.End()
+	log.Info().Msg("Untraced")
+
+	lines := bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), `"traceId":"`+span.SpanContext().TraceID().String()+`"`)
+	assert.NotContains(t, string(lines[1]), "traceId")
+}
+
+func TestTracing_GormPluginTracesQueries(t *testing.T) {
+	exporter := setupTracing(t)
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost port=1"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(tracing.GormPlugin{}))
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "parent")
+	var form model.FormModel
+	db.WithContext(ctx).First(&form, "id = ?", "secret-id")
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	query := spans[0]
+	assert.Equal(t, "gorm.query", query.Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent.SpanID())
+	assert.Equal(t, "form", spanAttribute(query, "db.collection.name").AsString())
+	assert.Contains(t, spanAttribute(query, "db.query.text").AsString(), "WHERE id = $1")
+	assert.NotContains(t, spanAttribute(query, "db.query.text").AsString(), "secret-id")
+}
+
+type failingFormService struct {
+	service.FormService
+}
+
+func (failingFormService) GetFormById(context.Context, string) (*api.FormResponseGet, error) {
+	return nil, &apierrors.ResourceNotFoundError{}
+}
+
+func TestTracing_FormServiceMethodsHaveSpans(t *testing.T) {
+	exporter := setupTracing(t)
+	svc := service.NewTracedFormService(failingFormService{})
+
+	_, err := svc.GetFormById(context.Background(), "id")
+
+	var notFound *apierrors.ResourceNotFoundError
+	assert.True(t, errors.As(err, &notFound))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "FormService.GetFormById", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
+
+type missingFormRepository struct {
+	repository.FormRepository
+}
+
+func (missingFormRepository) GetFormById(context.Context, string) (*model.FormModel, error) {
+	return nil, &apierrors.ResourceNotFoundError{}
+}
+
+func TestTracing_RepositoryMethodsHaveSpans(t *testing.T) {
+	exporter := setupTracing(t)
+	repo := repository.NewTracedFormRepository(missingFormRepository{})
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "parent")
+	_, err := repo.GetFormById(ctx, "id")
+	parent.End()
+
+	var notFound *apierrors.ResourceNotFoundError
+	assert.True(t, errors.As(err, &notFound))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "FormRepository.GetFormById", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
+
+func TestTracing_WebhookDeliveriesPropagateTrace(t *testing.T) {
+	exporter := setupTracing(t)
+	log := zerolog.Nop()
+
+	var traceparent string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer receiver.Close()
+
+	repo := &fakeWebhookRepository{due: []model.WebhookDeliveryModel{{
+		ID:        "delivery1",
+		WebhookID: "webhook1",
+		EventType: "form.created",
+		Payload:   `{}`,
+		Webhook:   &model.WebhookModel{ID: "webhook1", URL: receiver.URL, Secret: "webhook-secret"},
+	}}}
+	cfg := config.WebhooksConfig{AllowedNetworks: []string{"127.0.0.1"}}
+	webhook.NewDispatcher(&log, repo, cfg).DispatchDue(context.Background())
+
+	spans := exporter.GetSpans()
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name
+	}
+	assert.Contains(t, names, "Dispatcher.DispatchDue")
+	assert.Contains(t, names, "Dispatcher.deliver")
+	assert.Contains(t, traceparent, spans[0].SpanContext.TraceID().String())
+}
@@ -0,0 +1,110 @@
+package unit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"salesforge-assignment/internal/tracking"
+	"strings"
+	"testing"
+)
+
+func TestSigner_SignAndVerify(t *testing.T) {
+	signer := tracking.NewSigner([]byte("tracking-secret"))
+	claims := tracking.Claims{FormID: "form1", StepID: "step1", Recipient: "jane@example.com"}
+
+	token, err := signer.Sign(tracking.KindOpen, claims)
+	assert.NoError(t, err)
+	assert.NotContains(t, token, "jane@example.com")
+
+	verified, err := signer.Verify(tracking.KindOpen, token)
+	assert.NoError(t, err)
+	assert.Equal(t, claims, *verified)
+}
+
+func TestSigner_VerifyRejectsTamperedTokens(t *testing.T) {
+	signer := tracking.NewSigner([]byte("tracking-secret"))
+	token, err := signer.Sign(tracking.KindOpen, tracking.Claims{FormID: "form1", StepID: "step1", Recipient: "r1"})
+	assert.NoError(t, err)
+
+	other, err := tracking.NewSigner([]byte("other-secret")).Sign(tracking.KindOpen, tracking.Claims{FormID: "form2", StepID: "step1", Recipient: "r1"})
+	assert.NoError(t, err)
+
+	payload, _, _ := strings.Cut(token, ".")
+	otherPayload, _, _ := strings.Cut(other, ".")
+	_, signature, _ := strings.Cut(token, ".")
+
+	for _, tampered := range []string{"", "garbage", payload, payload + ".", otherPayload + "." + signature, other} {
+		_, err := signer.Verify(tracking.KindOpen, tampered)
+		assert.ErrorIs(t, err, tracking.ErrInvalidToken, "token %q", tampered)
+	}
+}
+
+func TestSigner_VerifyRejectsTokensOfAnotherKind(t *testing.T) {
+	signer := tracking.NewSigner([]byte("tracking-secret"))
+	token, err := signer.Sign(tracking.KindClick, tracking.Claims{FormID: "form1", StepID: "step1", Recipient: "r1", URL: "https://example.com"})
+	assert.NoError(t, err)
+
+	_, err = signer.Verify(tracking.KindOpen, token)
+	assert.ErrorIs(t, err, tracking.ErrInvalidToken)
+
+	_, err = signer.Verify(tracking.KindClick, token)
+	assert.NoError(t, err)
+}
+
+func TestSigner_HashIP(t *testing.T) {
+	signer := tracking.NewSigner([]byte("tracking-secret"))
+
+	hash := signer.HashIP("203.0.113.5")
+	assert.Equal(t, hash, signer.HashIP("203.0.113.5"))
+	assert.NotEqual(t, hash, signer.HashIP("203.0.113.6"))
+	assert.NotContains(t, hash, "203.0.113.5")
+}
+
+func TestTokenFromPixel(t *testing.T) {
+	token, ok := tracking.TokenFromPixel("abc.def.gif")
+	assert.True(t, ok)
+	assert.Equal(t, "abc.def", token)
+
+	_, ok = tracking.TokenFromPixel("abc.def.png")
+	assert.False(t, ok)
+}
+
+func TestAppendOpenPixel(t *testing.T) {
+	href := tracking.GetOpenPixelHref("tok", "https://public.example", "/api/v1")
+	assert.Equal(t, "https://public.example/api/v1/t/o/tok.gif", href)
+
+	content := tracking.AppendOpenPixel("<p>Hello</p>", href)
+	assert.True(t, strings.HasPrefix(content, "<p>Hello</p>"))
+	assert.Contains(t, content, `src="https://public.example/api/v1/t/o/tok.gif"`)
+}
+
+func TestRewriteLinks(t *testing.T) {
+	content := `<a href="https://example.com/a?x=1&amp;y=2">A</a> see http://example.org/b.`
+
+	var seen []string
+	rewritten, err := tracking.RewriteLinks(content, func(link string) (string, error) {
+		seen = append(seen, link)
+		return "https://t.example/c/" + string(rune('0'+len(seen))), nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/a?x=1&y=2", "http://example.org/b"}, seen)
+	assert.Equal(t, `<a href="https://t.example/c/1">A</a> see https://t.example/c/2.`, rewritten)
+}
+
+func TestRewriteLinks_NoLinks(t *testing.T) {
+	rewritten, err := tracking.RewriteLinks("plain text", func(link string) (string, error) {
+		t.Fatal("rewrite must not be called")
+		return "", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "plain text", rewritten)
+}
+
+func TestIsRedirectable(t *testing.T) {
+	assert.True(t, tracking.IsRedirectable("https://example.com/path"))
+	assert.True(t, tracking.IsRedirectable("http://example.com"))
+	assert.False(t, tracking.IsRedirectable(""))
+	assert.False(t, tracking.IsRedirectable("javascript:alert(1)"))
+	assert.False(t, tracking.IsRedirectable("//evil.example"))
+	assert.False(t, tracking.IsRedirectable("/relative"))
+}
@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/repository"
+	"testing"
+)
+
+// flakyTxManager fails the first units of work with the given errors.
+type flakyTxManager struct {
+	failures []error
+	runs     int
+}
+
+func (tm *flakyTxManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tm.runs++
+	if tm.runs <= len(tm.failures) {
+		return tm.failures[tm.runs-1]
+	}
+	return fn(ctx)
+}
+
+func runRetrying(inner repository.TxManager, attempts int) (int, error) {
+	log := zerolog.Nop()
+	calls := 0
+	err := repository.NewRetryingTxManager(&log, inner, attempts).RunInTransaction(context.Background(), func(context.Context) error {
+		calls++
+		return nil
+	})
+	return calls, err
+}
+
+func TestRetryingTxManager_RetriesConflicts(t *testing.T) {
+	inner := &flakyTxManager{failures: []error{
+		&pgconn.PgError{Code: "40001"},
+		&apierrors.ConcurrentUpdateError{Err: &pgconn.PgError{Code: "40P01"}},
+	}}
+
+	calls, err := runRetrying(inner, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 3, inner.runs)
+}
+
+func TestRetryingTxManager_ReportsConflictOnceAttemptsAreExhausted(t *testing.T) {
+	inner := &flakyTxManager{failures: []error{
+		&pgconn.PgError{Code: "40001"},
+		&pgconn.PgError{Code: "40001"},
+	}}
+
+	calls, err := runRetrying(inner, 2)
+	var conflict *apierrors.ConcurrentUpdateError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, 2, inner.runs)
+}
+
+func TestRetryingTxManager_DoesNotRetryOtherErrors(t *testing.T) {
+	failure := errors.New("connection refused")
+	inner := &flakyTxManager{failures: []error{failure}}
+
+	_, err := runRetrying(inner, 3)
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 1, inner.runs)
+
+	inner = &flakyTxManager{failures: []error{&pgconn.PgError{Code: "23505"}}}
+	_, err = runRetrying(inner, 3)
+	assert.IsType(t, &pgconn.PgError{}, err)
+	assert.Equal(t, 1, inner.runs)
+}
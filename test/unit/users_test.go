@@ -0,0 +1,115 @@
+package unit
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"salesforge-assignment/internal/api"
+	apierrors "salesforge-assignment/internal/api-errors"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/service"
+	"testing"
+)
+
+// fakeCredentialsRepository keeps credentials in memory by username.
+type fakeCredentialsRepository struct {
+	repository.CredentialsRepository
+	users map[string]*model.CredentialsModel
+}
+
+func newFakeCredentialsRepository() *fakeCredentialsRepository {
+	return &fakeCredentialsRepository{users: map[string]*model.CredentialsModel{}}
+}
+
+func (r *fakeCredentialsRepository) GetCredentialsByUsername(_ context.Context, username string) (*model.CredentialsModel, error) {
+	user, ok := r.users[username]
+	if !ok {
+		return nil, &apierrors.ResourceNotFoundError{}
+	}
+	return user, nil
+}
+
+func (r *fakeCredentialsRepository) CreateCredentials(_ context.Context, credentials *model.CredentialsModel) error {
+	if _, ok := r.users[credentials.Username]; ok {
+		return &apierrors.ConflictError{Field: "username"}
+	}
+	r.users[credentials.Username] = credentials
+	return nil
+}
+
+func (r *fakeCredentialsRepository) UpdateCredentials(_ context.Context, username string, updates map[string]interface{}) error {
+	user, ok := r.users[username]
+	if !ok {
+		return &apierrors.ResourceNotFoundError{}
+	}
+	if password, ok := updates["password"]; ok {
+		user.Password = password.(string)
+	}
+	if disabled, ok := updates["disabled"]; ok {
+		user.Disabled = disabled.(bool)
+	}
+	return nil
+}
+
+func newTestUserService(repo repository.CredentialsRepository) service.UserService {
+	return service.NewUserService(repo)
+}
+
+func login(t *testing.T, repo repository.CredentialsRepository, username string, password string) error {
+	t.Helper()
+	log := zerolog.Nop()
+	cfg := config.Defaults()
+	cfg.Auth.JWTSecret = "secret"
+	formService := service.NewFormService(&log, repo, nil, nil, nil, cfg)
+	_, err := formService.LoginUser(context.Background(), api.Authentication{Username: username, Password: password})
+	return err
+}
+
+func TestUserService_CreateUserHashesPassword(t *testing.T) {
+	repo := newFakeCredentialsRepository()
+	users := newTestUserService(repo)
+
+	require.NoError(t, users.CreateUser(context.Background(), "alice", "s3cret"))
+	require.Contains(t, repo.users, "alice")
+	assert.NotEqual(t, "s3cret", repo.users["alice"].Password)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(repo.users["alice"].Password), []byte("s3cret")))
+	assert.NoError(t, login(t, repo, "alice", "s3cret"))
+
+	var conflict *apierrors.ConflictError
+	assert.ErrorAs(t, users.CreateUser(context.Background(), "alice", "other"), &conflict)
+}
+
+func TestUserService_RejectsInvalidInput(t *testing.T) {
+	users := newTestUserService(newFakeCredentialsRepository())
+
+	var invalid *apierrors.InvalidInputError
+	assert.ErrorAs(t, users.CreateUser(context.Background(), " ", "s3cret"), &invalid)
+	assert.ErrorAs(t, users.CreateUser(context.Background(), "alice", ""), &invalid)
+}
+
+func TestUserService_ResetPassword(t *testing.T) {
+	repo := newFakeCredentialsRepository()
+	users := newTestUserService(repo)
+	require.NoError(t, users.CreateUser(context.Background(), "alice", "old"))
+
+	require.NoError(t, users.ResetPassword(context.Background(), "alice", "new"))
+	assert.Error(t, login(t, repo, "alice", "old"))
+	assert.NoError(t, login(t, repo, "alice", "new"))
+
+	var notFound *apierrors.ResourceNotFoundError
+	assert.ErrorAs(t, users.ResetPassword(context.Background(), "bob", "new"), &notFound)
+}
+
+func TestUserService_DisabledUserCannotLogIn(t *testing.T) {
+	repo := newFakeCredentialsRepository()
+	users := newTestUserService(repo)
+	require.NoError(t, users.CreateUser(context.Background(), "alice", "s3cret"))
+
+	require.NoError(t, users.DisableUser(context.Background(), "alice"))
+	var invalid *apierrors.InvalidCredentialsError
+	assert.ErrorAs(t, login(t, repo, "alice", "s3cret"), &invalid)
+}
@@ -0,0 +1,188 @@
+package unit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"salesforge-assignment/internal/config"
+	"salesforge-assignment/internal/logger"
+	"salesforge-assignment/internal/model"
+	"salesforge-assignment/internal/repository"
+	"salesforge-assignment/internal/retry"
+	"salesforge-assignment/internal/webhook"
+	"testing"
+	"time"
+)
+
+func TestWebhookSignature_SignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"form.created"}`)
+	header := webhook.Sign("webhook-secret", time.Now(), body)
+
+	assert.NoError(t, webhook.Verify("webhook-secret", header, body, time.Minute))
+	assert.ErrorIs(t, webhook.Verify("other-secret", header, body, time.Minute), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("webhook-secret", header, []byte(`{}`), time.Minute), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("webhook-secret", "garbage", body, 0), webhook.ErrInvalidSignature)
+}
+
+func TestWebhookSignature_RejectsStaleTimestamps(t *testing.T) {
+	body := []byte(`{}`)
+	header := webhook.Sign("webhook-secret", time.Now().Add(-time.Hour), body)
+
+	assert.ErrorIs(t, webhook.Verify("webhook-secret", header, body, 5*time.Minute), webhook.ErrInvalidSignature)
+	assert.NoError(t, webhook.Verify("webhook-secret", header, body, 0))
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, expected := range map[int]time.Duration{
+		1:  10 * time.Second,
+		2:  20 * time.Second,
+		4:  80 * time.Second,
+		30: time.Hour,
+	} {
+		delay := retry.Backoff(attempt, 10*time.Second, time.Hour)
+		assert.LessOrEqual(t, delay, expected, "attempt %d", attempt)
+		assert.GreaterOrEqual(t, delay, expected*4/5, "attempt %d", attempt)
+	}
+}
+
+type fakeWebhookRepository struct {
+	repository.WebhookRepository
+	due      []model.WebhookDeliveryModel
+	recorded []model.WebhookDeliveryModel
+	attempts []model.WebhookDeliveryAttemptModel
+}
+
+func (r *fakeWebhookRepository) ClaimDueDeliveries(context.Context, int, time.Duration) ([]model.WebhookDeliveryModel, error) {
+	due := r.due
+	r.due = nil
+	return due, nil
+}
+
+func (r *fakeWebhookRepository) RecordAttempt(
+	_ context.Context,
+	delivery *model.WebhookDeliveryModel,
+	attempt *model.WebhookDeliveryAttemptModel,
+) error {
+	r.recorded = append(r.recorded, *delivery)
+	r.attempts = append(r.attempts, *attempt)
+	return nil
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	log := logger.InitLogger(config.LogConfig{Level: "panic"})
+	cfg := config.WebhooksConfig{MaxAttempts: 2, InitialBackoff: time.Minute, MaxBackoff: time.Hour, AllowedNetworks: []string{"127.0.0.1"}}
+
+	status := http.StatusOK
+	var signature string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if webhook.Verify("webhook-secret", r.Header.Get(webhook.SignatureHeader), body, time.Minute) == nil {
+			signature = r.Header.Get(webhook.SignatureHeader)
+		}
+		w.WriteHeader(status)
+	}))
+	defer receiver.Close()
+
+	newDelivery := func(attempts int) model.WebhookDeliveryModel {
+		return model.WebhookDeliveryModel{
+			ID:        "delivery1",
+			WebhookID: "webhook1",
+			EventType: "form.created",
+			Payload:   `{"type":"form.created"}`,
+			Status:    model.WebhookDeliveryPending,
+			Attempts:  attempts,
+			Webhook:   &model.WebhookModel{ID: "webhook1", URL: receiver.URL, Secret: "webhook-secret"},
+		}
+	}
+
+	t.Run("successful delivery", func(t *testing.T) {
+		repo := &fakeWebhookRepository{due: []model.WebhookDeliveryModel{newDelivery(0)}}
+		assert.Equal(t, 1, webhook.NewDispatcher(log, repo, cfg).DispatchDue(context.Background()))
+
+		assert.NotEmpty(t, signature)
+		assert.Equal(t, model.WebhookDeliverySucceeded, repo.recorded[0].Status)
+		assert.Equal(t, http.StatusOK, *repo.attempts[0].StatusCode)
+	})
+
+	t.Run("failed delivery is retried", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		repo := &fakeWebhookRepository{due: []model.WebhookDeliveryModel{newDelivery(0)}}
+		webhook.NewDispatcher(log, repo, cfg).DispatchDue(context.Background())
+
+		assert.Equal(t, model.WebhookDeliveryPending, repo.recorded[0].Status)
+		assert.Equal(t, 1, repo.recorded[0].Attempts)
+		assert.True(t, repo.recorded[0].NextAttemptAt.After(time.Now().Add(30*time.Second)))
+		assert.Contains(t, repo.attempts[0].Error, "500")
+	})
+
+	t.Run("exhausted delivery is dead-lettered", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		repo := &fakeWebhookRepository{due: []model.WebhookDeliveryModel{newDelivery(1)}}
+		webhook.NewDispatcher(log, repo, cfg).DispatchDue(context.Background())
+
+		assert.Equal(t, model.WebhookDeliveryDead, repo.recorded[0].Status)
+		assert.Equal(t, 2, repo.recorded[0].Attempts)
+	})
+
+	t.Run("non-public receiver is refused", func(t *testing.T) {
+		status = http.StatusOK
+		signature = ""
+		repo := &fakeWebhookRepository{due: []model.WebhookDeliveryModel{newDelivery(0)}}
+		webhook.NewDispatcher(log, repo, config.WebhooksConfig{MaxAttempts: 2}).DispatchDue(context.Background())
+
+		assert.Empty(t, signature)
+		assert.Equal(t, model.WebhookDeliveryPending, repo.recorded[0].Status)
+		assert.Contains(t, repo.attempts[0].Error, "not a public address")
+	})
+}
+
+func TestWebhookDispatcher_PausesWhileDisabled(t *testing.T) {
+	log := logger.InitLogger(config.LogConfig{Level: "panic"})
+	repo := &fakeWebhookRepository{due: []model.WebhookDeliveryModel{{ID: "delivery1"}}}
+	dispatcher := webhook.NewDispatcher(log, repo, config.WebhooksConfig{PollInterval: 5 * time.Millisecond})
+	dispatcher.SetEnabled(func() bool { return false })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	dispatcher.Run(ctx)
+
+	assert.Len(t, repo.due, 1, "due deliveries are not claimed while paused")
+	assert.Empty(t, repo.recorded)
+}
+
+func TestWebhookDispatcher_RefusesNonPublicTargets(t *testing.T) {
+	log := logger.InitLogger(config.LogConfig{Level: "panic"})
+
+	for _, target := range []string{
+		"127.0.0.1",
+		"10.1.2.3",
+		"169.254.169.254",
+		"100.64.0.1",
+		"100.127.255.254",
+		"192.0.0.8",
+		"198.18.0.1",
+		"198.19.255.254",
+		"0.0.0.1",
+		"[::ffff:10.0.0.1]",
+		"[64:ff9b::a00:1]",
+		"[64:ff9b:1::a00:1]",
+		"[2002:a00:1::1]",
+		"[fd00::1]",
+	} {
+		t.Run(target, func(t *testing.T) {
+			repo := &fakeWebhookRepository{due: []model.WebhookDeliveryModel{{
+				ID:      "delivery1",
+				Payload: `{}`,
+				Status:  model.WebhookDeliveryPending,
+				Webhook: &model.WebhookModel{ID: "webhook1", URL: "http://" + target + "/hook", Secret: "webhook-secret"},
+			}}}
+			webhook.NewDispatcher(log, repo, config.WebhooksConfig{MaxAttempts: 2, Timeout: time.Second}).DispatchDue(context.Background())
+
+			if assert.Len(t, repo.attempts, 1) {
+				assert.Contains(t, repo.attempts[0].Error, "not a public address")
+			}
+		})
+	}
+}